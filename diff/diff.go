@@ -0,0 +1,205 @@
+package diff
+
+// Kind is whether a line of a diff is in both inputs, only the old input, or only the new input
+type Kind int
+
+const (
+	// Equal lines are in both the old and new input
+	Equal Kind = iota
+	// Deleted lines are only in the old input
+	Deleted
+	// Inserted lines are only in the new input
+	Inserted
+)
+
+// Line is a single line of a diff
+type Line struct {
+	Kind Kind
+
+	// OldIdx is the index of the line in the old input, or -1 if Inserted
+	OldIdx int
+
+	// NewIdx is the index of the line in the new input, or -1 if Deleted
+	NewIdx int
+
+	// Text is the content of the line
+	Text string
+}
+
+// Lines computes the line diff between oldLines and newLines from their longest common subsequence, using Myers'
+// linear space algorithm. Within each run of changes, deleted lines come before inserted lines. Time is proportional to
+// the number of lines times the number of changed lines, and memory to the number of lines
+func Lines(oldLines, newLines []string) []Line {
+	var s script
+	s.diff(oldLines, newLines, 0, 0)
+	s.flush()
+	return s.lines
+}
+
+// script builds the lines of a diff, holding back the changes since the last equal line so deleted lines can be put
+// before inserted ones
+type script struct {
+	lines    []Line
+	deleted  []Line
+	inserted []Line
+}
+
+// equal adds a line in both inputs, after the changes before it
+func (s *script) equal(oldIdx, newIdx int, text string) {
+	s.flush()
+	s.lines = append(s.lines, Line{Kind: Equal, OldIdx: oldIdx, NewIdx: newIdx, Text: text})
+}
+
+// flush adds the changes held back
+func (s *script) flush() {
+	s.lines = append(s.lines, s.deleted...)
+	s.lines = append(s.lines, s.inserted...)
+	s.deleted, s.inserted = s.deleted[:0], s.inserted[:0]
+}
+
+// diff adds the lines of the diff between a and b, which start at oldOffset and newOffset in the inputs, by splitting
+// them around the middle snake of their shortest edit script
+func (s *script) diff(a, b []string, oldOffset, newOffset int) {
+	prefix := 0
+	for prefix < len(a) && prefix < len(b) && a[prefix] == b[prefix] {
+		s.equal(oldOffset+prefix, newOffset+prefix, a[prefix])
+		prefix++
+	}
+	suffix := 0
+	for suffix < len(a)-prefix && suffix < len(b)-prefix && a[len(a)-1-suffix] == b[len(b)-1-suffix] {
+		suffix++
+	}
+	common := a[len(a)-suffix:]
+	a, b = a[prefix:len(a)-suffix], b[prefix:len(b)-suffix]
+	oldOffset, newOffset = oldOffset+prefix, newOffset+prefix
+
+	switch {
+	case len(a) == 0:
+		for j, text := range b {
+			s.inserted = append(s.inserted, Line{Kind: Inserted, OldIdx: -1, NewIdx: newOffset + j, Text: text})
+		}
+	case len(b) == 0:
+		for i, text := range a {
+			s.deleted = append(s.deleted, Line{Kind: Deleted, OldIdx: oldOffset + i, NewIdx: -1, Text: text})
+		}
+	default:
+		x, y, u, v := middleSnake(a, b)
+		s.diff(a[:x], b[:y], oldOffset, newOffset)
+		for k := range u - x {
+			s.equal(oldOffset+x+k, newOffset+y+k, a[x+k])
+		}
+		s.diff(a[u:], b[v:], oldOffset+u, newOffset+v)
+	}
+
+	for k, text := range common {
+		s.equal(oldOffset+len(a)+k, newOffset+len(b)+k, text)
+	}
+}
+
+// middleSnake returns the bounds of the middle snake of the shortest edit script of a and b, the run of equal lines
+// a[x:u] and b[y:v] that the forward and reverse searches for it meet on. a and b must not be empty
+func middleSnake(a, b []string) (x, y, u, v int) {
+	n, m := len(a), len(b)
+	delta := n - m
+	maxD := (n + m + 1) / 2
+	offset := maxD + 1
+	// forward[offset+k] is the furthest x reached from the start on diagonal k = x-y, and reverse[offset+k] the
+	// furthest distance reached from the end on diagonal k of the reversed inputs
+	forward := make([]int, 2*offset+1)
+	reverse := make([]int, 2*offset+1)
+	for d := 0; d <= maxD; d++ {
+		for k := -d; k <= d; k += 2 {
+			x := forward[offset+k+1]
+			if k != -d && (k == d || forward[offset+k-1] >= forward[offset+k+1]) {
+				x = forward[offset+k-1] + 1
+			}
+			y := x - k
+			startX, startY := x, y
+			for x < n && y < m && a[x] == b[y] {
+				x++
+				y++
+			}
+			forward[offset+k] = x
+			if delta%2 != 0 && delta-k >= -(d-1) && delta-k <= d-1 && x+reverse[offset+delta-k] >= n {
+				return startX, startY, x, y
+			}
+		}
+		for k := -d; k <= d; k += 2 {
+			x := reverse[offset+k+1]
+			if k != -d && (k == d || reverse[offset+k-1] >= reverse[offset+k+1]) {
+				x = reverse[offset+k-1] + 1
+			}
+			y := x - k
+			startX, startY := x, y
+			for x < n && y < m && a[n-1-x] == b[m-1-y] {
+				x++
+				y++
+			}
+			reverse[offset+k] = x
+			if delta%2 == 0 && delta-k >= -d && delta-k <= d && x+forward[offset+delta-k] >= n {
+				return n - x, m - y, n - startX, m - startY
+			}
+		}
+	}
+	// unreachable, as the searches meet by the time each has made half of at most n+m edits
+	return 0, 0, 0, 0
+}
+
+// Hunk is a run of consecutive changed lines in a diff
+type Hunk struct {
+	// Start is the index of the first line of the hunk
+	Start int
+
+	// End is the index just after the last line of the hunk
+	End int
+}
+
+// Hunks returns the runs of consecutive Deleted and Inserted lines
+func Hunks(lines []Line) []Hunk {
+	var hunks []Hunk
+	for i := 0; i < len(lines); i++ {
+		if lines[i].Kind == Equal {
+			continue
+		}
+		start := i
+		for i < len(lines) && lines[i].Kind != Equal {
+			i++
+		}
+		hunks = append(hunks, Hunk{Start: start, End: i})
+	}
+	return hunks
+}
+
+// pairs returns, for each line, the index of the line it replaced or was replaced by, or -1. Within a hunk, the nth
+// deleted line is paired with the nth inserted line
+func pairs(lines []Line, hunks []Hunk) []int {
+	res := make([]int, len(lines))
+	for i := range res {
+		res[i] = -1
+	}
+	for _, h := range hunks {
+		firstInserted := h.Start
+		for firstInserted < h.End && lines[firstInserted].Kind == Deleted {
+			firstInserted++
+		}
+		numDeleted, numInserted := firstInserted-h.Start, h.End-firstInserted
+		for k := range min(numDeleted, numInserted) {
+			res[h.Start+k] = firstInserted + k
+			res[firstInserted+k] = h.Start + k
+		}
+	}
+	return res
+}
+
+// changedRange returns the range of runes of a that differ from b, excluding their common prefix and suffix
+func changedRange(a, b []rune) (int, int) {
+	prefix := 0
+	for prefix < len(a) && prefix < len(b) && a[prefix] == b[prefix] {
+		prefix++
+	}
+	suffix := 0
+	for suffix < len(a)-prefix && suffix < len(b)-prefix && a[len(a)-1-suffix] == b[len(b)-1-suffix] {
+		suffix++
+	}
+	return prefix, len(a) - suffix
+}
@@ -0,0 +1,183 @@
+package diff
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestLines(t *testing.T) {
+	tests := []struct {
+		name     string
+		old      []string
+		new      []string
+		expected []Line
+	}{
+		{
+			name: "empty",
+		},
+		{
+			name: "equal",
+			old:  []string{"a", "b"},
+			new:  []string{"a", "b"},
+			expected: []Line{
+				{Kind: Equal, OldIdx: 0, NewIdx: 0, Text: "a"},
+				{Kind: Equal, OldIdx: 1, NewIdx: 1, Text: "b"},
+			},
+		},
+		{
+			name: "all inserted",
+			new:  []string{"a"},
+			expected: []Line{
+				{Kind: Inserted, OldIdx: -1, NewIdx: 0, Text: "a"},
+			},
+		},
+		{
+			name: "all deleted",
+			old:  []string{"a"},
+			expected: []Line{
+				{Kind: Deleted, OldIdx: 0, NewIdx: -1, Text: "a"},
+			},
+		},
+		{
+			name: "changes between common lines",
+			old:  []string{"a", "b", "c", "d", "e"},
+			new:  []string{"a", "x", "c", "e", "f"},
+			expected: []Line{
+				{Kind: Equal, OldIdx: 0, NewIdx: 0, Text: "a"},
+				{Kind: Deleted, OldIdx: 1, NewIdx: -1, Text: "b"},
+				{Kind: Inserted, OldIdx: -1, NewIdx: 1, Text: "x"},
+				{Kind: Equal, OldIdx: 2, NewIdx: 2, Text: "c"},
+				{Kind: Deleted, OldIdx: 3, NewIdx: -1, Text: "d"},
+				{Kind: Equal, OldIdx: 4, NewIdx: 3, Text: "e"},
+				{Kind: Inserted, OldIdx: -1, NewIdx: 4, Text: "f"},
+			},
+		},
+		{
+			name: "moved line",
+			old:  []string{"a", "b", "c"},
+			new:  []string{"b", "c", "a"},
+			expected: []Line{
+				{Kind: Deleted, OldIdx: 0, NewIdx: -1, Text: "a"},
+				{Kind: Equal, OldIdx: 1, NewIdx: 0, Text: "b"},
+				{Kind: Equal, OldIdx: 2, NewIdx: 1, Text: "c"},
+				{Kind: Inserted, OldIdx: -1, NewIdx: 2, Text: "a"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if diff := cmp.Diff(tt.expected, Lines(tt.old, tt.new)); diff != "" {
+				t.Errorf("Diff (-expected +actual):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestLines_ShortestEditScript(t *testing.T) {
+	tests := []struct {
+		name string
+		old  string
+		new  string
+	}{
+		{name: "myers paper example", old: "abcabba", new: "cbabac"},
+		{name: "odd difference in length", old: "abcabbax", new: "cbabac"},
+		{name: "nothing in common", old: "abc", new: "xyz"},
+		{name: "repeated lines", old: "aaabaaa", new: "aabaaaab"},
+		{name: "interleaved", old: "axbxcxdx", new: "ybyyydyc"},
+		{name: "long", old: strings.Repeat("abcde", 20), new: strings.Repeat("aebdc", 25)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			oldLines, newLines := strings.Split(tt.old, ""), strings.Split(tt.new, "")
+			lines := Lines(oldLines, newLines)
+
+			var gotOld, gotNew []string
+			numEqual := 0
+			for i, line := range lines {
+				if line.Kind != Deleted {
+					gotNew = append(gotNew, line.Text)
+				}
+				if line.Kind != Inserted {
+					gotOld = append(gotOld, line.Text)
+				}
+				if line.Kind == Equal {
+					numEqual++
+				}
+				if i > 0 && line.Kind == Deleted && lines[i-1].Kind == Inserted {
+					t.Errorf("deleted line %d after inserted line", i)
+				}
+			}
+			if diff := cmp.Diff(oldLines, gotOld); diff != "" {
+				t.Errorf("old lines Diff (-expected +actual):\n%s", diff)
+			}
+			if diff := cmp.Diff(newLines, gotNew); diff != "" {
+				t.Errorf("new lines Diff (-expected +actual):\n%s", diff)
+			}
+			if expected := lcsLength(oldLines, newLines); numEqual != expected {
+				t.Errorf("expected %d equal lines, got %d", expected, numEqual)
+			}
+		})
+	}
+}
+
+// lcsLength returns the length of the longest common subsequence of a and b
+func lcsLength(a, b []string) int {
+	prev, cur := make([]int, len(b)+1), make([]int, len(b)+1)
+	for i := range a {
+		for j := range b {
+			if a[i] == b[j] {
+				cur[j+1] = prev[j] + 1
+			} else {
+				cur[j+1] = max(prev[j+1], cur[j])
+			}
+		}
+		prev, cur = cur, prev
+	}
+	return prev[len(b)]
+}
+
+func TestHunksAndPairs(t *testing.T) {
+	lines := Lines(
+		[]string{"a", "b1", "b2", "c", "d"},
+		[]string{"a", "x1", "c", "d", "y1", "y2"},
+	)
+	hunks := Hunks(lines)
+	expectedHunks := []Hunk{{Start: 1, End: 4}, {Start: 6, End: 8}}
+	if diff := cmp.Diff(expectedHunks, hunks); diff != "" {
+		t.Errorf("Diff (-expected +actual):\n%s", diff)
+	}
+
+	// b1 is paired with x1, b2 has no pair
+	expectedPairs := []int{-1, 3, -1, 1, -1, -1, -1, -1}
+	if diff := cmp.Diff(expectedPairs, pairs(lines, hunks)); diff != "" {
+		t.Errorf("Diff (-expected +actual):\n%s", diff)
+	}
+}
+
+func TestChangedRange(t *testing.T) {
+	tests := []struct {
+		a, b          string
+		expectedStart int
+		expectedEnd   int
+	}{
+		{a: "count = 1", b: "count = 3", expectedStart: 8, expectedEnd: 9},
+		{a: "image: redis", b: "image: redis:7", expectedStart: 12, expectedEnd: 12},
+		{a: "image: redis:7", b: "image: redis", expectedStart: 12, expectedEnd: 14},
+		{a: "aaa", b: "aa", expectedStart: 2, expectedEnd: 3},
+		{a: "abc", b: "xyz", expectedStart: 0, expectedEnd: 3},
+		{a: "héllo", b: "hallo", expectedStart: 1, expectedEnd: 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.a+"/"+tt.b, func(t *testing.T) {
+			start, end := changedRange([]rune(tt.a), []rune(tt.b))
+			if start != tt.expectedStart || end != tt.expectedEnd {
+				t.Errorf("expected [%d, %d), got [%d, %d)", tt.expectedStart, tt.expectedEnd, start, end)
+			}
+		})
+	}
+}
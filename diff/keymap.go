@@ -0,0 +1,15 @@
+package diff
+
+import "github.com/charmbracelet/bubbles/v2/key"
+
+// KeyMap contains diff key bindings in addition to the viewport's navigation key bindings
+type KeyMap struct {
+	// NextHunk goes to the next run of changed lines
+	NextHunk key.Binding
+	// PrevHunk goes to the previous run of changed lines
+	PrevHunk key.Binding
+	// ToggleMode switches between the unified and side-by-side views
+	ToggleMode key.Binding
+	// SwitchSide switches which side of the side-by-side view receives horizontal panning when not shared
+	SwitchSide key.Binding
+}
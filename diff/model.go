@@ -0,0 +1,560 @@
+package diff
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/v2/key"
+	tea "github.com/charmbracelet/bubbletea/v2"
+	"github.com/charmbracelet/lipgloss/v2"
+	"github.com/robinovitch61/bubbleo/viewport"
+	"github.com/robinovitch61/bubbleo/viewport/linebuffer"
+)
+
+// Mode is how the diff is laid out
+type Mode int
+
+const (
+	// Unified shows deleted and inserted lines interleaved in a single viewport
+	Unified Mode = iota
+	// SideBySide shows the old input on the left and the new input on the right, with changed lines aligned
+	SideBySide
+)
+
+// Side is a side of the side-by-side view
+type Side int
+
+const (
+	// OldSide is the left side, showing the old input
+	OldSide Side = iota
+	// NewSide is the right side, showing the new input
+	NewSide
+)
+
+const (
+	equalMarker    = "  "
+	deletedMarker  = "- "
+	insertedMarker = "+ "
+)
+
+// Styles contains styling configuration for changed lines
+type Styles struct {
+	// DeletedStyle styles deleted lines
+	DeletedStyle lipgloss.Style
+	// InsertedStyle styles inserted lines
+	InsertedStyle lipgloss.Style
+	// DeletedChangeStyle styles the part of a deleted line that differs from the inserted line that replaced it
+	DeletedChangeStyle lipgloss.Style
+	// InsertedChangeStyle styles the part of an inserted line that differs from the deleted line it replaced
+	InsertedChangeStyle lipgloss.Style
+	// SeparatorStyle styles the separator between the sides of the side-by-side view
+	SeparatorStyle lipgloss.Style
+}
+
+// row is a single rendered row of one side of the diff
+type row struct {
+	// lineIdx is the index of the diff line shown in the row, or of the line across from it for empty filler rows in
+	// the side-by-side view
+	lineIdx int
+
+	lineBuffer linebuffer.LineBufferer
+}
+
+// Render returns the rendered row for the viewport
+func (r row) Render() linebuffer.LineBufferer {
+	return r.lineBuffer
+}
+
+// assert row implements viewport.Renderable
+var _ viewport.Renderable = row{}
+
+// Model represents a diff viewer component, showing the line diff between two inputs in a unified or side-by-side view
+type Model struct {
+	// left shows the unified view, or the old side of the side-by-side view
+	left viewport.Model[row]
+
+	// right shows the new side of the side-by-side view
+	right viewport.Model[row]
+
+	// keyMap contains the diff key bindings
+	keyMap KeyMap
+
+	// styles contains the styles for changed lines
+	styles Styles
+
+	// mode is the current view
+	mode Mode
+
+	// focusedSide is the side of the side-by-side view that receives key input
+	focusedSide Side
+
+	// sharedPan is true if both sides of the side-by-side view pan horizontally together
+	sharedPan bool
+
+	// separator is placed between the sides of the side-by-side view
+	separator string
+
+	// width and height are the dimensions of the whole component
+	width, height int
+
+	// lines is the diff between the inputs
+	lines []Line
+
+	// hunks are the runs of changed lines
+	hunks []Hunk
+
+	// pairs maps each line to the line it replaced or was replaced by, or -1
+	pairs []int
+
+	// leftRows and rightRows are the rows of the left and right viewports
+	leftRows, rightRows []row
+
+	// rowByLine maps each line to the row showing it in the current view
+	rowByLine []int
+
+	// hunkStartRows are the rows where each hunk starts in the current view
+	hunkStartRows []int
+}
+
+// New creates a new diff model with reasonable defaults, showing the unified view
+func New(width, height int, keyMap viewport.KeyMap, styles viewport.Styles) (m Model) {
+	m.left = viewport.New[row](width, height, keyMap, styles)
+	m.right = viewport.New[row](width, height, keyMap, styles)
+	m.sharedPan = true
+	m.separator = " │ "
+	m.width, m.height = width, height
+	m.setSizes()
+	m.applyFocus()
+	return m
+}
+
+// Update processes messages and updates the model. In the side-by-side view, keys go to the focused side and the
+// other side follows its vertical scroll, and its horizontal pan if shared
+func (m *Model) Update(msg tea.Msg) (Model, tea.Cmd) {
+	keyMsg, isKey := msg.(tea.KeyMsg)
+	if isKey {
+		switch {
+		case key.Matches(keyMsg, m.keyMap.NextHunk):
+			m.NextHunk()
+			return *m, nil
+		case key.Matches(keyMsg, m.keyMap.PrevHunk):
+			m.PrevHunk()
+			return *m, nil
+		case key.Matches(keyMsg, m.keyMap.ToggleMode):
+			if m.mode == Unified {
+				m.SetMode(SideBySide)
+			} else {
+				m.SetMode(Unified)
+			}
+			return *m, nil
+		case key.Matches(keyMsg, m.keyMap.SwitchSide):
+			if m.mode == SideBySide {
+				m.SetFocusedSide(1 - m.focusedSide)
+			}
+			return *m, nil
+		}
+	}
+
+	var cmd tea.Cmd
+	if m.mode == Unified {
+		m.left, cmd = m.left.Update(msg)
+		return *m, cmd
+	}
+
+	var leftCmd, rightCmd tea.Cmd
+	if !isKey || m.focusedSide == OldSide {
+		m.left, leftCmd = m.left.Update(msg)
+	}
+	if !isKey || m.focusedSide == NewSide {
+		m.right, rightCmd = m.right.Update(msg)
+	}
+	m.sync()
+	return *m, tea.Batch(leftCmd, rightCmd)
+}
+
+// View renders the diff
+func (m *Model) View() string {
+	if m.mode == Unified {
+		return m.left.View()
+	}
+	leftLines := strings.Split(m.left.View(), "\n")
+	rightLines := strings.Split(m.right.View(), "\n")
+	separator := m.styles.SeparatorStyle.Render(m.separator)
+	var builder strings.Builder
+	for i := range leftLines {
+		if i > 0 {
+			builder.WriteString("\n")
+		}
+		builder.WriteString(leftLines[i])
+		builder.WriteString(separator)
+		if i < len(rightLines) {
+			builder.WriteString(rightLines[i])
+		}
+	}
+	return builder.String()
+}
+
+// SetContent sets the inputs to diff
+func (m *Model) SetContent(oldLines, newLines []string) {
+	m.lines = Lines(oldLines, newLines)
+	m.hunks = Hunks(m.lines)
+	m.pairs = pairs(m.lines, m.hunks)
+	m.render()
+}
+
+// GetLines returns the diff between the inputs
+func (m *Model) GetLines() []Line {
+	return m.lines
+}
+
+// GetHunks returns the runs of changed lines in the diff
+func (m *Model) GetHunks() []Hunk {
+	return m.hunks
+}
+
+// SetDiffKeyMap sets the diff key bindings
+func (m *Model) SetDiffKeyMap(keyMap KeyMap) {
+	m.keyMap = keyMap
+}
+
+// SetDiffStyles sets the styles for changed lines
+func (m *Model) SetDiffStyles(styles Styles) {
+	m.styles = styles
+	m.render()
+}
+
+// SetMode sets the view, keeping the current line in place
+func (m *Model) SetMode(mode Mode) {
+	if mode == m.mode {
+		return
+	}
+	lineIdx := -1
+	if rows := m.focusedRows(); len(rows) > 0 {
+		lineIdx = rows[m.currentRowIdx()].lineIdx
+	}
+
+	m.mode = mode
+	m.focusedSide = OldSide
+	m.applyFocus()
+	m.setSizes()
+	m.render()
+
+	if lineIdx >= 0 {
+		rowIdx := m.rowByLine[lineIdx]
+		if m.left.GetSelectionEnabled() {
+			m.left.SetSelectedItemIdx(rowIdx)
+		} else {
+			m.left.SetTopItemIdx(rowIdx, 0)
+		}
+		m.sync()
+	}
+}
+
+// GetMode returns the current view
+func (m *Model) GetMode() Mode {
+	return m.mode
+}
+
+// SetFocusedSide sets the side of the side-by-side view that receives key input
+func (m *Model) SetFocusedSide(side Side) {
+	m.focusedSide = side
+	m.applyFocus()
+}
+
+// GetFocusedSide returns the side of the side-by-side view that receives key input
+func (m *Model) GetFocusedSide() Side {
+	return m.focusedSide
+}
+
+// SetSharedPan sets whether both sides of the side-by-side view pan horizontally together. If not, only the focused
+// side pans
+func (m *Model) SetSharedPan(sharedPan bool) {
+	m.sharedPan = sharedPan
+	m.sync()
+}
+
+// SetSeparator sets the separator between the sides of the side-by-side view
+func (m *Model) SetSeparator(separator string) {
+	m.separator = separator
+	m.setSizes()
+}
+
+// NextHunk goes to the start of the next hunk after the current row, if any
+func (m *Model) NextHunk() {
+	current := m.currentRowIdx()
+	for _, rowIdx := range m.hunkStartRows {
+		if rowIdx > current {
+			m.goToRow(rowIdx)
+			return
+		}
+	}
+}
+
+// PrevHunk goes to the start of the previous hunk before the current row, if any
+func (m *Model) PrevHunk() {
+	current := m.currentRowIdx()
+	for i := len(m.hunkStartRows) - 1; i >= 0; i-- {
+		if m.hunkStartRows[i] < current {
+			m.goToRow(m.hunkStartRows[i])
+			return
+		}
+	}
+}
+
+// GetSelectedLine returns the diff line of the selected row, or the line across from it for empty filler rows in the
+// side-by-side view. Returns nil if selection is disabled or there are no lines
+func (m *Model) GetSelectedLine() *Line {
+	r := m.focused().GetSelectedItem()
+	if r == nil {
+		return nil
+	}
+	return &m.lines[r.lineIdx]
+}
+
+// SetWidth sets the width of the whole component
+func (m *Model) SetWidth(width int) {
+	m.width = width
+	m.setSizes()
+}
+
+// SetHeight sets the height of the whole component
+func (m *Model) SetHeight(height int) {
+	m.height = height
+	m.setSizes()
+}
+
+// GetWidth returns the width of the whole component
+func (m *Model) GetWidth() int {
+	return m.width
+}
+
+// GetHeight returns the height of the whole component
+func (m *Model) GetHeight() int {
+	return m.height
+}
+
+// SetKeyMap sets the viewport navigation key bindings
+func (m *Model) SetKeyMap(keyMap viewport.KeyMap) {
+	m.left.SetKeyMap(keyMap)
+	m.right.SetKeyMap(keyMap)
+}
+
+// SetStyles sets the viewport styles
+func (m *Model) SetStyles(styles viewport.Styles) {
+	m.left.SetStyles(styles)
+	m.right.SetStyles(styles)
+}
+
+// SetBlurredStyles sets the viewport styles for the side of the side-by-side view that isn't focused
+func (m *Model) SetBlurredStyles(styles viewport.Styles) {
+	m.left.SetBlurredStyles(styles)
+	m.right.SetBlurredStyles(styles)
+}
+
+// SetSelectionEnabled sets whether rows can be selected
+func (m *Model) SetSelectionEnabled(selectionEnabled bool) {
+	m.left.SetSelectionEnabled(selectionEnabled)
+	m.right.SetSelectionEnabled(selectionEnabled)
+	m.sync()
+}
+
+// GetSelectionEnabled returns whether rows can be selected
+func (m *Model) GetSelectionEnabled() bool {
+	return m.left.GetSelectionEnabled()
+}
+
+// SetFooterEnabled sets whether the footer is shown
+func (m *Model) SetFooterEnabled(footerEnabled bool) {
+	m.left.SetFooterEnabled(footerEnabled)
+	m.right.SetFooterEnabled(footerEnabled)
+}
+
+// SetStringToHighlight sets a string to highlight in the diff
+func (m *Model) SetStringToHighlight(h string) {
+	m.left.SetStringToHighlight(h)
+	m.right.SetStringToHighlight(h)
+}
+
+// SetRegexToHighlight sets a regex to highlight in the diff
+func (m *Model) SetRegexToHighlight(r *regexp.Regexp) {
+	m.left.SetRegexToHighlight(r)
+	m.right.SetRegexToHighlight(r)
+}
+
+// focused returns the viewport that receives key input
+func (m *Model) focused() *viewport.Model[row] {
+	if m.mode == SideBySide && m.focusedSide == NewSide {
+		return &m.right
+	}
+	return &m.left
+}
+
+// applyFocus focuses the viewport that receives key input and blurs the other
+func (m *Model) applyFocus() {
+	if m.focused() == &m.left {
+		m.left.Focus()
+		m.right.Blur()
+	} else {
+		m.left.Blur()
+		m.right.Focus()
+	}
+}
+
+// sync scrolls the unfocused side of the side-by-side view to match the focused side
+func (m *Model) sync() {
+	if m.mode != SideBySide {
+		return
+	}
+	src, dst := &m.left, &m.right
+	if m.focusedSide == NewSide {
+		src, dst = dst, src
+	}
+	if src.GetSelectionEnabled() {
+		dst.SetSelectedItemIdx(src.GetSelectedItemIdx())
+	}
+	dst.SetTopItemIdx(src.GetTopItemIdx())
+	if m.sharedPan {
+		dst.SetXOffset(src.GetXOffset())
+	}
+}
+
+// currentRowIdx returns the selected row, or the top row if selection is disabled
+func (m *Model) currentRowIdx() int {
+	if m.focused().GetSelectionEnabled() {
+		return m.focused().GetSelectedItemIdx()
+	}
+	topRowIdx, _ := m.focused().GetTopItemIdx()
+	return topRowIdx
+}
+
+// goToRow selects the row at rowIdx if selection is enabled and scrolls it to the top
+func (m *Model) goToRow(rowIdx int) {
+	m.focused().ScrollToItem(rowIdx, viewport.AlignTop)
+	m.sync()
+}
+
+// focusedRows returns the rows of the viewport that receives key input
+func (m *Model) focusedRows() []row {
+	if m.mode == SideBySide && m.focusedSide == NewSide {
+		return m.rightRows
+	}
+	return m.leftRows
+}
+
+func (m *Model) setSizes() {
+	if m.mode == Unified {
+		m.left.SetWidth(m.width)
+		m.left.SetHeight(m.height)
+		return
+	}
+	available := max(0, m.width-lipgloss.Width(m.separator))
+	leftWidth := available / 2
+	m.left.SetWidth(leftWidth)
+	m.left.SetHeight(m.height)
+	m.right.SetWidth(available - leftWidth)
+	m.right.SetHeight(m.height)
+}
+
+func (m *Model) render() {
+	var leftRows, rightRows []row
+	m.rowByLine = make([]int, len(m.lines))
+	m.hunkStartRows = nil
+
+	if m.mode == Unified {
+		for lineIdx := range m.lines {
+			m.rowByLine[lineIdx] = lineIdx
+			leftRows = append(leftRows, row{lineIdx: lineIdx, lineBuffer: linebuffer.New(m.renderLine(lineIdx))})
+		}
+		for _, h := range m.hunks {
+			m.hunkStartRows = append(m.hunkStartRows, h.Start)
+		}
+		m.leftRows, m.rightRows = leftRows, nil
+		m.left.SetContent(leftRows)
+		m.right.SetContent(nil)
+		return
+	}
+
+	for lineIdx := 0; lineIdx < len(m.lines); {
+		if m.lines[lineIdx].Kind == Equal {
+			m.rowByLine[lineIdx] = len(leftRows)
+			r := row{lineIdx: lineIdx, lineBuffer: linebuffer.New(m.renderLine(lineIdx))}
+			leftRows = append(leftRows, r)
+			rightRows = append(rightRows, r)
+			lineIdx++
+			continue
+		}
+
+		// align the deleted lines of the hunk on the left with its inserted lines on the right
+		m.hunkStartRows = append(m.hunkStartRows, len(leftRows))
+		hunkEnd := lineIdx
+		for hunkEnd < len(m.lines) && m.lines[hunkEnd].Kind != Equal {
+			hunkEnd++
+		}
+		firstInserted := lineIdx
+		for firstInserted < hunkEnd && m.lines[firstInserted].Kind == Deleted {
+			firstInserted++
+		}
+		numDeleted, numInserted := firstInserted-lineIdx, hunkEnd-firstInserted
+		for k := range max(numDeleted, numInserted) {
+			rowIdx := len(leftRows)
+			deletedIdx, insertedIdx := lineIdx+k, firstInserted+k
+			if k >= numDeleted {
+				deletedIdx = -1
+			}
+			if k >= numInserted {
+				insertedIdx = -1
+			}
+			leftRows = append(leftRows, m.sideRow(rowIdx, deletedIdx, insertedIdx))
+			rightRows = append(rightRows, m.sideRow(rowIdx, insertedIdx, deletedIdx))
+		}
+		lineIdx = hunkEnd
+	}
+	m.leftRows, m.rightRows = leftRows, rightRows
+	m.left.SetContent(leftRows)
+	m.right.SetContent(rightRows)
+	m.sync()
+}
+
+// sideRow returns the row at rowIdx of one side of the side-by-side view showing the line at lineIdx, or an empty
+// filler row across from the line at otherIdx if lineIdx is -1
+func (m *Model) sideRow(rowIdx, lineIdx, otherIdx int) row {
+	if lineIdx < 0 {
+		return row{lineIdx: otherIdx, lineBuffer: linebuffer.New("")}
+	}
+	m.rowByLine[lineIdx] = rowIdx
+	return row{lineIdx: lineIdx, lineBuffer: linebuffer.New(m.renderLine(lineIdx))}
+}
+
+// renderLine renders the line at lineIdx with its marker, highlighting the part of a changed line that differs from
+// the line it is paired with
+func (m *Model) renderLine(lineIdx int) string {
+	line := m.lines[lineIdx]
+	marker, style, changeStyle := equalMarker, lipgloss.NewStyle(), lipgloss.NewStyle()
+	switch line.Kind {
+	case Equal:
+		return marker + line.Text
+	case Deleted:
+		marker, style, changeStyle = deletedMarker, m.styles.DeletedStyle, m.styles.DeletedChangeStyle
+	case Inserted:
+		marker, style, changeStyle = insertedMarker, m.styles.InsertedStyle, m.styles.InsertedChangeStyle
+	}
+
+	runes := []rune(line.Text)
+	start, end := len(runes), len(runes)
+	if pairIdx := m.pairs[lineIdx]; pairIdx >= 0 {
+		// only highlight the change if the paired lines have something in common
+		if s, e := changedRange(runes, []rune(m.lines[pairIdx].Text)); s > 0 || e < len(runes) {
+			start, end = s, e
+		}
+	}
+	return renderNonEmpty(style, marker+string(runes[:start])) +
+		renderNonEmpty(changeStyle, string(runes[start:end])) +
+		renderNonEmpty(style, string(runes[end:]))
+}
+
+func renderNonEmpty(style lipgloss.Style, s string) string {
+	if s == "" {
+		return ""
+	}
+	return style.Render(s)
+}
@@ -0,0 +1,277 @@
+package diff
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/bubbles/v2/key"
+	tea "github.com/charmbracelet/bubbletea/v2"
+	"github.com/charmbracelet/lipgloss/v2"
+	"github.com/robinovitch61/bubbleo/internal/testutil"
+	"github.com/robinovitch61/bubbleo/viewport"
+)
+
+var (
+	downKeyMsg       = tea.KeyPressMsg{Code: 'j', Text: "j"}
+	leftKeyMsg       = tea.KeyPressMsg{Code: 'h', Text: "h"}
+	rightKeyMsg      = tea.KeyPressMsg{Code: 'l', Text: "l"}
+	nextHunkKeyMsg   = tea.KeyPressMsg{Code: 'n', Text: "n"}
+	prevHunkKeyMsg   = tea.KeyPressMsg{Code: 'n', Text: "N", Mod: tea.ModShift}
+	toggleModeKeyMsg = tea.KeyPressMsg{Code: 's', Text: "s"}
+	switchSideKeyMsg = tea.KeyPressMsg{Code: tea.KeyTab}
+	red              = lipgloss.Color("#ff0000")
+	green            = lipgloss.Color("#00ff00")
+	selectionStyle   = testutil.SelectionStyle
+)
+
+var (
+	oldSpec = []string{
+		"job {",
+		"  count = 1",
+		"  image = redis",
+		"}",
+	}
+	newSpec = []string{
+		"job {",
+		"  count = 3",
+		"  image = redis",
+		"  port = 80",
+		"}",
+	}
+)
+
+func newDiff(width, height int) Model {
+	km := viewport.KeyMap{
+		Up:    key.NewBinding(key.WithKeys("up", "k")),
+		Down:  key.NewBinding(key.WithKeys("down", "j")),
+		Left:  key.NewBinding(key.WithKeys("left", "h")),
+		Right: key.NewBinding(key.WithKeys("right", "l")),
+	}
+	styles := viewport.Styles{
+		FooterStyle:              lipgloss.NewStyle(),
+		HighlightStyle:           lipgloss.NewStyle(),
+		HighlightStyleIfSelected: lipgloss.NewStyle(),
+		SelectedItemStyle:        selectionStyle,
+	}
+	d := New(width, height, km, styles)
+	d.SetFooterEnabled(false)
+	d.SetDiffKeyMap(KeyMap{
+		NextHunk:   key.NewBinding(key.WithKeys("n")),
+		PrevHunk:   key.NewBinding(key.WithKeys("shift+n")),
+		ToggleMode: key.NewBinding(key.WithKeys("s")),
+		SwitchSide: key.NewBinding(key.WithKeys("tab")),
+	})
+	d.SetContent(oldSpec, newSpec)
+	return d
+}
+
+// sideBySide joins the padded lines of each side with the default separator
+func sideBySide(sideWidth, height int, left, right []string) string {
+	leftLines := strings.Split(testutil.Pad(sideWidth, height, left), "\n")
+	rightLines := strings.Split(testutil.Pad(sideWidth, height, right), "\n")
+	var res []string
+	for i := range leftLines {
+		res = append(res, leftLines[i]+" │ "+rightLines[i])
+	}
+	return strings.Join(res, "\n")
+}
+
+func TestDiff_Unified(t *testing.T) {
+	w, h := 20, 7
+	d := newDiff(w, h)
+	expectedView := testutil.Pad(w, h, []string{
+		"  job {",
+		"-   count = 1",
+		"+   count = 3",
+		"    image = redis",
+		"+   port = 80",
+		"  }",
+	})
+	testutil.CmpStr(t, expectedView, d.View())
+}
+
+func TestDiff_SideBySide(t *testing.T) {
+	w, h := 31, 6
+	d := newDiff(w, h)
+	d, _ = d.Update(toggleModeKeyMsg)
+	if d.GetMode() != SideBySide {
+		t.Fatalf("expected side-by-side mode")
+	}
+	expectedView := sideBySide(14, h, []string{
+		"  job {",
+		"-   count = 1",
+		"    image =...",
+		"",
+		"  }",
+	}, []string{
+		"  job {",
+		"+   count = 3",
+		"    image =...",
+		"+   port = 80",
+		"  }",
+	})
+	testutil.CmpStr(t, expectedView, d.View())
+}
+
+func TestDiff_IntraLineHighlighting(t *testing.T) {
+	w, h := 20, 7
+	d := newDiff(w, h)
+	d.SetDiffStyles(Styles{
+		DeletedStyle:        lipgloss.NewStyle().Foreground(red),
+		InsertedStyle:       lipgloss.NewStyle().Foreground(green),
+		DeletedChangeStyle:  lipgloss.NewStyle().Bold(true),
+		InsertedChangeStyle: lipgloss.NewStyle().Underline(true),
+	})
+	expectedView := testutil.Pad(w, h, []string{
+		"  job {",
+		"\x1b[38;2;255;0;0m-   count = \x1b[m\x1b[1m1\x1b[m",
+		"\x1b[38;2;0;255;0m+   count = \x1b[m\x1b[4;4m3\x1b[m",
+		"    image = redis",
+		// unpaired lines are styled without intra-line highlighting
+		"\x1b[38;2;0;255;0m+   port = 80\x1b[m",
+		"  }",
+	})
+	testutil.CmpStr(t, expectedView, d.View())
+}
+
+func TestDiff_HunkNavigation(t *testing.T) {
+	w, h := 20, 7
+	d := newDiff(w, h)
+	d.SetSelectionEnabled(true)
+
+	expectedRows := []int{1, 4, 4}
+	for _, expectedRow := range expectedRows {
+		d, _ = d.Update(nextHunkKeyMsg)
+		if line := d.GetSelectedLine(); d.left.GetSelectedItemIdx() != expectedRow || line == nil {
+			t.Fatalf("expected row %d to be selected, got %d", expectedRow, d.left.GetSelectedItemIdx())
+		}
+	}
+	if line := d.GetSelectedLine(); line.Kind != Inserted || line.Text != "  port = 80" {
+		t.Errorf("expected inserted port line to be selected, got %+v", line)
+	}
+	d, _ = d.Update(prevHunkKeyMsg)
+	if d.left.GetSelectedItemIdx() != 1 {
+		t.Errorf("expected row 1 to be selected, got %d", d.left.GetSelectedItemIdx())
+	}
+
+	// in the side-by-side view the second hunk starts on row 3
+	d.SetMode(SideBySide)
+	d, _ = d.Update(nextHunkKeyMsg)
+	if d.left.GetSelectedItemIdx() != 3 || d.right.GetSelectedItemIdx() != 3 {
+		t.Errorf("expected row 3 to be selected on both sides, got %d and %d", d.left.GetSelectedItemIdx(), d.right.GetSelectedItemIdx())
+	}
+}
+
+func TestDiff_ModeSwitchKeepsLine(t *testing.T) {
+	w, h := 31, 6
+	d := newDiff(w, h)
+	d.SetSelectionEnabled(true)
+	d.left.SetSelectedItemIdx(4)
+
+	d.SetMode(SideBySide)
+	if line := d.GetSelectedLine(); line == nil || line.Text != "  port = 80" {
+		t.Fatalf("expected port line to stay selected, got %+v", line)
+	}
+	expectedView := sideBySide(14, h, []string{
+		"  job {",
+		"-   count = 1",
+		"    image =...",
+		testutil.Selected(" "),
+		"  }",
+	}, []string{
+		"  job {",
+		"+   count = 3",
+		"    image =...",
+		testutil.Selected("+   port = 80"),
+		"  }",
+	})
+	testutil.CmpStr(t, expectedView, d.View())
+
+	d.SetMode(Unified)
+	if line := d.GetSelectedLine(); line == nil || line.Text != "  port = 80" {
+		t.Errorf("expected port line to stay selected, got %+v", line)
+	}
+}
+
+func TestDiff_SyncedScrolling(t *testing.T) {
+	w, h := 31, 2
+	d := newDiff(w, h)
+	d.SetMode(SideBySide)
+
+	// vertical scroll follows the focused side
+	d, _ = d.Update(switchSideKeyMsg)
+	d, _ = d.Update(downKeyMsg)
+	d, _ = d.Update(downKeyMsg)
+	expectedView := sideBySide(14, h, []string{
+		"    image =...",
+		"",
+	}, []string{
+		"    image =...",
+		"+   port = 80",
+	})
+	testutil.CmpStr(t, expectedView, d.View())
+
+	// shared pan moves both sides
+	d, _ = d.Update(rightKeyMsg)
+	expectedView = sideBySide(14, h, []string{
+		"...age = redis",
+		"",
+	}, []string{
+		"...age = redis",
+		"...rt = 80",
+	})
+	testutil.CmpStr(t, expectedView, d.View())
+
+	// independent pan moves the focused side only
+	d.SetSharedPan(false)
+	d, _ = d.Update(switchSideKeyMsg)
+	d, _ = d.Update(leftKeyMsg)
+	expectedView = sideBySide(14, h, []string{
+		"    image =...",
+		"",
+	}, []string{
+		"...age = redis",
+		"...rt = 80",
+	})
+	testutil.CmpStr(t, expectedView, d.View())
+}
+
+func TestDiff_BlurredSide(t *testing.T) {
+	w, h := 31, 6
+	d := newDiff(w, h)
+	d.SetSelectionEnabled(true)
+	d.SetBlurredStyles(viewport.Styles{SelectedItemStyle: lipgloss.NewStyle().Foreground(red)})
+	d.SetMode(SideBySide)
+	blurred := lipgloss.NewStyle().Foreground(red).Render
+
+	expectedView := sideBySide(14, h, []string{
+		testutil.Selected("  job {"),
+		"-   count = 1",
+		"    image =...",
+		"",
+		"  }",
+	}, []string{
+		blurred("  job {"),
+		"+   count = 3",
+		"    image =...",
+		"+   port = 80",
+		"  }",
+	})
+	testutil.CmpStr(t, expectedView, d.View())
+
+	d, _ = d.Update(switchSideKeyMsg)
+	expectedView = sideBySide(14, h, []string{
+		blurred("  job {"),
+		"-   count = 1",
+		"    image =...",
+		"",
+		"  }",
+	}, []string{
+		testutil.Selected("  job {"),
+		"+   count = 3",
+		"    image =...",
+		"+   port = 80",
+		"  }",
+	})
+	testutil.CmpStr(t, expectedView, d.View())
+}
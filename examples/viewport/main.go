@@ -53,13 +53,49 @@ var keyMap = viewport.KeyMap{
 		key.WithHelp("→", "right"),
 	),
 	Top: key.NewBinding(
-		key.WithKeys("g", "ctrl+g"),
-		key.WithHelp("g", "top"),
+		key.WithKeys("g g", "ctrl+g"),
+		key.WithHelp("gg", "top"),
 	),
 	Bottom: key.NewBinding(
 		key.WithKeys("shift+g"),
 		key.WithHelp("G", "bottom"),
 	),
+	Percent: key.NewBinding(
+		key.WithKeys("%"),
+		key.WithHelp("N%", "go to N percent"),
+	),
+	SetMark: key.NewBinding(
+		key.WithKeys("m"),
+		key.WithHelp("m", "set mark"),
+	),
+	JumpToMark: key.NewBinding(
+		key.WithKeys("'"),
+		key.WithHelp("'", "jump to mark"),
+	),
+	NextMark: key.NewBinding(
+		key.WithKeys("]"),
+		key.WithHelp("]", "next mark"),
+	),
+	PrevMark: key.NewBinding(
+		key.WithKeys("["),
+		key.WithHelp("[", "prev mark"),
+	),
+	CenterSelection: key.NewBinding(
+		key.WithKeys("z z"),
+		key.WithHelp("zz", "center selection"),
+	),
+	TopAlignSelection: key.NewBinding(
+		key.WithKeys("z t"),
+		key.WithHelp("zt", "selection to top"),
+	),
+	BottomAlignSelection: key.NewBinding(
+		key.WithKeys("z b"),
+		key.WithHelp("zb", "selection to bottom"),
+	),
+	ToggleFold: key.NewBinding(
+		key.WithKeys("z a"),
+		key.WithHelp("za", "toggle fold"),
+	),
 }
 
 var styles = viewport.Styles{
@@ -67,6 +103,8 @@ var styles = viewport.Styles{
 	HighlightStyle:           lipgloss.NewStyle().Foreground(lipgloss.Color("1")).Background(lipgloss.Color("2")),
 	HighlightStyleIfSelected: lipgloss.NewStyle().Foreground(lipgloss.Color("1")).Background(lipgloss.Color("3")),
 	SelectedItemStyle:        lipgloss.NewStyle().Foreground(lipgloss.Color("1")).Background(lipgloss.Color("2")),
+	MarkedItemStyle:          lipgloss.NewStyle().Foreground(lipgloss.Color("4")),
+	FoldSummaryStyle:         lipgloss.NewStyle().Faint(true),
 }
 
 // RenderableString is a simple type that wraps a string and implements the Renderable interface
@@ -118,6 +156,8 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			m.viewport.SetSelectionEnabled(false)
 			m.viewport.SetStringToHighlight("surf")
 			m.viewport.SetWrapText(true)
+			m.viewport.SetCountPrefixEnabled(true)
+			m.viewport.SetSmoothScroll(true)
 			m.ready = true
 		} else {
 			m.viewport.SetWidth(msg.Width - 2)
@@ -139,6 +179,7 @@ func (m model) View() string {
 	var header = strings.Join(getHeader(
 		m.viewport.GetWrapText(),
 		m.viewport.GetSelectionEnabled(),
+		m.viewport.GetPendingCount(),
 		[]key.Binding{
 			keyMap.PageDown,
 			keyMap.PageUp,
@@ -150,6 +191,12 @@ func (m model) View() string {
 			keyMap.Right,
 			keyMap.Top,
 			keyMap.Bottom,
+			keyMap.Percent,
+			keyMap.SetMark,
+			keyMap.JumpToMark,
+			keyMap.NextMark,
+			keyMap.PrevMark,
+			keyMap.CenterSelection,
 		},
 	), "\n")
 	return lipgloss.JoinVertical(
@@ -159,12 +206,15 @@ func (m model) View() string {
 	)
 }
 
-func getHeader(wrapped, selectionEnabled bool, bindings []key.Binding) []string {
+func getHeader(wrapped, selectionEnabled bool, pendingCount int, bindings []key.Binding) []string {
 	var header []string
 	header = append(header, lipgloss.NewStyle().Bold(true).Render("A Supercharged Viewport"))
 	header = append(header, "- Wrapping enabled: "+fmt.Sprint(wrapped)+" (w to toggle)")
 	header = append(header, "- Selection enabled: "+fmt.Sprint(selectionEnabled)+" (s to toggle)")
 	header = append(header, "- Text to highlight: 'surf'")
+	if pendingCount > 0 {
+		header[len(header)-1] += fmt.Sprintf("  count: %d", pendingCount)
+	}
 	header = append(header, getShortHelp(bindings))
 	return header
 }
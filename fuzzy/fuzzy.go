@@ -0,0 +1,193 @@
+package fuzzy
+
+import (
+	"slices"
+
+	"github.com/charmbracelet/lipgloss/v2"
+	"github.com/robinovitch61/bubbleo/viewport"
+	"github.com/robinovitch61/bubbleo/viewport/linebuffer"
+)
+
+// Match is an item fuzzy matching a query
+type Match[T viewport.Renderable] struct {
+	// Item is the matching item
+	Item T
+
+	// Index is the index of the item in the unfiltered items
+	Index int
+
+	// Score is higher for better matches. See linebuffer.FuzzyMatch
+	Score int
+
+	// Positions are the byte offsets of the matched runes in the item's content without ansi codes
+	Positions []int
+
+	// lineBuffer is the rendered item with its matched runes highlighted, or nil to render the item as is
+	lineBuffer linebuffer.LineBufferer
+}
+
+// Render returns the rendered item, with its matched runes highlighted if shown by a Model
+func (m Match[T]) Render() linebuffer.LineBufferer {
+	if m.lineBuffer != nil {
+		return m.lineBuffer
+	}
+	return m.Item.Render()
+}
+
+// assert Match implements viewport.Renderable
+var _ viewport.Renderable = Match[viewport.RenderableString]{}
+
+// Filter returns the items whose content, ignoring ansi styling, fuzzy matches query. If ranked, matches are sorted
+// by score, best first, with ties going to shorter items and then earlier ones. Otherwise they keep their order in
+// items. An empty query matches every item
+func Filter[T viewport.Renderable](items []T, query string, caseMode linebuffer.CaseMode, ranked bool) []Match[T] {
+	matches := make([]Match[T], 0, len(items))
+	widths := make([]int, 0, len(items))
+	for i, item := range items {
+		if query == "" {
+			matches = append(matches, Match[T]{Item: item, Index: i})
+			continue
+		}
+		lb := item.Render()
+		if result, ok := linebuffer.FuzzyMatchContent(lb, query, caseMode); ok {
+			matches = append(matches, Match[T]{Item: item, Index: i, Score: result.Score, Positions: result.Positions})
+			widths = append(widths, lb.Width())
+		}
+	}
+	if !ranked || query == "" {
+		return matches
+	}
+
+	order := make([]int, len(matches))
+	for i := range order {
+		order[i] = i
+	}
+	slices.SortStableFunc(order, func(a, b int) int {
+		if matches[a].Score != matches[b].Score {
+			return matches[b].Score - matches[a].Score
+		}
+		return widths[a] - widths[b]
+	})
+	sorted := make([]Match[T], len(matches))
+	for i, idx := range order {
+		sorted[i] = matches[idx]
+	}
+	return sorted
+}
+
+// Model filters the items shown in a viewport by fuzzy matching a query, highlighting the matched characters of each
+// item that renders to a linebuffer.LineBuffer. It sets the viewport's content, so the caller sets items here rather
+// than on the viewport
+type Model[T viewport.Renderable] struct {
+	// vp is the viewport showing the matching items
+	vp *viewport.Model[Match[T]]
+
+	// items are all the items, matching or not
+	items []T
+
+	// query is fuzzy matched against each item
+	query string
+
+	// caseMode is how letter case is compared
+	caseMode linebuffer.CaseMode
+
+	// ranked is true if matches are sorted by score rather than kept in their original order
+	ranked bool
+
+	// matchStyle is the style of the matched characters
+	matchStyle lipgloss.Style
+
+	// matches are the items matching query, in the order shown
+	matches []Match[T]
+}
+
+// New creates a new fuzzy filter showing its matches in vp with their matched characters in matchStyle, ranked by
+// score and using smart case
+func New[T viewport.Renderable](vp *viewport.Model[Match[T]], matchStyle lipgloss.Style) (m Model[T]) {
+	m.vp = vp
+	m.matchStyle = matchStyle
+	m.caseMode = linebuffer.SmartCase
+	m.ranked = true
+	m.filter()
+	return m
+}
+
+// SetItems sets all the items to filter
+func (m *Model[T]) SetItems(items []T) {
+	m.items = items
+	m.filter()
+}
+
+// SetQuery sets the query fuzzy matched against the items, selecting the first match. An empty query shows every item
+func (m *Model[T]) SetQuery(query string) {
+	m.query = query
+	m.filter()
+	if m.vp.GetSelectionEnabled() {
+		m.vp.SetSelectedItemIdx(0)
+	}
+}
+
+// GetQuery returns the query
+func (m *Model[T]) GetQuery() string {
+	return m.query
+}
+
+// SetRanked sets whether matches are sorted by score, best first, rather than kept in their original order
+func (m *Model[T]) SetRanked(ranked bool) {
+	m.ranked = ranked
+	m.filter()
+}
+
+// GetRanked returns whether matches are sorted by score
+func (m *Model[T]) GetRanked() bool {
+	return m.ranked
+}
+
+// SetCaseMode sets how letter case is compared
+func (m *Model[T]) SetCaseMode(caseMode linebuffer.CaseMode) {
+	m.caseMode = caseMode
+	m.filter()
+}
+
+// SetMatchStyle sets the style of the matched characters
+func (m *Model[T]) SetMatchStyle(matchStyle lipgloss.Style) {
+	m.matchStyle = matchStyle
+	m.render()
+}
+
+// GetMatches returns the items matching the query, in the order shown
+func (m *Model[T]) GetMatches() []Match[T] {
+	return slices.Clone(m.matches)
+}
+
+// GetSelectedMatch returns the match selected in the viewport, or nil if there is none
+func (m *Model[T]) GetSelectedMatch() *Match[T] {
+	if !m.vp.GetSelectionEnabled() {
+		return nil
+	}
+	idx := m.vp.GetSelectedItemIdx()
+	if idx < 0 || idx >= len(m.matches) {
+		return nil
+	}
+	match := m.matches[idx]
+	return &match
+}
+
+// filter finds the items matching the query and shows them in the viewport
+func (m *Model[T]) filter() {
+	m.matches = Filter(m.items, m.query, m.caseMode, m.ranked)
+	m.render()
+}
+
+// render highlights the matched characters of each match, using the positions found when filtering, and shows the
+// matches in the viewport
+func (m *Model[T]) render() {
+	for i := range m.matches {
+		match := &m.matches[i]
+		match.lineBuffer = nil
+		if lb, ok := match.Item.Render().(linebuffer.LineBuffer); ok && len(match.Positions) > 0 {
+			match.lineBuffer = lb.WithHighlightRanges(linebuffer.FuzzyHighlightRanges(lb, match.Positions, m.matchStyle)...)
+		}
+	}
+	m.vp.SetContent(slices.Clone(m.matches))
+}
@@ -0,0 +1,163 @@
+package fuzzy
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/bubbles/v2/key"
+	"github.com/charmbracelet/lipgloss/v2"
+	"github.com/google/go-cmp/cmp"
+	"github.com/robinovitch61/bubbleo/internal/testutil"
+	"github.com/robinovitch61/bubbleo/viewport"
+	"github.com/robinovitch61/bubbleo/viewport/linebuffer"
+)
+
+var (
+	red  = lipgloss.Color("#ff0000")
+	blue = lipgloss.Color("#0000ff")
+)
+
+func items(content ...string) []viewport.RenderableString {
+	var res []viewport.RenderableString
+	for _, c := range content {
+		res = append(res, viewport.RenderableString{LineBuffer: linebuffer.New(c)})
+	}
+	return res
+}
+
+func newFilter(selectionEnabled bool, content ...string) (Model[viewport.RenderableString], *viewport.Model[Match[viewport.RenderableString]]) {
+	vp := viewport.New[Match[viewport.RenderableString]](20, 4, viewport.KeyMap{
+		Down: key.NewBinding(key.WithKeys("j")),
+	}, viewport.Styles{
+		HighlightStyle:           lipgloss.NewStyle().Foreground(red),
+		HighlightStyleIfSelected: lipgloss.NewStyle().Foreground(red),
+		SelectedItemStyle:        lipgloss.NewStyle(),
+	})
+	vp.SetFooterEnabled(false)
+	vp.SetSelectionEnabled(selectionEnabled)
+	m := New(&vp, lipgloss.NewStyle().Foreground(red))
+	m.SetItems(items(content...))
+	return m, &vp
+}
+
+func TestFilter(t *testing.T) {
+	content := []string{"main_test.go", "fuzzy.go", "\x1b[38;2;0;0;255mfuzzy\x1b[m_test.go", "README.md", "fizz_buzz.go"}
+	for _, tt := range []struct {
+		name     string
+		query    string
+		caseMode linebuffer.CaseMode
+		ranked   bool
+		expected []int
+	}{
+		{
+			name:     "empty query",
+			query:    "",
+			ranked:   true,
+			expected: []int{0, 1, 2, 3, 4},
+		},
+		{
+			name:     "original order",
+			query:    "fzgo",
+			expected: []int{1, 2, 4},
+		},
+		{
+			name:     "ranked",
+			query:    "fzgo",
+			ranked:   true,
+			expected: []int{1, 4, 2},
+		},
+		{
+			name:     "ranked by score",
+			query:    "test",
+			ranked:   true,
+			expected: []int{0, 2},
+		},
+		{
+			name:     "ranked ties to shorter",
+			query:    "fuzzy",
+			ranked:   true,
+			expected: []int{1, 2},
+		},
+		{
+			name:     "smart case",
+			query:    "READ",
+			caseMode: linebuffer.SmartCase,
+			expected: []int{3},
+		},
+		{
+			name:     "case-sensitive",
+			query:    "readme",
+			caseMode: linebuffer.CaseSensitive,
+			expected: []int{},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			matches := Filter(items(content...), tt.query, tt.caseMode, tt.ranked)
+			indexes := []int{}
+			for _, match := range matches {
+				indexes = append(indexes, match.Index)
+			}
+			if diff := cmp.Diff(tt.expected, indexes); diff != "" {
+				t.Errorf("Diff (-expected +actual):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestModel_Highlight(t *testing.T) {
+	m, vp := newFilter(false, "alpha", "beta", "gamma", "delta")
+	m.SetQuery("ta")
+	expected := strings.Join([]string{
+		"be" + lipgloss.NewStyle().Foreground(red).Render("ta") + "                ",
+		"del" + lipgloss.NewStyle().Foreground(red).Render("ta") + "               ",
+		"                    ",
+		"                    ",
+	}, "\n")
+	testutil.CmpStr(t, expected, vp.View())
+
+	m.SetRanked(false)
+	m.SetQuery("aa")
+	expected = strings.Join([]string{
+		lipgloss.NewStyle().Foreground(red).Render("a") + "lph" + lipgloss.NewStyle().Foreground(red).Render("a") + "               ",
+		"g" + lipgloss.NewStyle().Foreground(red).Render("a") + "mm" + lipgloss.NewStyle().Foreground(red).Render("a") + "               ",
+		"                    ",
+		"                    ",
+	}, "\n")
+	testutil.CmpStr(t, expected, vp.View())
+
+	m.SetMatchStyle(lipgloss.NewStyle().Foreground(blue))
+	expected = strings.Join([]string{
+		lipgloss.NewStyle().Foreground(blue).Render("a") + "lph" + lipgloss.NewStyle().Foreground(blue).Render("a") + "               ",
+		"g" + lipgloss.NewStyle().Foreground(blue).Render("a") + "mm" + lipgloss.NewStyle().Foreground(blue).Render("a") + "               ",
+		"                    ",
+		"                    ",
+	}, "\n")
+	testutil.CmpStr(t, expected, vp.View())
+
+	m.SetQuery("")
+	expected = strings.Join([]string{
+		"alpha               ",
+		"beta                ",
+		"gamma               ",
+		"delta               ",
+	}, "\n")
+	testutil.CmpStr(t, expected, vp.View())
+}
+
+func TestModel_SelectedMatch(t *testing.T) {
+	m, vp := newFilter(true, "alpha", "beta", "gamma", "delta")
+	vp.SetSelectedItemIdx(2)
+	m.SetQuery("ta")
+	match := m.GetSelectedMatch()
+	if match == nil {
+		t.Fatal("expected a selected match")
+	}
+	if match.Index != 1 || match.Item.Render().Content() != "beta" {
+		t.Errorf("expected selected match beta at index 1, got %q at index %d", match.Item.Render().Content(), match.Index)
+	}
+
+	m.SetQuery("zz")
+	if match := m.GetSelectedMatch(); match != nil {
+		t.Errorf("expected no selected match, got %q", match.Item.Render().Content())
+	}
+}
@@ -1,22 +1,44 @@
-package internal
+// Package testutil has helpers shared by the module's tests
+package testutil
 
 import (
 	"fmt"
 	"runtime"
 	"sort"
+	"strings"
 	"testing"
 	"time"
 
+	"github.com/charmbracelet/lipgloss/v2"
 	"github.com/google/go-cmp/cmp"
 )
 
+// SelectionStyle is the style tests give the selected item
+var SelectionStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("#0000ff"))
+
 // CmpStr compares two strings and fails the test if they are not equal
 func CmpStr(t *testing.T, expected, actual string) {
-	_, file, line, _ := runtime.Caller(1)
-	testName := t.Name()
+	t.Helper()
 	if diff := cmp.Diff(expected, actual); diff != "" {
-		t.Errorf("\nTest %q failed at %s:%d\nDiff (-expected +actual):\n%s", testName, file, line, diff)
+		t.Errorf("Diff (-expected +actual):\n%s", diff)
+	}
+}
+
+// Pad pads each line with spaces to width and adds blank lines up to height, like a rendered view
+func Pad(width, height int, lines []string) string {
+	var res []string
+	for _, line := range lines {
+		res = append(res, line+strings.Repeat(" ", max(0, width-lipgloss.Width(line))))
+	}
+	for i := len(lines); i < height; i++ {
+		res = append(res, strings.Repeat(" ", width))
 	}
+	return strings.Join(res, "\n")
+}
+
+// Selected renders s in SelectionStyle
+func Selected(s string) string {
+	return SelectionStyle.Render(s)
 }
 
 // RunWithTimeout runs a test function with a timeout.
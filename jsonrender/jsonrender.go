@@ -0,0 +1,267 @@
+package jsonrender
+
+import (
+	"bytes"
+	"encoding/json"
+	"slices"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss/v2"
+	"github.com/robinovitch61/bubbleo/viewport"
+	"github.com/robinovitch61/bubbleo/viewport/linebuffer"
+)
+
+// Styles contains styling configuration for JSON tokens
+type Styles struct {
+	KeyStyle         lipgloss.Style
+	StringStyle      lipgloss.Style
+	NumberStyle      lipgloss.Style
+	BoolStyle        lipgloss.Style
+	NullStyle        lipgloss.Style
+	PunctuationStyle lipgloss.Style
+}
+
+// Line is a single rendered line of a source line, which is either compact or pretty-printed across multiple lines
+type Line struct {
+	// SourceIdx is the index of the source line this was rendered from
+	SourceIdx int
+
+	// LineIdx is the index of this line within the rendered source line
+	LineIdx int
+
+	// IsJSON is true if the source line parsed as JSON, otherwise this is the raw source line
+	IsJSON bool
+
+	lineBuffer linebuffer.LineBuffer
+}
+
+// Render returns the rendered line for the viewport
+func (l Line) Render() linebuffer.LineBufferer {
+	return l.lineBuffer
+}
+
+// assert Line implements viewport.Renderable
+var _ viewport.Renderable = Line{}
+
+// CompareFn compares lines by their source line, so the selection stays on the same source line when it is expanded
+// or compacted. Use with the viewport's SetSelectionComparator
+func CompareFn(a, b Line) bool {
+	return a.SourceIdx == b.SourceIdx
+}
+
+// Renderer renders source lines of JSON as syntax-highlighted Lines for a viewport, each either compact on one line
+// or pretty-printed across multiple lines. Source lines that aren't valid JSON are rendered as-is
+type Renderer struct {
+	// Styles are applied to JSON tokens
+	Styles Styles
+
+	// Indent is the indentation for each nesting level when pretty-printed
+	Indent string
+
+	// expanded is the set of indexes of source lines that are pretty-printed
+	expanded map[int]struct{}
+
+	// allExpanded is true if every source line is pretty-printed unless in expanded
+	allExpanded bool
+
+	// sources are the source lines of the last Render, used to keep expanded on the same source lines when lines are
+	// added or removed
+	sources []string
+
+	// cache maps a source line to its rendered compact and expanded lines
+	cache map[string]renderedSource
+}
+
+type renderedSource struct {
+	isJSON   bool
+	compact  string
+	expanded []string
+}
+
+// NewRenderer creates a new Renderer with two space indentation. Source lines start compact
+func NewRenderer(styles Styles) *Renderer {
+	return &Renderer{
+		Styles:   styles,
+		Indent:   "  ",
+		expanded: map[int]struct{}{},
+		cache:    map[string]renderedSource{},
+	}
+}
+
+// Render renders the source lines, returning one Line per compact source line and one Line per pretty-printed line of
+// expanded source lines
+func (r *Renderer) Render(sources []string) []Line {
+	r.remapExpanded(sources)
+	cache := make(map[string]renderedSource, len(sources))
+	var lines []Line
+	for sourceIdx, source := range sources {
+		rendered, ok := r.cache[source]
+		if !ok {
+			rendered, ok = cache[source]
+		}
+		if !ok {
+			rendered = r.renderSource(source)
+		}
+		cache[source] = rendered
+
+		if !rendered.isJSON {
+			lines = append(lines, Line{SourceIdx: sourceIdx, lineBuffer: linebuffer.New(source)})
+			continue
+		}
+		if !r.IsExpanded(sourceIdx) {
+			lines = append(lines, Line{SourceIdx: sourceIdx, IsJSON: true, lineBuffer: linebuffer.New(rendered.compact)})
+			continue
+		}
+		for lineIdx, line := range rendered.expanded {
+			lines = append(lines, Line{SourceIdx: sourceIdx, LineIdx: lineIdx, IsJSON: true, lineBuffer: linebuffer.New(line)})
+		}
+	}
+	// only keep renders of the current source lines
+	r.cache = cache
+	return lines
+}
+
+// remapExpanded moves expanded from the source lines of the last Render to the same source lines in sources, matching
+// identical source lines in order
+func (r *Renderer) remapExpanded(sources []string) {
+	prevSources := r.sources
+	r.sources = slices.Clone(sources)
+	if prevSources == nil || len(r.expanded) == 0 {
+		return
+	}
+
+	// a source line is identified by its content and how many identical lines come before it
+	type occurrence struct {
+		source string
+		n      int
+	}
+	numSeen := make(map[string]int)
+	prevExpanded := make(map[occurrence]struct{}, len(r.expanded))
+	for i, source := range prevSources {
+		if _, ok := r.expanded[i]; ok {
+			prevExpanded[occurrence{source, numSeen[source]}] = struct{}{}
+		}
+		numSeen[source]++
+	}
+	clear(numSeen)
+	r.expanded = make(map[int]struct{}, len(prevExpanded))
+	for i, source := range sources {
+		if _, ok := prevExpanded[occurrence{source, numSeen[source]}]; ok {
+			r.expanded[i] = struct{}{}
+		}
+		numSeen[source]++
+	}
+}
+
+// SetExpanded sets whether the source line at sourceIdx is pretty-printed. It stays on the same source line, by
+// content, when lines are added or removed around it
+func (r *Renderer) SetExpanded(sourceIdx int, expanded bool) {
+	if expanded == r.allExpanded {
+		delete(r.expanded, sourceIdx)
+	} else {
+		r.expanded[sourceIdx] = struct{}{}
+	}
+}
+
+// Toggle pretty-prints the source line at sourceIdx if compact, or compacts it if pretty-printed
+func (r *Renderer) Toggle(sourceIdx int) {
+	r.SetExpanded(sourceIdx, !r.IsExpanded(sourceIdx))
+}
+
+// IsExpanded returns true if the source line at sourceIdx is pretty-printed
+func (r *Renderer) IsExpanded(sourceIdx int) bool {
+	_, ok := r.expanded[sourceIdx]
+	return ok != r.allExpanded
+}
+
+// SetAllExpanded sets whether every source line is pretty-printed
+func (r *Renderer) SetAllExpanded(expanded bool) {
+	r.allExpanded = expanded
+	r.expanded = map[int]struct{}{}
+}
+
+// ClearCache clears cached renders, e.g. after changing Styles or Indent
+func (r *Renderer) ClearCache() {
+	r.cache = map[string]renderedSource{}
+}
+
+func (r *Renderer) renderSource(source string) renderedSource {
+	raw := []byte(strings.TrimSpace(source))
+	if len(raw) == 0 || !json.Valid(raw) {
+		return renderedSource{}
+	}
+
+	var compact bytes.Buffer
+	if err := json.Compact(&compact, raw); err != nil {
+		return renderedSource{}
+	}
+	var expanded bytes.Buffer
+	if err := json.Indent(&expanded, raw, "", r.Indent); err != nil {
+		return renderedSource{}
+	}
+
+	expandedLines := strings.Split(expanded.String(), "\n")
+	for i := range expandedLines {
+		expandedLines[i] = r.highlight(expandedLines[i])
+	}
+	return renderedSource{
+		isJSON:   true,
+		compact:  r.highlight(compact.String()),
+		expanded: expandedLines,
+	}
+}
+
+// highlight styles the tokens of a line of valid JSON. Strings never span lines in compact or indented JSON, so a
+// string followed by a colon on the same line is a key
+func (r *Renderer) highlight(line string) string {
+	var builder strings.Builder
+	for i := 0; i < len(line); {
+		c := line[i]
+		switch {
+		case c == '"':
+			end := stringEnd(line, i)
+			style := r.Styles.StringStyle
+			if rest := strings.TrimLeft(line[end:], " \t"); strings.HasPrefix(rest, ":") {
+				style = r.Styles.KeyStyle
+			}
+			builder.WriteString(style.Render(line[i:end]))
+			i = end
+		case c == '-' || (c >= '0' && c <= '9'):
+			end := i + 1
+			for end < len(line) && strings.IndexByte("0123456789.eE+-", line[end]) >= 0 {
+				end++
+			}
+			builder.WriteString(r.Styles.NumberStyle.Render(line[i:end]))
+			i = end
+		case strings.HasPrefix(line[i:], "true"):
+			builder.WriteString(r.Styles.BoolStyle.Render("true"))
+			i += len("true")
+		case strings.HasPrefix(line[i:], "false"):
+			builder.WriteString(r.Styles.BoolStyle.Render("false"))
+			i += len("false")
+		case strings.HasPrefix(line[i:], "null"):
+			builder.WriteString(r.Styles.NullStyle.Render("null"))
+			i += len("null")
+		case strings.IndexByte("{}[]:,", c) >= 0:
+			builder.WriteString(r.Styles.PunctuationStyle.Render(string(c)))
+			i++
+		default:
+			builder.WriteByte(c)
+			i++
+		}
+	}
+	return builder.String()
+}
+
+// stringEnd returns the index just after the closing quote of the string starting at start
+func stringEnd(line string, start int) int {
+	for i := start + 1; i < len(line); i++ {
+		switch line[i] {
+		case '\\':
+			i++
+		case '"':
+			return i + 1
+		}
+	}
+	return len(line)
+}
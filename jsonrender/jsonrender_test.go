@@ -0,0 +1,199 @@
+package jsonrender
+
+import (
+	"testing"
+
+	"github.com/charmbracelet/lipgloss/v2"
+	"github.com/google/go-cmp/cmp"
+)
+
+var (
+	red   = lipgloss.Color("#ff0000")
+	green = lipgloss.Color("#00ff00")
+	blue  = lipgloss.Color("#0000ff")
+)
+
+func contents(lines []Line) []string {
+	var res []string
+	for _, l := range lines {
+		res = append(res, l.Render().Content())
+	}
+	return res
+}
+
+func TestRenderer_Render(t *testing.T) {
+	tests := []struct {
+		name     string
+		sources  []string
+		expanded []int
+		expected []string
+	}{
+		{
+			name:     "compact",
+			sources:  []string{`{"a": 1,  "b": [true, null]}`},
+			expected: []string{`{"a":1,"b":[true,null]}`},
+		},
+		{
+			name:     "expanded",
+			sources:  []string{`{"a": 1, "b": [true, null], "c": {}}`},
+			expanded: []int{0},
+			expected: []string{
+				`{`,
+				`  "a": 1,`,
+				`  "b": [`,
+				`    true,`,
+				`    null`,
+				`  ],`,
+				`  "c": {}`,
+				`}`,
+			},
+		},
+		{
+			name:     "key order is preserved",
+			sources:  []string{`{"z": 1, "a": 2}`},
+			expected: []string{`{"z":1,"a":2}`},
+		},
+		{
+			name:     "invalid json falls back to raw line",
+			sources:  []string{`not json {"a": 1}`, `{"a": 1`},
+			expanded: []int{0, 1},
+			expected: []string{`not json {"a": 1}`, `{"a": 1`},
+		},
+		{
+			name:     "mixed",
+			sources:  []string{`{"a": 1}`, `plain`, `[1, 2]`},
+			expanded: []int{2},
+			expected: []string{`{"a":1}`, `plain`, `[`, `  1,`, `  2`, `]`},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := NewRenderer(Styles{})
+			for _, idx := range tt.expanded {
+				r.SetExpanded(idx, true)
+			}
+			if diff := cmp.Diff(tt.expected, contents(r.Render(tt.sources))); diff != "" {
+				t.Errorf("Diff (-expected +actual):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestRenderer_Styles(t *testing.T) {
+	r := NewRenderer(Styles{
+		KeyStyle:    lipgloss.NewStyle().Foreground(red),
+		StringStyle: lipgloss.NewStyle().Foreground(green),
+		NumberStyle: lipgloss.NewStyle().Foreground(blue),
+		BoolStyle:   lipgloss.NewStyle().Bold(true),
+	})
+	lines := r.Render([]string{`{"k": "v: \"x\"", "n": -1.5e3, "b": false}`})
+	expected := []string{
+		"{" +
+			"\x1b[38;2;255;0;0m\"k\"\x1b[m:" +
+			"\x1b[38;2;0;255;0m\"v: \\\"x\\\"\"\x1b[m," +
+			"\x1b[38;2;255;0;0m\"n\"\x1b[m:" +
+			"\x1b[38;2;0;0;255m-1.5e3\x1b[m," +
+			"\x1b[38;2;255;0;0m\"b\"\x1b[m:" +
+			"\x1b[1mfalse\x1b[m" +
+			"}",
+	}
+	if diff := cmp.Diff(expected, contents(lines)); diff != "" {
+		t.Errorf("Diff (-expected +actual):\n%s", diff)
+	}
+}
+
+func TestRenderer_Toggle(t *testing.T) {
+	r := NewRenderer(Styles{})
+	sources := []string{`{"a": 1}`, `{"b": 2}`}
+
+	r.Toggle(1)
+	lines := r.Render(sources)
+	expected := []Line{
+		{SourceIdx: 0, LineIdx: 0, IsJSON: true},
+		{SourceIdx: 1, LineIdx: 0, IsJSON: true},
+		{SourceIdx: 1, LineIdx: 1, IsJSON: true},
+		{SourceIdx: 1, LineIdx: 2, IsJSON: true},
+	}
+	if len(lines) != len(expected) {
+		t.Fatalf("expected %d lines, got %d", len(expected), len(lines))
+	}
+	for i := range lines {
+		if lines[i].SourceIdx != expected[i].SourceIdx || lines[i].LineIdx != expected[i].LineIdx || lines[i].IsJSON != expected[i].IsJSON {
+			t.Errorf("line %d: expected %+v, got %+v", i, expected[i], lines[i])
+		}
+	}
+	if !CompareFn(lines[1], lines[3]) || CompareFn(lines[0], lines[1]) {
+		t.Errorf("expected lines to compare equal only if from the same source line")
+	}
+
+	// expand all, then compact one
+	r.SetAllExpanded(true)
+	r.Toggle(0)
+	if r.IsExpanded(0) || !r.IsExpanded(1) {
+		t.Errorf("expected only source line 1 to be expanded")
+	}
+	if diff := cmp.Diff([]string{`{"a":1}`, `{`, `  "b": 2`, `}`}, contents(r.Render(sources))); diff != "" {
+		t.Errorf("Diff (-expected +actual):\n%s", diff)
+	}
+}
+
+func TestRenderer_ExpandedFollowsSourceLine(t *testing.T) {
+	tests := []struct {
+		name        string
+		allExpanded bool
+		initial     []string
+		toggled     int
+		sources     []string
+		expected    []string
+	}{
+		{
+			name:     "line prepended",
+			initial:  []string{`{"a": 1}`, `{"b": 2}`},
+			toggled:  1,
+			sources:  []string{`[0]`, `{"a": 1}`, `{"b": 2}`},
+			expected: []string{`[0]`, `{"a":1}`, `{`, `  "b": 2`, `}`},
+		},
+		{
+			name:     "line removed",
+			initial:  []string{`{"a": 1}`, `{"b": 2}`},
+			toggled:  1,
+			sources:  []string{`{"b": 2}`},
+			expected: []string{`{`, `  "b": 2`, `}`},
+		},
+		{
+			name:     "expanded line removed",
+			initial:  []string{`{"a": 1}`, `{"b": 2}`},
+			toggled:  0,
+			sources:  []string{`{"b": 2}`},
+			expected: []string{`{"b":2}`},
+		},
+		{
+			name:        "compacted line prepended while all expanded",
+			allExpanded: true,
+			initial:     []string{`{"a": 1}`, `{"b": 2}`},
+			toggled:     0,
+			sources:     []string{`[0]`, `{"a": 1}`, `{"b": 2}`},
+			expected:    []string{`[`, `  0`, `]`, `{"a":1}`, `{`, `  "b": 2`, `}`},
+		},
+		{
+			name:     "identical lines matched in order",
+			initial:  []string{`{"a": 1}`, `{"a": 1}`},
+			toggled:  1,
+			sources:  []string{`[0]`, `{"a": 1}`, `{"a": 1}`},
+			expected: []string{`[0]`, `{"a":1}`, `{`, `  "a": 1`, `}`},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := NewRenderer(Styles{})
+			r.SetAllExpanded(tt.allExpanded)
+			r.Render(tt.initial)
+			r.Toggle(tt.toggled)
+			if diff := cmp.Diff(tt.expected, contents(r.Render(tt.sources))); diff != "" {
+				t.Errorf("Diff (-expected +actual):\n%s", diff)
+			}
+		})
+	}
+}
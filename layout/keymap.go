@@ -0,0 +1,15 @@
+package layout
+
+import "github.com/charmbracelet/bubbles/v2/key"
+
+// KeyMap contains layout key bindings. Other keys go to the focused pane
+type KeyMap struct {
+	// FocusNext focuses the next pane, wrapping around to the first
+	FocusNext key.Binding
+	// FocusPrev focuses the previous pane, wrapping around to the last
+	FocusPrev key.Binding
+	// Grow grows the focused pane by one cell, taking space from its neighbor in its split
+	Grow key.Binding
+	// Shrink shrinks the focused pane by one cell, giving space to its neighbor in its split
+	Shrink key.Binding
+}
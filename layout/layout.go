@@ -0,0 +1,630 @@
+package layout
+
+import (
+	"math"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/v2/key"
+	tea "github.com/charmbracelet/bubbletea/v2"
+	"github.com/charmbracelet/lipgloss/v2"
+	"github.com/robinovitch61/bubbleo/viewport/linebuffer"
+)
+
+// Direction is how the children of a split are arranged
+type Direction int
+
+const (
+	// Horizontal arranges children side by side, from left to right
+	Horizontal Direction = iota
+	// Vertical stacks children from top to bottom
+	Vertical
+)
+
+// Node is either a pane or a split of other nodes in a layout
+type Node struct {
+	// Title is drawn in the top border of a pane
+	Title string
+
+	// Pane is the component shown by the node, or nil if the node is a split
+	Pane Pane
+
+	// Direction is how Children are arranged if the node is a split
+	Direction Direction
+
+	// Children are the nodes of the split
+	Children []*Node
+
+	// Fixed is the size of the node along its parent's Direction in terminal cells, including borders. Zero means the
+	// node is sized by Ratio
+	Fixed int
+
+	// Ratio is the node's share of the space its parent has left after fixed size children. Zero is treated as 1
+	Ratio float64
+
+	// x, y, width, and height are the position and size of the node within the layout, including borders
+	x, y, width, height int
+}
+
+// NewPane returns a node showing pane, with title drawn in its top border
+func NewPane(title string, pane Pane) *Node {
+	return &Node{Title: title, Pane: pane}
+}
+
+// NewSplit returns a node arranging children in direction
+func NewSplit(direction Direction, children ...*Node) *Node {
+	return &Node{Direction: direction, Children: children}
+}
+
+// WithFixed sets the node's Fixed size and returns it
+func (n *Node) WithFixed(size int) *Node {
+	n.Fixed = size
+	return n
+}
+
+// WithRatio sets the node's Ratio and returns it
+func (n *Node) WithRatio(ratio float64) *Node {
+	n.Ratio = ratio
+	return n
+}
+
+func (n *Node) ratio() float64 {
+	if n.Ratio <= 0 {
+		return 1
+	}
+	return n.Ratio
+}
+
+func (n *Node) contains(x, y int) bool {
+	return x >= n.x && x < n.x+n.width && y >= n.y && y < n.y+n.height
+}
+
+// Styles contains styling configuration for pane borders and titles
+type Styles struct {
+	BorderStyle        lipgloss.Style
+	FocusedBorderStyle lipgloss.Style
+	TitleStyle         lipgloss.Style
+	FocusedTitleStyle  lipgloss.Style
+}
+
+// drag is an in-progress mouse drag of the divider between two children of a split
+type drag struct {
+	// split is the node whose children are being resized
+	split *Node
+
+	// childIdx is the index of the child before the divider
+	childIdx int
+
+	// pos is the mouse position along the split's Direction when last applied
+	pos int
+}
+
+// Model represents a layout component, arranging panes in nested horizontal and vertical splits
+type Model struct {
+	// root is the top node of the layout
+	root *Node
+
+	// keyMap contains the layout key bindings
+	keyMap KeyMap
+
+	// styles contains the styles for borders and titles
+	styles Styles
+
+	// bordersEnabled is true if each pane is drawn with a border and title
+	bordersEnabled bool
+
+	// width and height are the dimensions of the whole layout
+	width, height int
+
+	// originX and originY are the terminal position of the top left corner of the layout, for mouse input
+	originX, originY int
+
+	// focusedIdx is the index of the focused pane, in depth-first order
+	focusedIdx int
+
+	// drag is the in-progress divider drag, if any
+	drag *drag
+}
+
+// New creates a new layout of root with borders enabled, focusing the first pane
+func New(root *Node, width, height int) (m Model) {
+	m.root = root
+	m.bordersEnabled = true
+	m.width, m.height = width, height
+	m.layout()
+	m.applyFocus()
+	return m
+}
+
+// Update processes messages and updates the model. Keys go to the focused pane, mouse input goes to the pane under
+// the cursor in pane coordinates, and other messages go to every pane
+func (m *Model) Update(msg tea.Msg) (Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch {
+		case key.Matches(msg, m.keyMap.FocusNext):
+			m.moveFocus(1)
+			return *m, nil
+		case key.Matches(msg, m.keyMap.FocusPrev):
+			m.moveFocus(-1)
+			return *m, nil
+		case key.Matches(msg, m.keyMap.Grow):
+			m.resizeFocused(1)
+			return *m, nil
+		case key.Matches(msg, m.keyMap.Shrink):
+			m.resizeFocused(-1)
+			return *m, nil
+		}
+		if focused := m.GetFocusedNode(); focused != nil {
+			return *m, focused.Pane.Update(msg)
+		}
+		return *m, nil
+
+	case tea.MouseClickMsg:
+		x, y := msg.X-m.originX, msg.Y-m.originY
+		if msg.Button == tea.MouseLeft {
+			if split, childIdx, ok := m.dividerAt(m.root, x, y); ok {
+				m.drag = &drag{split: split, childIdx: childIdx, pos: alongDirection(split.Direction, x, y)}
+				return *m, nil
+			}
+			m.focusAt(x, y)
+		}
+		return *m, m.updatePaneAt(msg, x, y)
+
+	case tea.MouseMotionMsg:
+		x, y := msg.X-m.originX, msg.Y-m.originY
+		if m.drag != nil {
+			pos := alongDirection(m.drag.split.Direction, x, y)
+			m.drag.pos += m.moveDivider(m.drag.split, m.drag.childIdx, pos-m.drag.pos)
+			return *m, nil
+		}
+		return *m, m.updatePaneAt(msg, x, y)
+
+	case tea.MouseReleaseMsg:
+		if m.drag != nil {
+			m.drag = nil
+			return *m, nil
+		}
+		return *m, m.updatePaneAt(msg, msg.X-m.originX, msg.Y-m.originY)
+
+	case tea.MouseWheelMsg:
+		return *m, m.updatePaneAt(msg, msg.X-m.originX, msg.Y-m.originY)
+	}
+
+	var cmds []tea.Cmd
+	for _, n := range m.panes() {
+		cmds = append(cmds, n.Pane.Update(msg))
+	}
+	return *m, tea.Batch(cmds...)
+}
+
+// View renders the layout
+func (m *Model) View() string {
+	if m.root == nil {
+		return ""
+	}
+	return strings.Join(m.render(m.root), "\n")
+}
+
+// SetRoot sets the top node of the layout, focusing the first pane
+func (m *Model) SetRoot(root *Node) {
+	m.root = root
+	m.focusedIdx = 0
+	m.drag = nil
+	m.layout()
+	m.applyFocus()
+}
+
+// GetRoot returns the top node of the layout
+func (m *Model) GetRoot() *Node {
+	return m.root
+}
+
+// Relayout recomputes pane sizes, e.g. after changing the nodes of the layout
+func (m *Model) Relayout() {
+	m.layout()
+}
+
+// SetKeyMap sets the layout key bindings
+func (m *Model) SetKeyMap(keyMap KeyMap) {
+	m.keyMap = keyMap
+}
+
+// SetStyles sets the styles for borders and titles
+func (m *Model) SetStyles(styles Styles) {
+	m.styles = styles
+}
+
+// SetBordersEnabled sets whether each pane is drawn with a border and title
+func (m *Model) SetBordersEnabled(bordersEnabled bool) {
+	m.bordersEnabled = bordersEnabled
+	m.layout()
+}
+
+// SetWidth sets the width of the whole layout
+func (m *Model) SetWidth(width int) {
+	m.width = width
+	m.layout()
+}
+
+// SetHeight sets the height of the whole layout
+func (m *Model) SetHeight(height int) {
+	m.height = height
+	m.layout()
+}
+
+// GetWidth returns the width of the whole layout
+func (m *Model) GetWidth() int {
+	return m.width
+}
+
+// GetHeight returns the height of the whole layout
+func (m *Model) GetHeight() int {
+	return m.height
+}
+
+// SetOrigin sets the terminal position of the top left corner of the layout, so mouse input lands on the right pane
+// when the layout isn't drawn at the top left of the terminal
+func (m *Model) SetOrigin(x, y int) {
+	m.originX, m.originY = x, y
+}
+
+// Focus focuses the node showing pane, returning false if no node shows it
+func (m *Model) Focus(pane Pane) bool {
+	for i, n := range m.panes() {
+		if n.Pane == pane {
+			m.focusedIdx = i
+			m.applyFocus()
+			return true
+		}
+	}
+	return false
+}
+
+// GetFocusedNode returns the node of the focused pane, or nil if there are no panes
+func (m *Model) GetFocusedNode() *Node {
+	panes := m.panes()
+	if len(panes) == 0 {
+		return nil
+	}
+	return panes[clampValZeroToMax(m.focusedIdx, len(panes)-1)]
+}
+
+// panes returns the nodes showing panes in depth-first order
+func (m *Model) panes() []*Node {
+	var res []*Node
+	var walk func(n *Node)
+	walk = func(n *Node) {
+		if n == nil {
+			return
+		}
+		if n.Pane != nil {
+			res = append(res, n)
+			return
+		}
+		for _, c := range n.Children {
+			walk(c)
+		}
+	}
+	walk(m.root)
+	return res
+}
+
+func (m *Model) moveFocus(delta int) {
+	numPanes := len(m.panes())
+	if numPanes == 0 {
+		return
+	}
+	m.focusedIdx = ((m.focusedIdx+delta)%numPanes + numPanes) % numPanes
+	m.applyFocus()
+}
+
+func (m *Model) focusAt(x, y int) {
+	for i, n := range m.panes() {
+		if n.contains(x, y) {
+			m.focusedIdx = i
+			m.applyFocus()
+			return
+		}
+	}
+}
+
+// applyFocus focuses the focused pane and blurs the others, for panes that are Focusable
+func (m *Model) applyFocus() {
+	focused := m.GetFocusedNode()
+	for _, n := range m.panes() {
+		f, ok := n.Pane.(Focusable)
+		if !ok {
+			continue
+		}
+		if n == focused {
+			f.Focus()
+		} else {
+			f.Blur()
+		}
+	}
+}
+
+// updatePaneAt sends a mouse message to the pane under x and y, relative to the pane's content
+func (m *Model) updatePaneAt(msg tea.MouseMsg, x, y int) tea.Cmd {
+	for _, n := range m.panes() {
+		if !n.contains(x, y) {
+			continue
+		}
+		contentX, contentY := n.x, n.y
+		if m.bordersEnabled {
+			contentX, contentY = contentX+1, contentY+1
+		}
+		mouse := msg.Mouse()
+		mouse.X, mouse.Y = x-contentX, y-contentY
+		switch msg.(type) {
+		case tea.MouseClickMsg:
+			return n.Pane.Update(tea.MouseClickMsg(mouse))
+		case tea.MouseReleaseMsg:
+			return n.Pane.Update(tea.MouseReleaseMsg(mouse))
+		case tea.MouseWheelMsg:
+			return n.Pane.Update(tea.MouseWheelMsg(mouse))
+		case tea.MouseMotionMsg:
+			return n.Pane.Update(tea.MouseMotionMsg(mouse))
+		}
+	}
+	return nil
+}
+
+// layout positions every node within the layout and sizes the panes
+func (m *Model) layout() {
+	if m.root == nil {
+		return
+	}
+	m.layoutNode(m.root, 0, 0, max(0, m.width), max(0, m.height))
+}
+
+func (m *Model) layoutNode(n *Node, x, y, width, height int) {
+	n.x, n.y, n.width, n.height = x, y, width, height
+	if n.Pane != nil {
+		if m.bordersEnabled {
+			width, height = max(0, width-2), max(0, height-2)
+		}
+		n.Pane.SetWidth(width)
+		n.Pane.SetHeight(height)
+		return
+	}
+
+	sizes := childSizes(n, alongDirection(n.Direction, width, height))
+	offset := 0
+	for i, c := range n.Children {
+		if n.Direction == Horizontal {
+			m.layoutNode(c, x+offset, y, sizes[i], height)
+		} else {
+			m.layoutNode(c, x, y+offset, width, sizes[i])
+		}
+		offset += sizes[i]
+	}
+}
+
+// childSizes splits total between the children of a split. Fixed size children get their size while space remains,
+// and the rest is shared by Ratio
+func childSizes(n *Node, total int) []int {
+	sizes := make([]int, len(n.Children))
+	remaining := total
+	totalRatio := 0.0
+	for i, c := range n.Children {
+		if c.Fixed > 0 {
+			sizes[i] = min(c.Fixed, remaining)
+			remaining -= sizes[i]
+		} else {
+			totalRatio += c.ratio()
+		}
+	}
+
+	// round cumulative shares so the sizes always add up to the remaining space
+	cumulativeRatio, assigned := 0.0, 0
+	for i, c := range n.Children {
+		if c.Fixed > 0 {
+			continue
+		}
+		cumulativeRatio += c.ratio()
+		end := int(math.Round(float64(remaining) * cumulativeRatio / totalRatio))
+		sizes[i] = end - assigned
+		assigned = end
+	}
+	return sizes
+}
+
+// resizeFocused grows the focused pane by delta cells within the nearest enclosing split that has a neighbor to take
+// space from
+func (m *Model) resizeFocused(delta int) {
+	focused := m.GetFocusedNode()
+	if focused == nil {
+		return
+	}
+	path := pathTo(m.root, focused)
+	for i := len(path) - 2; i >= 0; i-- {
+		split, child := path[i], path[i+1]
+		if len(split.Children) < 2 {
+			continue
+		}
+		childIdx := 0
+		for j, c := range split.Children {
+			if c == child {
+				childIdx = j
+			}
+		}
+		if childIdx < len(split.Children)-1 {
+			m.moveDivider(split, childIdx, delta)
+		} else {
+			m.moveDivider(split, childIdx-1, -delta)
+		}
+		return
+	}
+}
+
+// moveDivider moves the divider after the child at childIdx of split by delta cells, as far as the minimum pane size
+// allows, returning how far it moved
+func (m *Model) moveDivider(split *Node, childIdx, delta int) int {
+	if childIdx < 0 || childIdx >= len(split.Children)-1 {
+		return 0
+	}
+	sizes := make([]int, len(split.Children))
+	for i, c := range split.Children {
+		sizes[i] = alongDirection(split.Direction, c.width, c.height)
+	}
+	minSize := 1
+	if m.bordersEnabled {
+		minSize = 3
+	}
+	delta = max(delta, min(0, minSize-sizes[childIdx]))
+	delta = min(delta, max(0, sizes[childIdx+1]-minSize))
+	if delta == 0 {
+		return 0
+	}
+	sizes[childIdx] += delta
+	sizes[childIdx+1] -= delta
+
+	// fixed size children keep their new size, and the rest share the remaining space in proportion to their new size
+	for i, c := range split.Children {
+		if c.Fixed > 0 {
+			c.Fixed = max(1, sizes[i])
+		} else {
+			c.Ratio = float64(max(1, sizes[i]))
+		}
+	}
+	m.layout()
+	return delta
+}
+
+// dividerAt returns the split and child index of the divider at x and y. With borders, a divider is the borders on
+// either side of it. Without, it is the first row or column after it
+func (m *Model) dividerAt(n *Node, x, y int) (*Node, int, bool) {
+	if n == nil || n.Pane != nil || !n.contains(x, y) {
+		return nil, 0, false
+	}
+	pos := alongDirection(n.Direction, x, y)
+	for i := 0; i < len(n.Children)-1; i++ {
+		next := n.Children[i+1]
+		boundary := alongDirection(n.Direction, next.x, next.y)
+		if pos == boundary || (m.bordersEnabled && pos == boundary-1) {
+			return n, i, true
+		}
+	}
+	for _, c := range n.Children {
+		if split, childIdx, ok := m.dividerAt(c, x, y); ok {
+			return split, childIdx, true
+		}
+	}
+	return nil, 0, false
+}
+
+// render returns the lines of node n
+func (m *Model) render(n *Node) []string {
+	if n.Pane != nil {
+		return m.renderPane(n)
+	}
+	var lines []string
+	if n.Direction == Vertical {
+		for _, c := range n.Children {
+			lines = append(lines, m.render(c)...)
+		}
+		return lines
+	}
+	lines = make([]string, n.height)
+	for _, c := range n.Children {
+		childLines := m.render(c)
+		for i := range lines {
+			if i < len(childLines) {
+				lines[i] += childLines[i]
+			}
+		}
+	}
+	return lines
+}
+
+func (m *Model) renderPane(n *Node) []string {
+	lines := make([]string, 0, n.height)
+	if !m.bordersEnabled {
+		content := strings.Split(n.Pane.View(), "\n")
+		for i := range n.height {
+			lines = append(lines, fit(lineAt(content, i), n.width))
+		}
+		return lines
+	}
+	if n.width < 2 || n.height < 2 {
+		// too small for a border
+		for range n.height {
+			lines = append(lines, strings.Repeat(" ", n.width))
+		}
+		return lines
+	}
+
+	borderStyle, titleStyle := m.styles.BorderStyle, m.styles.TitleStyle
+	if n == m.GetFocusedNode() {
+		borderStyle, titleStyle = m.styles.FocusedBorderStyle, m.styles.FocusedTitleStyle
+	}
+	border := lipgloss.RoundedBorder()
+	innerWidth := n.width - 2
+
+	// the title follows a single border character, with a space either side if they fit
+	top := borderStyle.Render(border.TopLeft)
+	titleWidth := 0
+	if n.Title != "" && innerWidth > 1 {
+		title := n.Title
+		if lipgloss.Width(title)+3 <= innerWidth {
+			title = " " + title + " "
+		}
+		title, titleWidth = linebuffer.New(title).Take(0, innerWidth-1, "", linebuffer.HighlightData{}, lipgloss.NewStyle())
+		top += borderStyle.Render(border.Top) + titleStyle.Render(title)
+		titleWidth++
+	}
+	top += borderStyle.Render(strings.Repeat(border.Top, innerWidth-titleWidth) + border.TopRight)
+	lines = append(lines, top)
+
+	content := strings.Split(n.Pane.View(), "\n")
+	left, right := borderStyle.Render(border.Left), borderStyle.Render(border.Right)
+	for i := range n.height - 2 {
+		lines = append(lines, left+fit(lineAt(content, i), innerWidth)+right)
+	}
+	lines = append(lines, borderStyle.Render(border.BottomLeft+strings.Repeat(border.Bottom, innerWidth)+border.BottomRight))
+	return lines
+}
+
+// fit truncates or pads line to width terminal cells
+func fit(line string, width int) string {
+	if lipgloss.Width(line) > width {
+		line, _ = linebuffer.New(line).Take(0, width, "", linebuffer.HighlightData{}, lipgloss.NewStyle())
+	}
+	return line + strings.Repeat(" ", max(0, width-lipgloss.Width(line)))
+}
+
+func lineAt(lines []string, i int) string {
+	if i < len(lines) {
+		return lines[i]
+	}
+	return ""
+}
+
+// pathTo returns the nodes from root down to target, or nil if target isn't in the tree
+func pathTo(root, target *Node) []*Node {
+	if root == nil {
+		return nil
+	}
+	if root == target {
+		return []*Node{root}
+	}
+	for _, c := range root.Children {
+		if path := pathTo(c, target); path != nil {
+			return append([]*Node{root}, path...)
+		}
+	}
+	return nil
+}
+
+// alongDirection returns x for Horizontal splits and y for Vertical splits
+func alongDirection(direction Direction, x, y int) int {
+	if direction == Horizontal {
+		return x
+	}
+	return y
+}
+
+func clampValZeroToMax(v, maxVal int) int {
+	return max(0, min(maxVal, v))
+}
@@ -0,0 +1,314 @@
+package layout
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/bubbles/v2/key"
+	tea "github.com/charmbracelet/bubbletea/v2"
+	"github.com/charmbracelet/lipgloss/v2"
+	"github.com/google/go-cmp/cmp"
+	"github.com/robinovitch61/bubbleo/internal/testutil"
+	"github.com/robinovitch61/bubbleo/viewport"
+	"github.com/robinovitch61/bubbleo/viewport/linebuffer"
+)
+
+var (
+	downKeyMsg      = tea.KeyPressMsg{Code: 'j', Text: "j"}
+	focusNextKeyMsg = tea.KeyPressMsg{Code: tea.KeyTab}
+	growKeyMsg      = tea.KeyPressMsg{Code: '+', Text: "+"}
+	shrinkKeyMsg    = tea.KeyPressMsg{Code: '-', Text: "-"}
+)
+
+// recordingPane is a Pane that records the messages it receives
+type recordingPane struct {
+	width, height int
+	msgs          []tea.Msg
+}
+
+func (p *recordingPane) Update(msg tea.Msg) tea.Cmd {
+	p.msgs = append(p.msgs, msg)
+	return nil
+}
+
+func (p *recordingPane) View() string {
+	return ""
+}
+
+func (p *recordingPane) SetWidth(width int) {
+	p.width = width
+}
+
+func (p *recordingPane) SetHeight(height int) {
+	p.height = height
+}
+
+func newViewport(content ...string) *viewport.Model[viewport.RenderableString] {
+	vp := viewport.New[viewport.RenderableString](0, 0, viewport.KeyMap{
+		Down: key.NewBinding(key.WithKeys("j")),
+	}, viewport.Styles{
+		SelectedItemStyle: lipgloss.NewStyle(),
+	})
+	vp.SetFooterEnabled(false)
+	var items []viewport.RenderableString
+	for _, c := range content {
+		items = append(items, viewport.RenderableString{LineBuffer: linebuffer.New(c)})
+	}
+	vp.SetContent(items)
+	return &vp
+}
+
+func newLayout(root *Node, width, height int) Model {
+	l := New(root, width, height)
+	l.SetKeyMap(KeyMap{
+		FocusNext: key.NewBinding(key.WithKeys("tab")),
+		FocusPrev: key.NewBinding(key.WithKeys("shift+tab")),
+		Grow:      key.NewBinding(key.WithKeys("+")),
+		Shrink:    key.NewBinding(key.WithKeys("-")),
+	})
+	return l
+}
+
+func TestLayout_View(t *testing.T) {
+	left := newViewport("first", "second", "third")
+	right := newViewport("a long line of details")
+	l := newLayout(NewSplit(Horizontal,
+		NewPane("logs", Viewport(left)),
+		NewPane("details", Viewport(right)).WithRatio(2),
+	), 30, 4)
+	expected := strings.Join([]string{
+		"╭─ logs ─╮╭─ details ────────╮",
+		"│first   ││a long line of ...│",
+		"│second  ││                  │",
+		"╰────────╯╰──────────────────╯",
+	}, "\n")
+	testutil.CmpStr(t, expected, l.View())
+
+	l.SetBordersEnabled(false)
+	expected = strings.Join([]string{
+		"first     a long line of de...",
+		"second                        ",
+		"third                         ",
+		"                              ",
+	}, "\n")
+	testutil.CmpStr(t, expected, l.View())
+}
+
+func TestLayout_Sizes(t *testing.T) {
+	tests := []struct {
+		name     string
+		children []*Node
+		total    int
+		expected []int
+	}{
+		{
+			name:     "equal ratios",
+			children: []*Node{{}, {}, {}},
+			total:    10,
+			expected: []int{3, 4, 3},
+		},
+		{
+			name:     "ratios",
+			children: []*Node{{Ratio: 1}, {Ratio: 3}},
+			total:    20,
+			expected: []int{5, 15},
+		},
+		{
+			name:     "fixed and ratio",
+			children: []*Node{{Fixed: 4}, {}, {Ratio: 2}},
+			total:    10,
+			expected: []int{4, 2, 4},
+		},
+		{
+			name:     "fixed larger than total",
+			children: []*Node{{Fixed: 8}, {Fixed: 8}, {}},
+			total:    10,
+			expected: []int{8, 2, 0},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sizes := childSizes(&Node{Children: tt.children}, tt.total)
+			if diff := cmp.Diff(tt.expected, sizes); diff != "" {
+				t.Errorf("Diff (-expected +actual):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestLayout_NestedSizesPropagate(t *testing.T) {
+	top, bottomLeft, bottomRight := &recordingPane{}, &recordingPane{}, &recordingPane{}
+	l := newLayout(NewSplit(Vertical,
+		NewPane("top", top).WithFixed(5),
+		NewSplit(Horizontal,
+			NewPane("bottom left", bottomLeft),
+			NewPane("bottom right", bottomRight),
+		),
+	), 40, 20)
+
+	expectedSizes := func(expected [][2]int) {
+		t.Helper()
+		for i, p := range []*recordingPane{top, bottomLeft, bottomRight} {
+			if p.width != expected[i][0] || p.height != expected[i][1] {
+				t.Errorf("pane %d: expected %dx%d, got %dx%d", i, expected[i][0], expected[i][1], p.width, p.height)
+			}
+		}
+	}
+	expectedSizes([][2]int{{38, 3}, {18, 13}, {18, 13}})
+
+	l.SetWidth(41)
+	l.SetHeight(10)
+	expectedSizes([][2]int{{39, 3}, {19, 3}, {18, 3}})
+}
+
+func TestLayout_FocusAndKeyRouting(t *testing.T) {
+	left, right := &recordingPane{}, &recordingPane{}
+	l := newLayout(NewSplit(Horizontal, NewPane("left", left), NewPane("right", right)), 20, 5)
+	if l.GetFocusedNode().Pane != left {
+		t.Fatalf("expected first pane to be focused")
+	}
+
+	l, _ = l.Update(downKeyMsg)
+	l, _ = l.Update(focusNextKeyMsg)
+	l, _ = l.Update(downKeyMsg)
+	l, _ = l.Update(downKeyMsg)
+	if len(left.msgs) != 1 || len(right.msgs) != 2 {
+		t.Errorf("expected keys to go to the focused pane, got %d and %d", len(left.msgs), len(right.msgs))
+	}
+
+	// focus wraps around
+	l, _ = l.Update(focusNextKeyMsg)
+	if l.GetFocusedNode().Pane != left {
+		t.Errorf("expected focus to wrap around to the first pane")
+	}
+	if !l.Focus(right) || l.GetFocusedNode().Pane != right {
+		t.Errorf("expected right pane to be focused")
+	}
+
+	// other messages go to every pane
+	type tickMsg struct{}
+	l, _ = l.Update(tickMsg{})
+	if len(left.msgs) != 2 || len(right.msgs) != 3 {
+		t.Errorf("expected other messages to go to every pane, got %d and %d", len(left.msgs), len(right.msgs))
+	}
+}
+
+func TestLayout_FocusedBorderStyle(t *testing.T) {
+	l := newLayout(NewSplit(Horizontal,
+		NewPane("a", &recordingPane{}),
+		NewPane("b", &recordingPane{}),
+	), 12, 3)
+	l.SetStyles(Styles{FocusedBorderStyle: lipgloss.NewStyle().Bold(true)})
+	expected := strings.Join([]string{
+		"\x1b[1m╭\x1b[m\x1b[1m─\x1b[m a \x1b[1m╮\x1b[m╭─ b ╮",
+		"\x1b[1m│\x1b[m    \x1b[1m│\x1b[m│    │",
+		"\x1b[1m╰────╯\x1b[m╰────╯",
+	}, "\n")
+	testutil.CmpStr(t, expected, l.View())
+}
+
+func TestLayout_ResizeWithKeys(t *testing.T) {
+	left, right, bottom := &recordingPane{}, &recordingPane{}, &recordingPane{}
+	l := newLayout(NewSplit(Vertical,
+		NewSplit(Horizontal, NewPane("left", left), NewPane("right", right).WithFixed(10)),
+		NewPane("bottom", bottom),
+	), 20, 10)
+	if left.width != 8 || right.width != 8 {
+		t.Fatalf("expected widths 8 and 8, got %d and %d", left.width, right.width)
+	}
+
+	l, _ = l.Update(growKeyMsg)
+	l, _ = l.Update(growKeyMsg)
+	if left.width != 10 || right.width != 6 {
+		t.Errorf("expected widths 10 and 6, got %d and %d", left.width, right.width)
+	}
+
+	// the last pane of a split grows by moving the divider before it
+	l.Focus(right)
+	l, _ = l.Update(growKeyMsg)
+	if left.width != 9 || right.width != 7 {
+		t.Errorf("expected widths 9 and 7, got %d and %d", left.width, right.width)
+	}
+
+	// fixed sizes stay fixed when the layout is resized
+	l.SetWidth(30)
+	if left.width != 19 || right.width != 7 {
+		t.Errorf("expected widths 19 and 7, got %d and %d", left.width, right.width)
+	}
+
+	// panes can't shrink below their borders plus one cell
+	for range 10 {
+		l, _ = l.Update(shrinkKeyMsg)
+	}
+	if right.width != 1 {
+		t.Errorf("expected width 1, got %d", right.width)
+	}
+
+	// a pane that's alone in its split resizes within the enclosing split
+	l.Focus(bottom)
+	l, _ = l.Update(shrinkKeyMsg)
+	if bottom.height != 2 || left.height != 4 {
+		t.Errorf("expected heights 2 and 4, got %d and %d", bottom.height, left.height)
+	}
+}
+
+func TestLayout_Mouse(t *testing.T) {
+	left, right := &recordingPane{}, &recordingPane{}
+	l := newLayout(NewSplit(Horizontal, NewPane("left", left), NewPane("right", right)), 20, 5)
+	l.SetOrigin(2, 1)
+
+	// clicking a pane focuses it and sends the click in pane coordinates
+	l, _ = l.Update(tea.MouseClickMsg{X: 2 + 14, Y: 1 + 2, Button: tea.MouseLeft})
+	if l.GetFocusedNode().Pane != right {
+		t.Fatalf("expected right pane to be focused")
+	}
+	if diff := cmp.Diff([]tea.Msg{tea.MouseClickMsg{X: 3, Y: 1, Button: tea.MouseLeft}}, right.msgs); diff != "" {
+		t.Errorf("Diff (-expected +actual):\n%s", diff)
+	}
+
+	// dragging the divider resizes the panes
+	l, _ = l.Update(tea.MouseClickMsg{X: 2 + 9, Y: 1 + 2, Button: tea.MouseLeft})
+	l, _ = l.Update(tea.MouseMotionMsg{X: 2 + 12, Y: 1 + 2, Button: tea.MouseLeft})
+	l, _ = l.Update(tea.MouseMotionMsg{X: 2 + 11, Y: 1 + 3, Button: tea.MouseLeft})
+	l, _ = l.Update(tea.MouseReleaseMsg{X: 2 + 11, Y: 1 + 3, Button: tea.MouseLeft})
+	if left.width != 10 || right.width != 6 {
+		t.Errorf("expected widths 10 and 6, got %d and %d", left.width, right.width)
+	}
+	if len(right.msgs) != 1 || len(left.msgs) != 0 {
+		t.Errorf("expected drag not to reach panes, got %d and %d messages", len(left.msgs), len(right.msgs))
+	}
+
+	// dragging past the minimum size stops at it
+	l, _ = l.Update(tea.MouseClickMsg{X: 2 + 12, Y: 1, Button: tea.MouseLeft})
+	l, _ = l.Update(tea.MouseMotionMsg{X: 2 + 30, Y: 1, Button: tea.MouseLeft})
+	l, _ = l.Update(tea.MouseMotionMsg{X: 2 + 15, Y: 1, Button: tea.MouseLeft})
+	if left.width != 13 || right.width != 3 {
+		t.Errorf("expected widths 13 and 3, got %d and %d", left.width, right.width)
+	}
+}
+
+func TestLayout_Viewport(t *testing.T) {
+	left := newViewport("first", "second", "third", "fourth")
+	right := newViewport("a", "b", "c", "d")
+	left.SetSelectionEnabled(true)
+	right.SetSelectionEnabled(true)
+	l := newLayout(NewSplit(Horizontal, NewPane("left", Viewport(left)), NewPane("right", Viewport(right))), 20, 4)
+
+	l, _ = l.Update(downKeyMsg)
+	if left.GetSelectedItemIdx() != 1 || right.GetSelectedItemIdx() != 0 {
+		t.Errorf("expected only the focused viewport to move, got %d and %d", left.GetSelectedItemIdx(), right.GetSelectedItemIdx())
+	}
+	if left.GetWidth() != 8 || left.GetHeight() != 2 {
+		t.Errorf("expected viewport size 8x2, got %dx%d", left.GetWidth(), left.GetHeight())
+	}
+
+	// only the focused viewport is focused
+	if !left.Focused() || right.Focused() {
+		t.Errorf("expected only the left viewport to be focused")
+	}
+	l, _ = l.Update(focusNextKeyMsg)
+	if left.Focused() || !right.Focused() {
+		t.Errorf("expected only the right viewport to be focused")
+	}
+}
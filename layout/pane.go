@@ -0,0 +1,65 @@
+package layout
+
+import (
+	tea "github.com/charmbracelet/bubbletea/v2"
+	"github.com/robinovitch61/bubbleo/viewport"
+)
+
+// Pane is a component that can be arranged in a layout
+type Pane interface {
+	// Update processes a message, returning a command
+	Update(msg tea.Msg) tea.Cmd
+
+	// View renders the pane, which should fill its width and height
+	View() string
+
+	// SetWidth sets the width of the pane
+	SetWidth(width int)
+
+	// SetHeight sets the height of the pane
+	SetHeight(height int)
+}
+
+// Focusable is implemented by panes that behave or render differently when focused, like viewports. The layout
+// focuses the focused pane and blurs the others
+type Focusable interface {
+	Focus()
+	Blur()
+}
+
+// viewportPane adapts a viewport to a Pane
+type viewportPane[T viewport.Renderable] struct {
+	vp *viewport.Model[T]
+}
+
+// Viewport returns a Pane for vp. The layout updates, resizes, focuses, and blurs vp in place, so the caller can keep
+// using vp directly
+func Viewport[T viewport.Renderable](vp *viewport.Model[T]) Pane {
+	return viewportPane[T]{vp: vp}
+}
+
+func (p viewportPane[T]) Update(msg tea.Msg) tea.Cmd {
+	var cmd tea.Cmd
+	*p.vp, cmd = p.vp.Update(msg)
+	return cmd
+}
+
+func (p viewportPane[T]) View() string {
+	return p.vp.View()
+}
+
+func (p viewportPane[T]) SetWidth(width int) {
+	p.vp.SetWidth(width)
+}
+
+func (p viewportPane[T]) SetHeight(height int) {
+	p.vp.SetHeight(height)
+}
+
+func (p viewportPane[T]) Focus() {
+	p.vp.Focus()
+}
+
+func (p viewportPane[T]) Blur() {
+	p.vp.Blur()
+}
@@ -0,0 +1,28 @@
+package search
+
+import "github.com/charmbracelet/bubbles/v2/key"
+
+// KeyMap contains search key bindings. While the prompt is open, other keys edit the query. While it's closed, other
+// keys go to the viewport
+type KeyMap struct {
+	// SearchForward opens the prompt to search toward the end of the content
+	SearchForward key.Binding
+	// SearchBackward opens the prompt to search toward the start of the content
+	SearchBackward key.Binding
+	// NextMatch jumps to the next match in the direction of the last search
+	NextMatch key.Binding
+	// PrevMatch jumps to the next match against the direction of the last search
+	PrevMatch key.Binding
+	// Submit closes the prompt and jumps to the first match
+	Submit key.Binding
+	// Cancel closes the prompt and restores the last submitted search
+	Cancel key.Binding
+	// ToggleRegex toggles whether the query is a regular expression while the prompt is open
+	ToggleRegex key.Binding
+	// ToggleCaseSensitive toggles whether matching is case-sensitive while the prompt is open
+	ToggleCaseSensitive key.Binding
+	// HistoryPrev replaces the query with the previous submitted query while the prompt is open
+	HistoryPrev key.Binding
+	// HistoryNext replaces the query with the next submitted query while the prompt is open
+	HistoryNext key.Binding
+}
@@ -0,0 +1,409 @@
+package search
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/v2/key"
+	"github.com/charmbracelet/bubbles/v2/textinput"
+	tea "github.com/charmbracelet/bubbletea/v2"
+	"github.com/charmbracelet/lipgloss/v2"
+	"github.com/robinovitch61/bubbleo/viewport"
+	"github.com/robinovitch61/bubbleo/viewport/linebuffer"
+)
+
+// Direction is which way a search moves through the content from the current item
+type Direction int
+
+const (
+	// Forward searches toward the end of the content
+	Forward Direction = iota
+	// Backward searches toward the start of the content
+	Backward
+)
+
+// Styles contains styling configuration for the search prompt
+type Styles struct {
+	// PromptStyle styles the "/" or "?" at the start of the prompt
+	PromptStyle lipgloss.Style
+	// InputStyle styles the query
+	InputStyle lipgloss.Style
+	// ToggleStyle styles the indicators for the regex and case-sensitive toggles
+	ToggleStyle lipgloss.Style
+	// ErrorStyle styles invalid regex errors and the message when nothing matches
+	ErrorStyle lipgloss.Style
+}
+
+// query is a search as typed in the prompt
+type query struct {
+	text          string
+	regex         bool
+	caseSensitive bool
+}
+
+// caseMode returns how the query compares letter case
+func (q query) caseMode() linebuffer.CaseMode {
+	if q.caseSensitive {
+		return linebuffer.CaseSensitive
+	}
+	return linebuffer.CaseInsensitive
+}
+
+// Model represents a search prompt for a viewport. It takes over the bottom line of the viewport while open,
+// highlighting matches as the query is typed, and jumps between matching items once submitted
+type Model[T viewport.Renderable] struct {
+	// vp is the searched viewport
+	vp *viewport.Model[T]
+
+	// keyMap contains the search key bindings
+	keyMap KeyMap
+
+	// styles contains the styles for the prompt
+	styles Styles
+
+	// input is the query being typed
+	input textinput.Model
+
+	// active is true while the prompt is open
+	active bool
+
+	// direction is the direction of the open prompt, or of the last submitted search if closed
+	direction Direction
+
+	// regex is true if queries are regular expressions rather than literal strings
+	regex bool
+
+	// caseSensitive is true if matching is case-sensitive
+	caseSensitive bool
+
+	// submitted is the last submitted search, restored if the prompt is cancelled
+	submitted query
+
+	// history contains submitted query text, oldest first, without duplicates
+	history []string
+
+	// historyIdx is the index in history shown in the prompt, or len(history) for the query being typed
+	historyIdx int
+
+	// draft is the query being typed, kept while browsing history
+	draft string
+
+	// err is the error from the query in the open prompt, e.g. an invalid regex
+	err error
+
+	// status is a message shown in place of the prompt after a search until the next key press
+	status string
+}
+
+// New creates a new search prompt for vp with case-sensitive, literal string matching. The prompt updates and
+// highlights vp in place, so the caller can keep using vp directly
+func New[T viewport.Renderable](vp *viewport.Model[T], keyMap KeyMap, styles Styles) (m Model[T]) {
+	m.vp = vp
+	m.keyMap = keyMap
+	m.caseSensitive = true
+	m.input = textinput.New()
+	m.input.Prompt = ""
+	m.SetStyles(styles)
+	return m
+}
+
+// Update processes messages and updates the model. While the prompt is open, keys edit the query and other messages
+// also go to the viewport. While it's closed, keys other than the search bindings go to the viewport
+func (m *Model[T]) Update(msg tea.Msg) (Model[T], tea.Cmd) {
+	keyMsg, isKey := msg.(tea.KeyMsg)
+	if isKey {
+		m.status = ""
+	}
+
+	if m.active {
+		if isKey {
+			return *m, m.updatePrompt(keyMsg)
+		}
+		var inputCmd, vpCmd tea.Cmd
+		m.input, inputCmd = m.input.Update(msg)
+		*m.vp, vpCmd = m.vp.Update(msg)
+		return *m, tea.Batch(inputCmd, vpCmd)
+	}
+
+	if isKey {
+		switch {
+		case key.Matches(keyMsg, m.keyMap.SearchForward):
+			return *m, m.Open(Forward)
+		case key.Matches(keyMsg, m.keyMap.SearchBackward):
+			return *m, m.Open(Backward)
+		case key.Matches(keyMsg, m.keyMap.NextMatch):
+			m.jump(m.direction)
+			return *m, nil
+		case key.Matches(keyMsg, m.keyMap.PrevMatch):
+			m.jump(1 - m.direction)
+			return *m, nil
+		}
+	}
+
+	var cmd tea.Cmd
+	*m.vp, cmd = m.vp.Update(msg)
+	return *m, cmd
+}
+
+// View renders the viewport, with the prompt or the last search's message on its bottom line
+func (m *Model[T]) View() string {
+	view := m.vp.View()
+	var line string
+	switch {
+	case m.active:
+		line = m.promptView()
+	case m.status != "":
+		line = m.styles.ErrorStyle.Render(m.status)
+	default:
+		return view
+	}
+	line, lineWidth := linebuffer.New(line).Take(0, m.vp.GetWidth(), "...", linebuffer.HighlightData{}, lipgloss.NewStyle())
+	line += strings.Repeat(" ", max(0, m.vp.GetWidth()-lineWidth))
+
+	lines := strings.Split(view, "\n")
+	if len(lines) < m.vp.GetHeight() {
+		return view + "\n" + line
+	}
+	lines[len(lines)-1] = line
+	return strings.Join(lines, "\n")
+}
+
+// Open opens the prompt to search in direction, starting from an empty query
+func (m *Model[T]) Open(direction Direction) tea.Cmd {
+	m.active = true
+	m.direction = direction
+	m.status = ""
+	m.err = nil
+	m.historyIdx = len(m.history)
+	m.draft = ""
+	m.input.Reset()
+	return m.input.Focus()
+}
+
+// Close closes the prompt without searching, restoring the highlight of the last submitted search
+func (m *Model[T]) Close() {
+	if !m.active {
+		return
+	}
+	m.active = false
+	m.err = nil
+	m.input.Blur()
+	_ = m.highlight(m.submitted)
+}
+
+// IsActive returns true if the prompt is open and receiving keys, e.g. so the caller doesn't treat them as shortcuts
+func (m *Model[T]) IsActive() bool {
+	return m.active
+}
+
+// Clear removes the highlight of the last submitted search
+func (m *Model[T]) Clear() {
+	m.submitted = query{}
+	m.status = ""
+	_ = m.highlight(m.submitted)
+}
+
+// GetQuery returns the text of the last submitted search
+func (m *Model[T]) GetQuery() string {
+	return m.submitted.text
+}
+
+// SetKeyMap sets the search key bindings
+func (m *Model[T]) SetKeyMap(keyMap KeyMap) {
+	m.keyMap = keyMap
+}
+
+// SetStyles sets the styles for the prompt
+func (m *Model[T]) SetStyles(styles Styles) {
+	m.styles = styles
+	inputStyles := m.input.Styles
+	inputStyles.Focused.Text = styles.InputStyle
+	inputStyles.Blurred.Text = styles.InputStyle
+	m.input.Styles = inputStyles
+}
+
+// SetRegex sets whether queries are regular expressions rather than literal strings
+func (m *Model[T]) SetRegex(regex bool) {
+	m.regex = regex
+	m.refresh()
+}
+
+// GetRegex returns whether queries are regular expressions
+func (m *Model[T]) GetRegex() bool {
+	return m.regex
+}
+
+// SetCaseSensitive sets whether matching is case-sensitive
+func (m *Model[T]) SetCaseSensitive(caseSensitive bool) {
+	m.caseSensitive = caseSensitive
+	m.refresh()
+}
+
+// GetCaseSensitive returns whether matching is case-sensitive
+func (m *Model[T]) GetCaseSensitive() bool {
+	return m.caseSensitive
+}
+
+// SetHistory sets the submitted queries browsed with the history keys, oldest first, e.g. to restore persisted history
+func (m *Model[T]) SetHistory(history []string) {
+	m.history = nil
+	for _, text := range history {
+		m.addToHistory(text)
+	}
+	m.historyIdx = len(m.history)
+}
+
+// GetHistory returns the submitted queries, oldest first, e.g. for the caller to persist them
+func (m *Model[T]) GetHistory() []string {
+	return slices.Clone(m.history)
+}
+
+func (m *Model[T]) updatePrompt(msg tea.KeyMsg) tea.Cmd {
+	switch {
+	case key.Matches(msg, m.keyMap.Submit):
+		m.submit()
+		return nil
+	case key.Matches(msg, m.keyMap.Cancel):
+		m.Close()
+		return nil
+	case key.Matches(msg, m.keyMap.ToggleRegex):
+		m.SetRegex(!m.regex)
+		return nil
+	case key.Matches(msg, m.keyMap.ToggleCaseSensitive):
+		m.SetCaseSensitive(!m.caseSensitive)
+		return nil
+	case key.Matches(msg, m.keyMap.HistoryPrev):
+		m.browseHistory(-1)
+		return nil
+	case key.Matches(msg, m.keyMap.HistoryNext):
+		m.browseHistory(1)
+		return nil
+	}
+
+	prevValue := m.input.Value()
+	var cmd tea.Cmd
+	m.input, cmd = m.input.Update(msg)
+	if m.input.Value() != prevValue {
+		m.historyIdx = len(m.history)
+		m.refresh()
+	}
+	return cmd
+}
+
+// submit closes the prompt and jumps to the first match of its query. If the query is invalid, the prompt stays open
+func (m *Model[T]) submit() {
+	q := m.currentQuery()
+	if err := m.highlight(q); err != nil {
+		m.err = err
+		return
+	}
+	m.active = false
+	m.err = nil
+	m.input.Blur()
+	m.submitted = q
+	if q.text == "" {
+		return
+	}
+	m.addToHistory(q.text)
+	m.jump(m.direction)
+}
+
+// jump goes to the nearest item matching the last submitted search in direction
+func (m *Model[T]) jump(direction Direction) {
+	if m.submitted.text == "" {
+		return
+	}
+	found := false
+	if direction == Forward {
+		found = m.vp.NextMatch()
+	} else {
+		found = m.vp.PrevMatch()
+	}
+	if !found {
+		m.status = fmt.Sprintf("Pattern not found: %s", m.submitted.text)
+	}
+}
+
+// refresh highlights the query in the open prompt as it changes
+func (m *Model[T]) refresh() {
+	if m.active {
+		m.err = m.highlight(m.currentQuery())
+	}
+}
+
+// highlight highlights q in the viewport. If q is invalid, the highlight is left as is
+func (m *Model[T]) highlight(q query) error {
+	if q.text == "" || !q.regex {
+		m.vp.SetHighlightCaseMode(q.caseMode())
+		m.vp.SetStringToHighlight(q.text)
+		return nil
+	}
+	re, err := regexp.Compile(q.text)
+	if err != nil {
+		return err
+	}
+	m.vp.SetHighlightCaseMode(q.caseMode())
+	m.vp.SetRegexToHighlight(re)
+	return nil
+}
+
+func (m *Model[T]) currentQuery() query {
+	return query{text: m.input.Value(), regex: m.regex, caseSensitive: m.caseSensitive}
+}
+
+// browseHistory replaces the query with the history entry step away from the one shown, or the draft past the newest
+func (m *Model[T]) browseHistory(step int) {
+	idx := m.historyIdx + step
+	if idx < 0 || idx > len(m.history) {
+		return
+	}
+	if m.historyIdx == len(m.history) {
+		m.draft = m.input.Value()
+	}
+	m.historyIdx = idx
+	if idx == len(m.history) {
+		m.input.SetValue(m.draft)
+	} else {
+		m.input.SetValue(m.history[idx])
+	}
+	m.input.CursorEnd()
+	m.refresh()
+}
+
+// addToHistory appends text to the history, moving it to the end if already present
+func (m *Model[T]) addToHistory(text string) {
+	if text == "" {
+		return
+	}
+	m.history = slices.DeleteFunc(m.history, func(s string) bool { return s == text })
+	m.history = append(m.history, text)
+}
+
+// promptView renders the open prompt, with the toggle indicators and any error right-aligned
+func (m *Model[T]) promptView() string {
+	prompt := "/"
+	if m.direction == Backward {
+		prompt = "?"
+	}
+
+	var suffix []string
+	if m.err != nil {
+		suffix = append(suffix, m.styles.ErrorStyle.Render(m.err.Error()))
+	}
+	if m.regex {
+		suffix = append(suffix, m.styles.ToggleStyle.Render("[.*]"))
+	}
+	if m.caseSensitive {
+		suffix = append(suffix, m.styles.ToggleStyle.Render("[Aa]"))
+	}
+	suffixView := strings.Join(suffix, " ")
+	if suffixView != "" {
+		suffixView = " " + suffixView
+	}
+
+	// the input pads itself to its width, pushing the suffix to the right edge. One cell is left for the cursor
+	m.input.SetWidth(max(1, m.vp.GetWidth()-lipgloss.Width(prompt)-lipgloss.Width(suffixView)-1))
+	return m.styles.PromptStyle.Render(prompt) + m.input.View() + suffixView
+}
@@ -0,0 +1,293 @@
+package search
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/bubbles/v2/key"
+	tea "github.com/charmbracelet/bubbletea/v2"
+	"github.com/charmbracelet/lipgloss/v2"
+	"github.com/google/go-cmp/cmp"
+	"github.com/robinovitch61/bubbleo/internal/testutil"
+	"github.com/robinovitch61/bubbleo/viewport"
+	"github.com/robinovitch61/bubbleo/viewport/linebuffer"
+)
+
+var (
+	red = lipgloss.Color("#ff0000")
+
+	downKeyMsg     = tea.KeyPressMsg{Code: 'j', Text: "j"}
+	forwardKeyMsg  = tea.KeyPressMsg{Code: '/', Text: "/"}
+	backwardKeyMsg = tea.KeyPressMsg{Code: '?', Text: "?"}
+	nextKeyMsg     = tea.KeyPressMsg{Code: 'n', Text: "n"}
+	prevKeyMsg     = tea.KeyPressMsg{Code: 'N', Text: "N"}
+	enterKeyMsg    = tea.KeyPressMsg{Code: tea.KeyEnter}
+	escKeyMsg      = tea.KeyPressMsg{Code: tea.KeyEscape}
+	upKeyMsg       = tea.KeyPressMsg{Code: tea.KeyUp}
+	historyDownMsg = tea.KeyPressMsg{Code: tea.KeyDown}
+	regexKeyMsg    = tea.KeyPressMsg{Code: 'r', Mod: tea.ModAlt}
+	caseKeyMsg     = tea.KeyPressMsg{Code: 'c', Mod: tea.ModAlt}
+)
+
+var keyMap = KeyMap{
+	SearchForward:       key.NewBinding(key.WithKeys("/")),
+	SearchBackward:      key.NewBinding(key.WithKeys("?")),
+	NextMatch:           key.NewBinding(key.WithKeys("n")),
+	PrevMatch:           key.NewBinding(key.WithKeys("N")),
+	Submit:              key.NewBinding(key.WithKeys("enter")),
+	Cancel:              key.NewBinding(key.WithKeys("esc")),
+	ToggleRegex:         key.NewBinding(key.WithKeys("alt+r")),
+	ToggleCaseSensitive: key.NewBinding(key.WithKeys("alt+c")),
+	HistoryPrev:         key.NewBinding(key.WithKeys("up")),
+	HistoryNext:         key.NewBinding(key.WithKeys("down")),
+}
+
+func newSearch(selectionEnabled bool, content ...string) (Model[viewport.RenderableString], *viewport.Model[viewport.RenderableString]) {
+	vp := viewport.New[viewport.RenderableString](30, 4, viewport.KeyMap{
+		Down: key.NewBinding(key.WithKeys("j")),
+	}, viewport.Styles{
+		HighlightStyle:           lipgloss.NewStyle().Foreground(red),
+		HighlightStyleIfSelected: lipgloss.NewStyle().Foreground(red),
+		SelectedItemStyle:        lipgloss.NewStyle(),
+	})
+	vp.SetFooterEnabled(false)
+	vp.SetSelectionEnabled(selectionEnabled)
+	var items []viewport.RenderableString
+	for _, c := range content {
+		items = append(items, viewport.RenderableString{LineBuffer: linebuffer.New(c)})
+	}
+	vp.SetContent(items)
+	return New(&vp, keyMap, Styles{}), &vp
+}
+
+func typeText(s Model[viewport.RenderableString], text string) Model[viewport.RenderableString] {
+	for _, r := range text {
+		s, _ = s.Update(tea.KeyPressMsg{Code: r, Text: string(r)})
+	}
+	return s
+}
+
+func search(s Model[viewport.RenderableString], open tea.KeyPressMsg, text string) Model[viewport.RenderableString] {
+	s, _ = s.Update(open)
+	s = typeText(s, text)
+	s, _ = s.Update(enterKeyMsg)
+	return s
+}
+
+// promptLine is the prompt line with the default toggles for query q
+func promptLine(q string) string {
+	return fmt.Sprintf("%-26s[Aa]", "/"+q)
+}
+
+func TestSearch_LiveHighlight(t *testing.T) {
+	s, _ := newSearch(false, "alpha", "beta", "gamma")
+	s, _ = s.Update(forwardKeyMsg)
+	if !s.IsActive() {
+		t.Fatal("expected prompt to be open")
+	}
+	s = typeText(s, "ta")
+	expected := strings.Join([]string{
+		"alpha                         ",
+		"be" + lipgloss.NewStyle().Foreground(red).Render("ta") + "                          ",
+		"gamma                         ",
+		promptLine("ta"),
+	}, "\n")
+	testutil.CmpStr(t, expected, s.View())
+
+	s, _ = s.Update(tea.KeyPressMsg{Code: tea.KeyBackspace})
+	expected = strings.Join([]string{
+		"alpha                         ",
+		"be" + lipgloss.NewStyle().Foreground(red).Render("t") + "a                          ",
+		"gamma                         ",
+		promptLine("t"),
+	}, "\n")
+	testutil.CmpStr(t, expected, s.View())
+}
+
+func TestSearch_Jump(t *testing.T) {
+	tests := []struct {
+		name     string
+		keys     []tea.KeyPressMsg
+		expected int
+	}{
+		{
+			name:     "forward",
+			keys:     nil,
+			expected: 2,
+		},
+		{
+			name:     "next wraps around",
+			keys:     []tea.KeyPressMsg{nextKeyMsg, nextKeyMsg},
+			expected: 0,
+		},
+		{
+			name:     "prev",
+			keys:     []tea.KeyPressMsg{prevKeyMsg},
+			expected: 0,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, vp := newSearch(true, "alpha", "beta", "alphabet", "alpha beta")
+			s = search(s, forwardKeyMsg, "alpha")
+			if s.IsActive() {
+				t.Fatal("expected prompt to be closed")
+			}
+			for _, msg := range tt.keys {
+				s, _ = s.Update(msg)
+			}
+			if got := vp.GetSelectedItemIdx(); got != tt.expected {
+				t.Errorf("expected selected item %d, got %d", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestSearch_Backward(t *testing.T) {
+	s, vp := newSearch(true, "alpha", "beta", "alphabet", "alpha beta")
+	s = search(s, backwardKeyMsg, "beta")
+	if got := vp.GetSelectedItemIdx(); got != 3 {
+		t.Errorf("expected selected item 3, got %d", got)
+	}
+	// next continues backward
+	s, _ = s.Update(nextKeyMsg)
+	if got := vp.GetSelectedItemIdx(); got != 1 {
+		t.Errorf("expected selected item 1, got %d", got)
+	}
+}
+
+func TestSearch_KeysWhileOpen(t *testing.T) {
+	s, vp := newSearch(true, "alpha", "beta", "gamma")
+	s, _ = s.Update(downKeyMsg)
+	if got := vp.GetSelectedItemIdx(); got != 1 {
+		t.Fatalf("expected keys to reach the viewport while closed, got selected item %d", got)
+	}
+	s, _ = s.Update(forwardKeyMsg)
+	s, _ = s.Update(downKeyMsg)
+	if got := vp.GetSelectedItemIdx(); got != 1 {
+		t.Errorf("expected keys to edit the query while open, got selected item %d", got)
+	}
+	if !strings.HasSuffix(s.View(), promptLine("j")) {
+		t.Errorf("expected query j, got view %q", s.View())
+	}
+}
+
+func TestSearch_NotFound(t *testing.T) {
+	s, _ := newSearch(false, "alpha", "beta", "gamma")
+	s = search(s, forwardKeyMsg, "delta")
+	expected := strings.Join([]string{
+		"alpha                         ",
+		"beta                          ",
+		"gamma                         ",
+		"Pattern not found: delta      ",
+	}, "\n")
+	testutil.CmpStr(t, expected, s.View())
+
+	s, _ = s.Update(downKeyMsg)
+	expected = strings.Join([]string{
+		"alpha                         ",
+		"beta                          ",
+		"gamma                         ",
+		"                              ",
+	}, "\n")
+	testutil.CmpStr(t, expected, s.View())
+}
+
+func TestSearch_CaseSensitivity(t *testing.T) {
+	s, vp := newSearch(true, "alpha", "BETA", "beta")
+	s, _ = s.Update(forwardKeyMsg)
+	s = typeText(s, "BETA")
+	s, _ = s.Update(caseKeyMsg)
+	if s.GetCaseSensitive() {
+		t.Fatal("expected case-insensitive")
+	}
+	if !strings.HasSuffix(s.View(), fmt.Sprintf("%-30s", "/BETA")) {
+		t.Errorf("expected no case indicator, got view %q", s.View())
+	}
+	s, _ = s.Update(enterKeyMsg)
+	s, _ = s.Update(nextKeyMsg)
+	if got := vp.GetSelectedItemIdx(); got != 2 {
+		t.Errorf("expected selected item 2, got %d", got)
+	}
+}
+
+func TestSearch_Regex(t *testing.T) {
+	s, vp := newSearch(true, "alpha", "beta", "gamma")
+	s, _ = s.Update(forwardKeyMsg)
+	s, _ = s.Update(regexKeyMsg)
+	s = typeText(s, "(m")
+	expected := strings.Join([]string{
+		"alpha                         ",
+		"beta                          ",
+		"gamma                         ",
+		"/(m  error parsing regexp: ...",
+	}, "\n")
+	testutil.CmpStr(t, expected, s.View())
+
+	// invalid regex keeps the prompt open
+	s, _ = s.Update(enterKeyMsg)
+	if !s.IsActive() {
+		t.Fatal("expected prompt to stay open")
+	}
+
+	s = typeText(s, ")+a$")
+	s, _ = s.Update(enterKeyMsg)
+	if s.IsActive() {
+		t.Fatal("expected prompt to be closed")
+	}
+	if got := vp.GetSelectedItemIdx(); got != 2 {
+		t.Errorf("expected selected item 2, got %d", got)
+	}
+}
+
+func TestSearch_History(t *testing.T) {
+	s, _ := newSearch(false, "alpha", "beta", "gamma")
+	s = search(s, forwardKeyMsg, "beta")
+	s = search(s, forwardKeyMsg, "gamma")
+	s = search(s, forwardKeyMsg, "beta")
+	if diff := cmp.Diff([]string{"gamma", "beta"}, s.GetHistory()); diff != "" {
+		t.Errorf("Diff (-expected +actual):\n%s", diff)
+	}
+
+	s, _ = s.Update(forwardKeyMsg)
+	s = typeText(s, "al")
+	tests := []struct {
+		msg      tea.KeyPressMsg
+		expected string
+	}{
+		{upKeyMsg, "beta"},
+		{upKeyMsg, "gamma"},
+		{upKeyMsg, "gamma"},
+		{historyDownMsg, "beta"},
+		{historyDownMsg, "al"},
+		{historyDownMsg, "al"},
+	}
+	for i, tt := range tests {
+		s, _ = s.Update(tt.msg)
+		if !strings.HasSuffix(s.View(), promptLine(tt.expected)) {
+			t.Errorf("step %d: expected query %q, got view %q", i, tt.expected, s.View())
+		}
+	}
+}
+
+func TestSearch_CancelRestoresHighlight(t *testing.T) {
+	s, _ := newSearch(false, "alpha", "beta", "gamma")
+	s = search(s, forwardKeyMsg, "beta")
+	s, _ = s.Update(forwardKeyMsg)
+	s = typeText(s, "gam")
+	s, _ = s.Update(escKeyMsg)
+	if s.IsActive() {
+		t.Fatal("expected prompt to be closed")
+	}
+	if s.GetQuery() != "beta" {
+		t.Errorf("expected query beta, got %q", s.GetQuery())
+	}
+	expected := strings.Join([]string{
+		"alpha                         ",
+		lipgloss.NewStyle().Foreground(red).Render("beta") + "                          ",
+		"gamma                         ",
+		"                              ",
+	}, "\n")
+	testutil.CmpStr(t, expected, s.View())
+}
@@ -0,0 +1,262 @@
+package syntax
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// punctuation is the set of characters tokenized as TokenPunctuation
+const punctuation = "{}[]()<>=:;,.+-*/%!&|^~?"
+
+const (
+	// stateBlockComment is the State inside a block comment
+	stateBlockComment State = iota + 1
+	// stateMultilineString is the State inside a string literal quoted with MultilineQuote
+	stateMultilineString
+)
+
+// ConfigLexer is a Lexer for C-like, config, and markup languages, configured by the language's keywords, comments,
+// and string quotes
+type ConfigLexer struct {
+	// Keywords are tokenized as TokenKeyword
+	Keywords []string
+
+	// LineComments are the prefixes that start a comment running to the end of the line, e.g. "//" or "#"
+	LineComments []string
+
+	// BlockCommentStart and BlockCommentEnd delimit comments that can span lines, e.g. "/*" and "*/"
+	BlockCommentStart string
+	BlockCommentEnd   string
+
+	// Quotes are the characters that delimit string literals
+	Quotes string
+
+	// MultilineQuote is the one of Quotes that delimits string literals that can span lines, e.g. '`' for Go raw
+	// strings, or 0 if none can
+	MultilineQuote rune
+
+	// KeySeparators are the characters that, when following the first identifier or string on a line, make it a
+	// TokenKey, e.g. ":" for YAML or "=" for HCL. The identifier or string can come after indentation and a "- " list
+	// item marker
+	KeySeparators string
+
+	// HyphenatedIdentifiers is true if identifiers can contain hyphens, e.g. "max-parallel" in YAML or HCL. Otherwise
+	// a hyphen after an identifier is punctuation, e.g. the minus in "i-1"
+	HyphenatedIdentifiers bool
+}
+
+// assert ConfigLexer implements Lexer
+var _ Lexer = ConfigLexer{}
+
+// Go returns a Lexer for Go
+func Go() ConfigLexer {
+	return ConfigLexer{
+		Keywords: []string{
+			"break", "case", "chan", "const", "continue", "default", "defer", "else", "fallthrough", "for", "func",
+			"go", "goto", "if", "import", "interface", "map", "package", "range", "return", "select", "struct",
+			"switch", "type", "var", "true", "false", "nil",
+		},
+		LineComments:      []string{"//"},
+		BlockCommentStart: "/*",
+		BlockCommentEnd:   "*/",
+		Quotes:            "\"'`",
+		MultilineQuote:    '`',
+	}
+}
+
+// YAML returns a Lexer for YAML
+func YAML() ConfigLexer {
+	return ConfigLexer{
+		Keywords:              []string{"true", "false", "null", "yes", "no", "on", "off"},
+		LineComments:          []string{"#"},
+		Quotes:                "\"'",
+		KeySeparators:         ":",
+		HyphenatedIdentifiers: true,
+	}
+}
+
+// HCL returns a Lexer for HCL, e.g. Terraform and Nomad job specifications
+func HCL() ConfigLexer {
+	return ConfigLexer{
+		Keywords:              []string{"true", "false", "null", "for", "in", "if", "else", "endif", "endfor"},
+		LineComments:          []string{"#", "//"},
+		BlockCommentStart:     "/*",
+		BlockCommentEnd:       "*/",
+		Quotes:                "\"",
+		KeySeparators:         "=",
+		HyphenatedIdentifiers: true,
+	}
+}
+
+// Tokenize returns the tokens of line given the state at the end of the previous line, and the state at the end of
+// line
+func (l ConfigLexer) Tokenize(line string, state State) ([]Token, State) {
+	var tokens []Token
+	add := func(tokenType TokenType, text string) {
+		if text == "" {
+			return
+		}
+		// merge with the previous token of the same type to keep styled output small
+		if n := len(tokens); n > 0 && tokens[n-1].Type == tokenType {
+			tokens[n-1].Text += text
+			return
+		}
+		tokens = append(tokens, Token{Type: tokenType, Text: text})
+	}
+
+	i := 0
+	if state == stateBlockComment {
+		end := strings.Index(line, l.BlockCommentEnd)
+		if end < 0 {
+			add(TokenComment, line)
+			return tokens, stateBlockComment
+		}
+		i = end + len(l.BlockCommentEnd)
+		add(TokenComment, line[:i])
+	}
+	if state == stateMultilineString {
+		end, closed := quotedEnd(line, l.MultilineQuote, 0)
+		add(TokenString, line[:end])
+		if !closed {
+			return tokens, stateMultilineString
+		}
+		i = end
+	}
+
+	// only the first identifier or string on a line can be a key, e.g. not "nginx" in "image: nginx:1.2"
+	keyAllowed := i == 0
+	for i < len(line) {
+		rest := line[i:]
+		r, size := utf8.DecodeRuneInString(rest)
+		canBeKey := keyAllowed
+		if r != ' ' && r != '\t' && !strings.HasPrefix(rest, "- ") {
+			keyAllowed = false
+		}
+		switch {
+		case l.BlockCommentStart != "" && strings.HasPrefix(rest, l.BlockCommentStart):
+			end := strings.Index(rest[len(l.BlockCommentStart):], l.BlockCommentEnd)
+			if end < 0 {
+				add(TokenComment, rest)
+				return tokens, stateBlockComment
+			}
+			end += len(l.BlockCommentStart) + len(l.BlockCommentEnd)
+			add(TokenComment, rest[:end])
+			i += end
+
+		case l.isLineComment(rest):
+			add(TokenComment, rest)
+			i = len(line)
+
+		case strings.ContainsRune(l.Quotes, r):
+			end, closed := quotedEnd(rest, r, size)
+			if !closed && r == l.MultilineQuote {
+				add(TokenString, rest)
+				return tokens, stateMultilineString
+			}
+			tokenType := TokenString
+			if canBeKey && l.isFollowedByKeySeparator(rest[end:]) {
+				tokenType = TokenKey
+			}
+			add(tokenType, rest[:end])
+			i += end
+
+		case unicode.IsDigit(r) && !continuesIdentifier(line, i):
+			end := size
+			for end < len(rest) && (isIdentifierByte(rest[end]) || rest[end] == '.') {
+				end++
+			}
+			add(TokenNumber, rest[:end])
+			i += end
+
+		case unicode.IsLetter(r) || r == '_':
+			end := size
+			for end < len(rest) {
+				next, nextSize := utf8.DecodeRuneInString(rest[end:])
+				if !unicode.IsLetter(next) && !unicode.IsDigit(next) && next != '_' && (next != '-' || !l.HyphenatedIdentifiers) {
+					break
+				}
+				end += nextSize
+			}
+			word := rest[:end]
+			switch {
+			case canBeKey && l.isFollowedByKeySeparator(rest[end:]):
+				add(TokenKey, word)
+			case l.isKeyword(word):
+				add(TokenKeyword, word)
+			default:
+				add(TokenText, word)
+			}
+			i += end
+
+		case r < utf8.RuneSelf && strings.IndexByte(punctuation, byte(r)) >= 0:
+			add(TokenPunctuation, rest[:size])
+			i += size
+
+		default:
+			add(TokenText, rest[:size])
+			i += size
+		}
+	}
+	return tokens, 0
+}
+
+func (l ConfigLexer) isLineComment(s string) bool {
+	for _, prefix := range l.LineComments {
+		if strings.HasPrefix(s, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+func (l ConfigLexer) isKeyword(word string) bool {
+	for _, keyword := range l.Keywords {
+		if word == keyword {
+			return true
+		}
+	}
+	return false
+}
+
+func (l ConfigLexer) isFollowedByKeySeparator(s string) bool {
+	s = strings.TrimLeft(s, " \t")
+	if s == "" || l.KeySeparators == "" {
+		return false
+	}
+	// "==" is a comparison, not a key
+	if s[0] == '=' && strings.HasPrefix(s, "==") {
+		return false
+	}
+	// a key's colon is followed by a space or the end of the line, unlike the one in "http://"
+	if s[0] == ':' && len(s) > 1 && s[1] != ' ' && s[1] != '\t' {
+		return false
+	}
+	return strings.IndexByte(l.KeySeparators, s[0]) >= 0
+}
+
+// quotedEnd returns the index just after the closing quote of a string quoted with quote whose content starts at start
+// in s, and whether it is closed. Returns the end of s if unterminated
+func quotedEnd(s string, quote rune, start int) (int, bool) {
+	escaped := false
+	for i, r := range s[start:] {
+		switch {
+		case escaped:
+			escaped = false
+		case r == '\\' && quote != '`':
+			escaped = true
+		case r == quote:
+			return start + i + utf8.RuneLen(r), true
+		}
+	}
+	return len(s), false
+}
+
+// continuesIdentifier returns true if the byte before i in line is part of an identifier, e.g. the 2 in "v2"
+func continuesIdentifier(line string, i int) bool {
+	return i > 0 && isIdentifierByte(line[i-1])
+}
+
+func isIdentifierByte(b byte) bool {
+	return b == '_' || (b >= '0' && b <= '9') || (b >= 'a' && b <= 'z') || (b >= 'A' && b <= 'Z')
+}
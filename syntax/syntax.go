@@ -0,0 +1,131 @@
+package syntax
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/lipgloss/v2"
+	"github.com/robinovitch61/bubbleo/viewport"
+	"github.com/robinovitch61/bubbleo/viewport/linebuffer"
+)
+
+// TokenType is the kind of a token of source code
+type TokenType int
+
+const (
+	// TokenText is anything not otherwise classified, including whitespace and identifiers
+	TokenText TokenType = iota
+	// TokenKeyword is a reserved word of the language
+	TokenKeyword
+	// TokenKey is the key of a key/value pair, e.g. in YAML or HCL
+	TokenKey
+	// TokenString is a string literal, including its quotes
+	TokenString
+	// TokenNumber is a numeric literal
+	TokenNumber
+	// TokenComment is a line or block comment
+	TokenComment
+	// TokenPunctuation is an operator, bracket, or separator
+	TokenPunctuation
+)
+
+// Token is a classified piece of a line of source code
+type Token struct {
+	Type TokenType
+	Text string
+}
+
+// State is lexer state carried from the end of one line to the start of the next, e.g. being inside a block comment.
+// The zero value is the state at the start of the content
+type State int
+
+// Lexer tokenizes source code for a language one line at a time
+type Lexer interface {
+	// Tokenize returns the tokens of line, whose texts concatenate to line, given the state at the end of the previous
+	// line. Also returns the state at the end of line
+	Tokenize(line string, state State) ([]Token, State)
+}
+
+// Styles maps token types to the style they're rendered with. Token types without a style are unstyled
+type Styles map[TokenType]lipgloss.Style
+
+// Highlighter styles lines of source code using a Lexer, caching styled lines so highlighting large or frequently
+// updated content stays fast. Styled lines keep working with the viewport's search highlighting
+type Highlighter struct {
+	lexer  Lexer
+	styles Styles
+
+	// cache maps a line and the lexer state at its start to its styled line and the lexer state at its end
+	cache map[cacheKey]cachedLine
+}
+
+type cacheKey struct {
+	line  string
+	state State
+}
+
+type cachedLine struct {
+	styled string
+	state  State
+}
+
+// NewHighlighter creates a new Highlighter for the language of lexer
+func NewHighlighter(lexer Lexer, styles Styles) *Highlighter {
+	return &Highlighter{
+		lexer:  lexer,
+		styles: styles,
+		cache:  map[cacheKey]cachedLine{},
+	}
+}
+
+// SetStyles sets the styles for token types, clearing the cache
+func (h *Highlighter) SetStyles(styles Styles) {
+	h.styles = styles
+	h.cache = map[cacheKey]cachedLine{}
+}
+
+// Highlight returns a styled LineBuffer for each line of source code
+func (h *Highlighter) Highlight(lines []string) []linebuffer.LineBuffer {
+	res := make([]linebuffer.LineBuffer, len(lines))
+	cache := make(map[cacheKey]cachedLine, len(lines))
+	var state State
+	for i, line := range lines {
+		key := cacheKey{line: line, state: state}
+		cached, ok := h.cache[key]
+		if !ok {
+			cached, ok = cache[key]
+		}
+		if !ok {
+			tokens, endState := h.lexer.Tokenize(line, state)
+			cached = cachedLine{styled: h.render(tokens), state: endState}
+		}
+		cache[key] = cached
+		res[i] = linebuffer.New(cached.styled)
+		state = cached.state
+	}
+	// only keep the current lines so the cache doesn't grow without bound as content changes
+	h.cache = cache
+	return res
+}
+
+// HighlightRenderables returns a styled viewport.RenderableString for each line of source code
+func (h *Highlighter) HighlightRenderables(lines []string) []viewport.RenderableString {
+	lineBuffers := h.Highlight(lines)
+	res := make([]viewport.RenderableString, len(lineBuffers))
+	for i := range lineBuffers {
+		res[i] = viewport.RenderableString{LineBuffer: lineBuffers[i]}
+	}
+	return res
+}
+
+func (h *Highlighter) render(tokens []Token) string {
+	var builder strings.Builder
+	for _, token := range tokens {
+		style, ok := h.styles[token.Type]
+		if !ok || token.Text == "" {
+			builder.WriteString(token.Text)
+			continue
+		}
+		builder.WriteString(style.Render(token.Text))
+	}
+	return builder.String()
+}
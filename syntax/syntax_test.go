@@ -0,0 +1,323 @@
+package syntax
+
+import (
+	"testing"
+
+	"github.com/charmbracelet/bubbles/v2/key"
+	"github.com/charmbracelet/lipgloss/v2"
+	"github.com/google/go-cmp/cmp"
+	"github.com/robinovitch61/bubbleo/viewport"
+)
+
+var (
+	red   = lipgloss.Color("#ff0000")
+	green = lipgloss.Color("#00ff00")
+	blue  = lipgloss.Color("#0000ff")
+)
+
+func TestConfigLexer_Tokenize(t *testing.T) {
+	tests := []struct {
+		name          string
+		lexer         ConfigLexer
+		line          string
+		state         State
+		expected      []Token
+		expectedState State
+	}{
+		{
+			name:  "go",
+			lexer: Go(),
+			line:  `func v2(s string) int { return 42 } // done`,
+			expected: []Token{
+				{TokenKeyword, "func"},
+				{TokenText, " v2"},
+				{TokenPunctuation, "("},
+				{TokenText, "s string"},
+				{TokenPunctuation, ")"},
+				{TokenText, " int "},
+				{TokenPunctuation, "{"},
+				{TokenText, " "},
+				{TokenKeyword, "return"},
+				{TokenText, " "},
+				{TokenNumber, "42"},
+				{TokenText, " "},
+				{TokenPunctuation, "}"},
+				{TokenText, " "},
+				{TokenComment, "// done"},
+			},
+		},
+		{
+			name:  "go minus after identifier",
+			lexer: Go(),
+			line:  `i-1`,
+			expected: []Token{
+				{TokenText, "i"},
+				{TokenPunctuation, "-"},
+				{TokenNumber, "1"},
+			},
+		},
+		{
+			name:  "go string with escaped quote",
+			lexer: Go(),
+			line:  `x := "a \"b\" // c"`,
+			expected: []Token{
+				{TokenText, "x "},
+				{TokenPunctuation, ":="},
+				{TokenText, " "},
+				{TokenString, `"a \"b\" // c"`},
+			},
+		},
+		{
+			name:  "block comment starts",
+			lexer: Go(),
+			line:  `x /* start`,
+			expected: []Token{
+				{TokenText, "x "},
+				{TokenComment, "/* start"},
+			},
+			expectedState: stateBlockComment,
+		},
+		{
+			name:  "block comment continues",
+			lexer: Go(),
+			line:  `still`,
+			state: stateBlockComment,
+			expected: []Token{
+				{TokenComment, "still"},
+			},
+			expectedState: stateBlockComment,
+		},
+		{
+			name:  "block comment ends",
+			lexer: Go(),
+			line:  `end */ x`,
+			state: stateBlockComment,
+			expected: []Token{
+				{TokenComment, "end */"},
+				{TokenText, " x"},
+			},
+		},
+		{
+			name:  "raw string starts",
+			lexer: Go(),
+			line:  "x := `abc",
+			expected: []Token{
+				{TokenText, "x "},
+				{TokenPunctuation, ":="},
+				{TokenText, " "},
+				{TokenString, "`abc"},
+			},
+			expectedState: stateMultilineString,
+		},
+		{
+			name:  "raw string continues",
+			lexer: Go(),
+			line:  `func() { "not code" } // \`,
+			state: stateMultilineString,
+			expected: []Token{
+				{TokenString, `func() { "not code" } // \`},
+			},
+			expectedState: stateMultilineString,
+		},
+		{
+			name:  "raw string ends",
+			lexer: Go(),
+			line:  "def` + y",
+			state: stateMultilineString,
+			expected: []Token{
+				{TokenString, "def`"},
+				{TokenText, " "},
+				{TokenPunctuation, "+"},
+				{TokenText, " y"},
+			},
+		},
+		{
+			name:  "unterminated string doesn't span lines",
+			lexer: Go(),
+			line:  `x := "abc`,
+			expected: []Token{
+				{TokenText, "x "},
+				{TokenPunctuation, ":="},
+				{TokenText, " "},
+				{TokenString, `"abc`},
+			},
+		},
+		{
+			name:  "yaml",
+			lexer: YAML(),
+			line:  `- image-name: "redis:7" # pinned`,
+			expected: []Token{
+				{TokenPunctuation, "-"},
+				{TokenText, " "},
+				{TokenKey, "image-name"},
+				{TokenPunctuation, ":"},
+				{TokenText, " "},
+				{TokenString, `"redis:7"`},
+				{TokenText, " "},
+				{TokenComment, "# pinned"},
+			},
+		},
+		{
+			name:  "yaml value with colon",
+			lexer: YAML(),
+			line:  `  image: nginx:1.2`,
+			expected: []Token{
+				{TokenText, "  "},
+				{TokenKey, "image"},
+				{TokenPunctuation, ":"},
+				{TokenText, " nginx"},
+				{TokenPunctuation, ":"},
+				{TokenNumber, "1.2"},
+			},
+		},
+		{
+			name:  "yaml url value",
+			lexer: YAML(),
+			line:  `url: http://example.com`,
+			expected: []Token{
+				{TokenKey, "url"},
+				{TokenPunctuation, ":"},
+				{TokenText, " http"},
+				{TokenPunctuation, "://"},
+				{TokenText, "example"},
+				{TokenPunctuation, "."},
+				{TokenText, "com"},
+			},
+		},
+		{
+			name:  "yaml list item url",
+			lexer: YAML(),
+			line:  `- http://example.com`,
+			expected: []Token{
+				{TokenPunctuation, "-"},
+				{TokenText, " http"},
+				{TokenPunctuation, "://"},
+				{TokenText, "example"},
+				{TokenPunctuation, "."},
+				{TokenText, "com"},
+			},
+		},
+		{
+			name:  "yaml key without value",
+			lexer: YAML(),
+			line:  `- "quoted key":`,
+			expected: []Token{
+				{TokenPunctuation, "-"},
+				{TokenText, " "},
+				{TokenKey, `"quoted key"`},
+				{TokenPunctuation, ":"},
+			},
+		},
+		{
+			name:  "hcl",
+			lexer: HCL(),
+			line:  `count = -1 == x && true`,
+			expected: []Token{
+				{TokenKey, "count"},
+				{TokenText, " "},
+				{TokenPunctuation, "="},
+				{TokenText, " "},
+				{TokenPunctuation, "-"},
+				{TokenNumber, "1"},
+				{TokenText, " "},
+				{TokenPunctuation, "=="},
+				{TokenText, " x "},
+				{TokenPunctuation, "&&"},
+				{TokenText, " "},
+				{TokenKeyword, "true"},
+			},
+		},
+		{
+			name:  "hcl hyphenated identifier",
+			lexer: HCL(),
+			line:  `max-parallel = 2`,
+			expected: []Token{
+				{TokenKey, "max-parallel"},
+				{TokenText, " "},
+				{TokenPunctuation, "="},
+				{TokenText, " "},
+				{TokenNumber, "2"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tokens, state := tt.lexer.Tokenize(tt.line, tt.state)
+			if diff := cmp.Diff(tt.expected, tokens); diff != "" {
+				t.Errorf("Diff (-expected +actual):\n%s", diff)
+			}
+			if state != tt.expectedState {
+				t.Errorf("expected state %d, got %d", tt.expectedState, state)
+			}
+		})
+	}
+}
+
+type countingLexer struct {
+	Lexer
+	numCalls int
+}
+
+func (l *countingLexer) Tokenize(line string, state State) ([]Token, State) {
+	l.numCalls++
+	return l.Lexer.Tokenize(line, state)
+}
+
+func TestHighlighter_Highlight(t *testing.T) {
+	lexer := &countingLexer{Lexer: Go()}
+	h := NewHighlighter(lexer, Styles{
+		TokenKeyword: lipgloss.NewStyle().Foreground(red),
+		TokenComment: lipgloss.NewStyle().Foreground(green),
+	})
+	lines := []string{
+		"/* a",
+		"func */ func",
+		"func",
+	}
+	expected := []string{
+		"\x1b[38;2;0;255;0m/* a\x1b[m",
+		"\x1b[38;2;0;255;0mfunc */\x1b[m \x1b[38;2;255;0;0mfunc\x1b[m",
+		"\x1b[38;2;255;0;0mfunc\x1b[m",
+	}
+	lineBuffers := h.Highlight(lines)
+	for i := range lineBuffers {
+		if diff := cmp.Diff(expected[i], lineBuffers[i].Content()); diff != "" {
+			t.Errorf("line %d Diff (-expected +actual):\n%s", i, diff)
+		}
+	}
+	if lexer.numCalls != 3 {
+		t.Errorf("expected 3 tokenize calls, got %d", lexer.numCalls)
+	}
+
+	// lines are re-tokenized if they or their start state changed, here "func" still starts outside a comment
+	h.Highlight([]string{"// a", "func */ func", "func", "new"})
+	if lexer.numCalls != 3+3 {
+		t.Errorf("expected 6 tokenize calls, got %d", lexer.numCalls)
+	}
+	h.Highlight([]string{"// a", "func */ func", "func", "new", "newer"})
+	if lexer.numCalls != 6+1 {
+		t.Errorf("expected 7 tokenize calls, got %d", lexer.numCalls)
+	}
+}
+
+func TestHighlighter_SearchHighlightOverlay(t *testing.T) {
+	h := NewHighlighter(Go(), Styles{
+		TokenKeyword: lipgloss.NewStyle().Foreground(red),
+		TokenString:  lipgloss.NewStyle().Foreground(green),
+	})
+	vp := viewport.New[viewport.RenderableString](20, 2, viewport.KeyMap{Down: key.NewBinding(key.WithKeys("j"))}, viewport.Styles{
+		HighlightStyle: lipgloss.NewStyle().Foreground(blue),
+	})
+	vp.SetFooterEnabled(false)
+	vp.SetContent(h.HighlightRenderables([]string{`return "x"`}))
+
+	// search highlight spanning a keyword, plain text, and a string keeps the syntax styles around it
+	vp.SetStringToHighlight(`rn "`)
+	expected := "\x1b[38;2;255;0;0mretu\x1b[m\x1b[38;2;0;0;255mrn \"\x1b[m\x1b[38;2;0;255;0mx\"\x1b[m" +
+		"          \n" +
+		"                    "
+	if diff := cmp.Diff(expected, vp.View()); diff != "" {
+		t.Errorf("Diff (-expected +actual):\n%s", diff)
+	}
+}
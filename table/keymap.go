@@ -0,0 +1,13 @@
+package table
+
+import "github.com/charmbracelet/bubbles/v2/key"
+
+// KeyMap contains table key bindings in addition to the viewport's navigation key bindings
+type KeyMap struct {
+	// SortByNextColumn sorts by the column after the current sort column, going back to unsorted after the last column
+	SortByNextColumn key.Binding
+	// SortByPrevColumn sorts by the column before the current sort column, going back to unsorted before the first column
+	SortByPrevColumn key.Binding
+	// ReverseSort toggles between ascending and descending order
+	ReverseSort key.Binding
+}
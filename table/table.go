@@ -0,0 +1,504 @@
+package table
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/v2/key"
+	tea "github.com/charmbracelet/bubbletea/v2"
+	"github.com/charmbracelet/lipgloss/v2"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/robinovitch61/bubbleo/viewport"
+	"github.com/robinovitch61/bubbleo/viewport/linebuffer"
+)
+
+// Alignment is the horizontal alignment of text within a column
+type Alignment int
+
+const (
+	// AlignLeft pads cells on the right
+	AlignLeft Alignment = iota
+	// AlignRight pads cells on the left
+	AlignRight
+	// AlignCenter pads cells evenly on both sides
+	AlignCenter
+)
+
+// Column defines how a column of the table is rendered from a row of type T
+type Column[T any] struct {
+	// Title is shown in the header
+	Title string
+
+	// MinWidth is the minimum width of the column in terminal cells
+	MinWidth int
+
+	// MaxWidth is the maximum width of the column in terminal cells. Zero means no maximum
+	MaxWidth int
+
+	// Align is the alignment of the title and cells in the column
+	Align Alignment
+
+	// Weight is the share of any leftover viewport width given to the column. Weighted columns also give up width,
+	// down to MinWidth, when the table is wider than the viewport. Zero means the column is sized to its content
+	Weight int
+
+	// Value returns the cell content for a row, which may be styled
+	Value func(T) string
+
+	// Less reports whether row a sorts before row b when sorting by the column. If nil, rows are sorted by comparing
+	// their Value strings, ignoring ansi styling
+	Less func(a, b T) bool
+}
+
+const (
+	ascendingIndicator  = " ▲"
+	descendingIndicator = " ▼"
+)
+
+// CompareFn is a function type for comparing two rows of type T
+type CompareFn[T any] func(a, b T) bool
+
+// row is a single rendered row of the table
+type row[T any] struct {
+	item       T
+	lineBuffer linebuffer.LineBufferer
+}
+
+// Render returns the rendered row for the viewport
+func (r row[T]) Render() linebuffer.LineBufferer {
+	return r.lineBuffer
+}
+
+// assert row implements viewport.Renderable
+var _ viewport.Renderable = row[any]{}
+
+// Model represents a table component, rendering typed rows as aligned columns in a viewport
+type Model[T any] struct {
+	// viewport renders the rows and handles navigation, selection and highlighting
+	viewport viewport.Model[row[T]]
+
+	// columns defines the columns of the table
+	columns []Column[T]
+
+	// keyMap contains the table key bindings
+	keyMap KeyMap
+
+	// rows are the unrendered rows of the table in the order they were set
+	rows []T
+
+	// sortedRows are the rows in the order they are displayed
+	sortedRows []T
+
+	// sortColumnIdx is the index of the column the rows are sorted by, or -1 if unsorted
+	sortColumnIdx int
+
+	// sortDescending is true if the rows are sorted in descending order
+	sortDescending bool
+
+	// columnWidths is the rendered width of each column
+	columnWidths []int
+
+	// numFrozenColumns is the number of leading columns that stay in place when panning horizontally
+	numFrozenColumns int
+
+	// separator is placed between columns
+	separator string
+
+	// continuationIndicator replaces the end of cells that are truncated
+	continuationIndicator string
+}
+
+// New creates a new table model with reasonable defaults
+func New[T any](width, height int, keyMap viewport.KeyMap, styles viewport.Styles) (m Model[T]) {
+	m.viewport = viewport.New[row[T]](width, height, keyMap, styles)
+	m.viewport.SetHeaderPanEnabled(true)
+	m.sortColumnIdx = -1
+	m.separator = " "
+	m.continuationIndicator = "..."
+	return m
+}
+
+// Update processes messages and updates the model
+func (m *Model[T]) Update(msg tea.Msg) (Model[T], tea.Cmd) {
+	if msg, ok := msg.(tea.KeyMsg); ok {
+		switch {
+		case key.Matches(msg, m.keyMap.SortByNextColumn):
+			m.SortBy(m.nextSortColumnIdx(1), m.sortDescending)
+			return *m, nil
+		case key.Matches(msg, m.keyMap.SortByPrevColumn):
+			m.SortBy(m.nextSortColumnIdx(-1), m.sortDescending)
+			return *m, nil
+		case key.Matches(msg, m.keyMap.ReverseSort):
+			if m.sortColumnIdx >= 0 {
+				m.SortBy(m.sortColumnIdx, !m.sortDescending)
+			}
+			return *m, nil
+		}
+	}
+
+	var cmd tea.Cmd
+	m.viewport, cmd = m.viewport.Update(msg)
+	return *m, cmd
+}
+
+// View renders the table
+func (m *Model[T]) View() string {
+	return m.viewport.View()
+}
+
+// SetColumns sets the columns of the table. Sorting is cleared if the sort column no longer exists
+func (m *Model[T]) SetColumns(columns []Column[T]) {
+	m.columns = columns
+	if m.sortColumnIdx >= len(columns) {
+		m.sortColumnIdx = -1
+	}
+	m.render()
+}
+
+// GetColumns returns the columns of the table
+func (m *Model[T]) GetColumns() []Column[T] {
+	return m.columns
+}
+
+// SetRows sets the rows of the table, which are displayed in the current sort order. If a selection comparator is set,
+// the selected row stays selected
+func (m *Model[T]) SetRows(rows []T) {
+	m.rows = rows
+	m.render()
+}
+
+// GetRows returns the rows of the table in the order they were set
+func (m *Model[T]) GetRows() []T {
+	return m.rows
+}
+
+// GetSortedRows returns the rows of the table in the order they are displayed
+func (m *Model[T]) GetSortedRows() []T {
+	return m.sortedRows
+}
+
+// SetTableKeyMap sets the table key bindings, e.g. for sorting
+func (m *Model[T]) SetTableKeyMap(keyMap KeyMap) {
+	m.keyMap = keyMap
+}
+
+// SortBy sorts the rows by the column at columnIdx. Sorting is stable, so rows that compare equal keep the order they
+// were set in. A negative columnIdx clears sorting. If a selection comparator is set, the selected row stays selected
+func (m *Model[T]) SortBy(columnIdx int, descending bool) {
+	if columnIdx >= len(m.columns) {
+		return
+	}
+	m.sortColumnIdx = max(-1, columnIdx)
+	m.sortDescending = descending
+	m.render()
+}
+
+// ClearSort displays the rows in the order they were set
+func (m *Model[T]) ClearSort() {
+	m.SortBy(-1, false)
+}
+
+// GetSort returns the index of the column the rows are sorted by, or -1 if unsorted, and whether the order is
+// descending
+func (m *Model[T]) GetSort() (int, bool) {
+	return m.sortColumnIdx, m.sortDescending
+}
+
+// SetFrozenColumns sets the number of leading columns that stay in place when panning horizontally, e.g. to keep a
+// name column visible
+func (m *Model[T]) SetFrozenColumns(numFrozenColumns int) {
+	m.numFrozenColumns = max(0, numFrozenColumns)
+	m.render()
+}
+
+// SetSeparator sets the string placed between columns
+func (m *Model[T]) SetSeparator(separator string) {
+	m.separator = separator
+	m.render()
+}
+
+// SetContinuationIndicator sets the string that replaces the end of truncated cells
+func (m *Model[T]) SetContinuationIndicator(continuationIndicator string) {
+	m.continuationIndicator = continuationIndicator
+	m.render()
+}
+
+// GetColumnWidths returns the rendered width of each column
+func (m *Model[T]) GetColumnWidths() []int {
+	return m.columnWidths
+}
+
+// SetWidth sets the width of the table, resizing weighted columns
+func (m *Model[T]) SetWidth(width int) {
+	m.viewport.SetWidth(width)
+	m.render()
+}
+
+// SetHeight sets the height of the table
+func (m *Model[T]) SetHeight(height int) {
+	m.viewport.SetHeight(height)
+}
+
+// GetWidth returns the width of the table
+func (m *Model[T]) GetWidth() int {
+	return m.viewport.GetWidth()
+}
+
+// GetHeight returns the height of the table
+func (m *Model[T]) GetHeight() int {
+	return m.viewport.GetHeight()
+}
+
+// SetKeyMap sets the key mapping for navigation controls
+func (m *Model[T]) SetKeyMap(keyMap viewport.KeyMap) {
+	m.viewport.SetKeyMap(keyMap)
+}
+
+// SetStyles sets the styling configuration
+func (m *Model[T]) SetStyles(styles viewport.Styles) {
+	m.viewport.SetStyles(styles)
+}
+
+// SetSelectionEnabled sets whether a row can be selected
+func (m *Model[T]) SetSelectionEnabled(selectionEnabled bool) {
+	m.viewport.SetSelectionEnabled(selectionEnabled)
+}
+
+// GetSelectionEnabled returns whether a row can be selected
+func (m *Model[T]) GetSelectionEnabled() bool {
+	return m.viewport.GetSelectionEnabled()
+}
+
+// SetSelectionComparator sets the comparator used to keep the same row selected when rows change
+func (m *Model[T]) SetSelectionComparator(compareFn CompareFn[T]) {
+	if compareFn == nil {
+		m.viewport.SetSelectionComparator(nil)
+		return
+	}
+	m.viewport.SetSelectionComparator(func(a, b row[T]) bool {
+		return compareFn(a.item, b.item)
+	})
+}
+
+// SetSelectedItemIdx sets the index of the selected row
+func (m *Model[T]) SetSelectedItemIdx(selectedItemIdx int) {
+	m.viewport.SetSelectedItemIdx(selectedItemIdx)
+}
+
+// GetSelectedItemIdx returns the index of the selected row
+func (m *Model[T]) GetSelectedItemIdx() int {
+	return m.viewport.GetSelectedItemIdx()
+}
+
+// GetSelectedItem returns a pointer to the selected row, or nil if there is no selection
+func (m *Model[T]) GetSelectedItem() *T {
+	selected := m.viewport.GetSelectedItem()
+	if selected == nil {
+		return nil
+	}
+	return &selected.item
+}
+
+// SetFooterEnabled sets whether the footer is shown when the rows overflow
+func (m *Model[T]) SetFooterEnabled(footerEnabled bool) {
+	m.viewport.SetFooterEnabled(footerEnabled)
+}
+
+// SetStringToHighlight sets a string to highlight in the rows
+func (m *Model[T]) SetStringToHighlight(h string) {
+	m.viewport.SetStringToHighlight(h)
+}
+
+// SetRegexToHighlight sets a regex to highlight in the rows
+func (m *Model[T]) SetRegexToHighlight(r *regexp.Regexp) {
+	m.viewport.SetRegexToHighlight(r)
+}
+
+// render computes the column widths and renders the header and rows into the viewport
+func (m *Model[T]) render() {
+	m.sortedRows = m.sortRows()
+	cells := make([][]string, len(m.sortedRows))
+	for i := range m.sortedRows {
+		cells[i] = make([]string, len(m.columns))
+		for j, column := range m.columns {
+			if column.Value != nil {
+				cells[i][j] = column.Value(m.sortedRows[i])
+			}
+		}
+	}
+
+	titles := make([]string, len(m.columns))
+	for j := range m.columns {
+		titles[j] = m.columns[j].Title
+		if j == m.sortColumnIdx {
+			if m.sortDescending {
+				titles[j] += descendingIndicator
+			} else {
+				titles[j] += ascendingIndicator
+			}
+		}
+	}
+	m.columnWidths = m.computeColumnWidths(titles, cells)
+	m.viewport.SetFrozenWidth(m.frozenWidth())
+	m.viewport.SetHeader([]string{m.renderLine(titles)})
+
+	rows := make([]row[T], len(m.sortedRows))
+	for i := range m.sortedRows {
+		rows[i] = row[T]{item: m.sortedRows[i], lineBuffer: linebuffer.New(m.renderLine(cells[i]))}
+	}
+	m.viewport.SetContent(rows)
+}
+
+// sortRows returns a copy of the rows in sort order
+func (m *Model[T]) sortRows() []T {
+	sorted := make([]T, len(m.rows))
+	copy(sorted, m.rows)
+	if m.sortColumnIdx < 0 || m.sortColumnIdx >= len(m.columns) {
+		return sorted
+	}
+
+	column := m.columns[m.sortColumnIdx]
+	if column.Less != nil {
+		sort.SliceStable(sorted, func(i, j int) bool {
+			if m.sortDescending {
+				return column.Less(sorted[j], sorted[i])
+			}
+			return column.Less(sorted[i], sorted[j])
+		})
+		return sorted
+	}
+	if column.Value == nil {
+		return sorted
+	}
+
+	// cells may be styled, so they're compared without ansi codes, stripped once per row
+	type keyedRow struct {
+		key string
+		row T
+	}
+	keyed := make([]keyedRow, len(sorted))
+	for i, row := range sorted {
+		keyed[i] = keyedRow{key: ansi.Strip(column.Value(row)), row: row}
+	}
+	sort.SliceStable(keyed, func(i, j int) bool {
+		if m.sortDescending {
+			return keyed[j].key < keyed[i].key
+		}
+		return keyed[i].key < keyed[j].key
+	})
+	for i := range keyed {
+		sorted[i] = keyed[i].row
+	}
+	return sorted
+}
+
+// frozenWidth returns the width of the frozen columns, including the separator after them
+func (m *Model[T]) frozenWidth() int {
+	numFrozenColumns := min(m.numFrozenColumns, len(m.columnWidths))
+	frozenWidth := numFrozenColumns * lipgloss.Width(m.separator)
+	for j := range numFrozenColumns {
+		frozenWidth += m.columnWidths[j]
+	}
+	return frozenWidth
+}
+
+// nextSortColumnIdx returns the sort column idx delta columns from the current one, where -1 is unsorted
+func (m *Model[T]) nextSortColumnIdx(delta int) int {
+	numOptions := len(m.columns) + 1
+	return (m.sortColumnIdx+1+delta+numOptions)%numOptions - 1
+}
+
+// renderLine truncates and aligns each cell to its column width and joins them with the separator
+func (m *Model[T]) renderLine(cells []string) string {
+	var builder strings.Builder
+	for j := range cells {
+		if j > 0 {
+			builder.WriteString(m.separator)
+		}
+		builder.WriteString(m.renderCell(cells[j], m.columnWidths[j], m.columns[j].Align))
+	}
+	return strings.TrimRight(builder.String(), " ")
+}
+
+// renderCell truncates cell to width with the continuation indicator, then pads it according to align
+func (m *Model[T]) renderCell(cell string, width int, align Alignment) string {
+	if lipgloss.Width(cell) > width {
+		cell, _ = linebuffer.New(cell).Take(0, width, m.continuationIndicator, linebuffer.HighlightData{}, lipgloss.NewStyle())
+	}
+	padding := max(0, width-lipgloss.Width(cell))
+	switch align {
+	case AlignRight:
+		return strings.Repeat(" ", padding) + cell
+	case AlignCenter:
+		return strings.Repeat(" ", padding/2) + cell + strings.Repeat(" ", padding-padding/2)
+	default:
+		return cell + strings.Repeat(" ", padding)
+	}
+}
+
+// computeColumnWidths sizes each column to fit its title and cells within its min and max width, then grows or
+// shrinks weighted columns so the table fills the viewport width where possible
+func (m *Model[T]) computeColumnWidths(titles []string, cells [][]string) []int {
+	widths := make([]int, len(m.columns))
+	totalWeight := 0
+	for j, column := range m.columns {
+		w := lipgloss.Width(titles[j])
+		for i := range cells {
+			w = max(w, lipgloss.Width(cells[i][j]))
+		}
+		widths[j] = clampWidth(w, column.MinWidth, column.MaxWidth)
+		totalWeight += max(0, column.Weight)
+	}
+	if totalWeight == 0 {
+		return widths
+	}
+
+	totalWidth := max(0, len(m.columns)-1) * lipgloss.Width(m.separator)
+	for j := range widths {
+		totalWidth += widths[j]
+	}
+	leftover := m.viewport.GetWidth() - totalWidth
+
+	// distribute the leftover width by weight, repeating while some columns hit their min or max width
+	for leftover != 0 {
+		var adjustable []int
+		adjustableWeight := 0
+		for j, column := range m.columns {
+			if column.Weight <= 0 {
+				continue
+			}
+			if (leftover > 0 && (column.MaxWidth <= 0 || widths[j] < column.MaxWidth)) ||
+				(leftover < 0 && widths[j] > max(1, column.MinWidth)) {
+				adjustable = append(adjustable, j)
+				adjustableWeight += column.Weight
+			}
+		}
+		if len(adjustable) == 0 {
+			break
+		}
+
+		remaining := leftover
+		for k, j := range adjustable {
+			share := leftover * m.columns[j].Weight / adjustableWeight
+			if k == len(adjustable)-1 {
+				share = remaining
+			}
+			newWidth := clampWidth(widths[j]+share, max(1, m.columns[j].MinWidth), m.columns[j].MaxWidth)
+			remaining -= newWidth - widths[j]
+			widths[j] = newWidth
+		}
+		if remaining == leftover {
+			break
+		}
+		leftover = remaining
+	}
+	return widths
+}
+
+func clampWidth(w, minWidth, maxWidth int) int {
+	if maxWidth > 0 {
+		w = min(w, maxWidth)
+	}
+	return max(w, minWidth, 0)
+}
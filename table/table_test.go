@@ -0,0 +1,414 @@
+package table
+
+import (
+	"testing"
+
+	"github.com/charmbracelet/bubbles/v2/key"
+	tea "github.com/charmbracelet/bubbletea/v2"
+	"github.com/charmbracelet/lipgloss/v2"
+	"github.com/google/go-cmp/cmp"
+	"github.com/robinovitch61/bubbleo/internal/testutil"
+	"github.com/robinovitch61/bubbleo/viewport"
+)
+
+var (
+	downKeyMsg     = tea.KeyPressMsg{Code: 'j', Text: "j"}
+	rightKeyMsg    = tea.KeyPressMsg{Code: tea.KeyRight}
+	red            = lipgloss.Color("#ff0000")
+	selectionStyle = testutil.SelectionStyle
+)
+
+type person struct {
+	name string
+	age  string
+	city string
+}
+
+var people = []person{
+	{name: "Alice", age: "30", city: "Amsterdam"},
+	{name: "Bob", age: "4", city: "Berlin"},
+	{name: "Christopher", age: "101", city: "Cape Town"},
+}
+
+func newTable(width, height int, columns []Column[person]) Model[person] {
+	km := viewport.KeyMap{
+		Up:    key.NewBinding(key.WithKeys("up", "k")),
+		Down:  key.NewBinding(key.WithKeys("down", "j")),
+		Left:  key.NewBinding(key.WithKeys("left")),
+		Right: key.NewBinding(key.WithKeys("right")),
+	}
+	styles := viewport.Styles{
+		FooterStyle:              lipgloss.NewStyle(),
+		HighlightStyle:           lipgloss.NewStyle().Foreground(red),
+		HighlightStyleIfSelected: lipgloss.NewStyle().Foreground(red),
+		SelectedItemStyle:        selectionStyle,
+	}
+	tbl := New[person](width, height, km, styles)
+	tbl.SetColumns(columns)
+	tbl.SetRows(people)
+	return tbl
+}
+
+func nameColumn() Column[person] {
+	return Column[person]{Title: "Name", Value: func(p person) string { return p.name }}
+}
+
+func ageColumn() Column[person] {
+	return Column[person]{Title: "Age", Align: AlignRight, Value: func(p person) string { return p.age }}
+}
+
+func cityColumn() Column[person] {
+	return Column[person]{Title: "City", Value: func(p person) string { return p.city }}
+}
+
+func TestTable_ColumnWidths(t *testing.T) {
+	tests := []struct {
+		name     string
+		width    int
+		columns  []Column[person]
+		expected []int
+	}{
+		{
+			name:     "sized to content",
+			width:    40,
+			columns:  []Column[person]{nameColumn(), ageColumn(), cityColumn()},
+			expected: []int{11, 3, 9},
+		},
+		{
+			name:  "min and max width",
+			width: 40,
+			columns: []Column[person]{
+				{Title: "Name", MaxWidth: 6, Value: func(p person) string { return p.name }},
+				{Title: "Age", MinWidth: 5, Value: func(p person) string { return p.age }},
+			},
+			expected: []int{6, 5},
+		},
+		{
+			name:  "weighted column fills width",
+			width: 30,
+			columns: []Column[person]{
+				nameColumn(),
+				{Title: "City", Weight: 1, Value: func(p person) string { return p.city }},
+			},
+			expected: []int{11, 18},
+		},
+		{
+			name:  "weighted columns split by weight",
+			width: 31,
+			columns: []Column[person]{
+				{Title: "Name", Weight: 1, Value: func(p person) string { return p.name }},
+				{Title: "City", Weight: 2, Value: func(p person) string { return p.city }},
+			},
+			expected: []int{14, 16},
+		},
+		{
+			name:  "weighted column respects max width",
+			width: 40,
+			columns: []Column[person]{
+				{Title: "Name", Weight: 1, MaxWidth: 12, Value: func(p person) string { return p.name }},
+				{Title: "City", Weight: 1, Value: func(p person) string { return p.city }},
+			},
+			expected: []int{12, 27},
+		},
+		{
+			name:  "weighted column shrinks to min width",
+			width: 12,
+			columns: []Column[person]{
+				nameColumn(),
+				{Title: "City", Weight: 1, MinWidth: 4, Value: func(p person) string { return p.city }},
+			},
+			expected: []int{11, 4},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tbl := newTable(tt.width, 5, tt.columns)
+			if diff := cmp.Diff(tt.expected, tbl.GetColumnWidths()); diff != "" {
+				t.Errorf("Diff (-expected +actual):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestTable_View(t *testing.T) {
+	w, h := 30, 5
+	tbl := newTable(w, h, []Column[person]{nameColumn(), ageColumn(), cityColumn()})
+	expectedView := testutil.Pad(w, h, []string{
+		"Name        Age City",
+		"Alice        30 Amsterdam",
+		"Bob           4 Berlin",
+		"Christopher 101 Cape Town",
+		"100% (3/3)",
+	})
+	testutil.CmpStr(t, expectedView, tbl.View())
+
+	tbl.SetSeparator(" | ")
+	expectedView = testutil.Pad(w, h, []string{
+		"Name        | Age | City",
+		"Alice       |  30 | Amsterdam",
+		"Bob         |   4 | Berlin",
+		"Christopher | 101 | Cape Town",
+		"100% (3/3)",
+	})
+	testutil.CmpStr(t, expectedView, tbl.View())
+}
+
+func TestTable_CellTruncation(t *testing.T) {
+	w, h := 30, 5
+	tbl := newTable(w, h, []Column[person]{
+		{Title: "Name", MaxWidth: 7, Value: func(p person) string { return p.name }},
+		{Title: "City", Align: AlignCenter, MinWidth: 11, Value: func(p person) string { return p.city }},
+	})
+	expectedView := testutil.Pad(w, h, []string{
+		"Name       City",
+		"Alice    Amsterdam",
+		"Bob       Berlin",
+		"Chri...  Cape Town",
+		"100% (3/3)",
+	})
+	testutil.CmpStr(t, expectedView, tbl.View())
+}
+
+func TestTable_HeaderPans(t *testing.T) {
+	w, h := 10, 5
+	tbl := newTable(w, h, []Column[person]{nameColumn(), cityColumn()})
+	expectedView := testutil.Pad(w, h, []string{
+		"Name   ...",
+		"Alice  ...",
+		"Bob    ...",
+		"Christo...",
+		"100% (3/3)",
+	})
+	testutil.CmpStr(t, expectedView, tbl.View())
+
+	for range 12 {
+		tbl, _ = tbl.Update(rightKeyMsg)
+	}
+	expectedView = testutil.Pad(w, h, []string{
+		"...ty",
+		"...sterdam",
+		"...rlin",
+		"...pe Town",
+		"100% (3/3)",
+	})
+	testutil.CmpStr(t, expectedView, tbl.View())
+}
+
+func TestTable_SelectionAndHighlight(t *testing.T) {
+	w, h := 30, 5
+	tbl := newTable(w, h, []Column[person]{nameColumn(), cityColumn()})
+	tbl.SetSelectionEnabled(true)
+	tbl.SetSelectionComparator(func(a, b person) bool { return a.name == b.name })
+	tbl.SetStringToHighlight("er")
+
+	tbl, _ = tbl.Update(downKeyMsg)
+	if selected := tbl.GetSelectedItem(); selected == nil || selected.name != "Bob" {
+		t.Fatalf("expected Bob to be selected, got %v", selected)
+	}
+	expectedView := testutil.Pad(w, h, []string{
+		"Name        City",
+		"Alice       Amst\x1b[38;2;255;0;0mer\x1b[mdam",
+		"\x1b[38;2;0;0;255mBob         B\x1b[m\x1b[38;2;255;0;0mer\x1b[m\x1b[38;2;0;0;255mlin\x1b[m",
+		"Christoph\x1b[38;2;255;0;0mer\x1b[m Cape Town",
+		"66% (2/3)",
+	})
+	testutil.CmpStr(t, expectedView, tbl.View())
+
+	// selection follows the row when rows change
+	tbl.SetRows([]person{people[2], people[1], people[0]})
+	if idx := tbl.GetSelectedItemIdx(); idx != 1 {
+		t.Errorf("expected selected idx 1, got %d", idx)
+	}
+	tbl.SetRows([]person{people[1], people[2], people[0]})
+	if idx := tbl.GetSelectedItemIdx(); idx != 0 {
+		t.Errorf("expected selected idx 0, got %d", idx)
+	}
+}
+
+func TestTable_Sort(t *testing.T) {
+	w, h := 30, 6
+	byAge := func(a, b person) bool { return len(a.age) < len(b.age) || (len(a.age) == len(b.age) && a.age < b.age) }
+	tbl := newTable(w, h, []Column[person]{
+		nameColumn(),
+		{Title: "Age", Align: AlignRight, Value: func(p person) string { return p.age }, Less: byAge},
+	})
+	tbl.SetRows(append(people, person{name: "Dave", age: "30"}))
+
+	tests := []struct {
+		name         string
+		columnIdx    int
+		descending   bool
+		expectedView string
+	}{
+		{
+			name:      "custom comparator ascending is stable",
+			columnIdx: 1,
+			expectedView: testutil.Pad(w, h, []string{
+				"Name        Age ▲",
+				"Bob             4",
+				"Alice          30",
+				"Dave           30",
+				"Christopher   101",
+				"100% (4/4)",
+			}),
+		},
+		{
+			name:       "custom comparator descending is stable",
+			columnIdx:  1,
+			descending: true,
+			expectedView: testutil.Pad(w, h, []string{
+				"Name        Age ▼",
+				"Christopher   101",
+				"Alice          30",
+				"Dave           30",
+				"Bob             4",
+				"100% (4/4)",
+			}),
+		},
+		{
+			name:       "default comparator",
+			columnIdx:  0,
+			descending: true,
+			expectedView: testutil.Pad(w, h, []string{
+				"Name ▼      Age",
+				"Dave         30",
+				"Christopher 101",
+				"Bob           4",
+				"Alice        30",
+				"100% (4/4)",
+			}),
+		},
+		{
+			name:      "unsorted",
+			columnIdx: -1,
+			expectedView: testutil.Pad(w, h, []string{
+				"Name        Age",
+				"Alice        30",
+				"Bob           4",
+				"Christopher 101",
+				"Dave         30",
+				"100% (4/4)",
+			}),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tbl.SortBy(tt.columnIdx, tt.descending)
+			testutil.CmpStr(t, tt.expectedView, tbl.View())
+		})
+	}
+}
+
+func TestTable_SortStyled(t *testing.T) {
+	redName := func(p person) string {
+		if p.name == "Christopher" || p.name == "Dave" {
+			return lipgloss.NewStyle().Foreground(red).Render(p.name)
+		}
+		return p.name
+	}
+	tbl := newTable(30, 6, []Column[person]{{Title: "Name", Value: redName}})
+	tbl.SetRows(append(people, person{name: "Dave", age: "30"}))
+
+	tests := []struct {
+		name          string
+		descending    bool
+		expectedNames []string
+	}{
+		{
+			name:          "ascending ignores ansi codes",
+			expectedNames: []string{"Alice", "Bob", "Christopher", "Dave"},
+		},
+		{
+			name:          "descending ignores ansi codes",
+			descending:    true,
+			expectedNames: []string{"Dave", "Christopher", "Bob", "Alice"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tbl.SortBy(0, tt.descending)
+			var names []string
+			for _, p := range tbl.GetSortedRows() {
+				names = append(names, p.name)
+			}
+			if diff := cmp.Diff(tt.expectedNames, names); diff != "" {
+				t.Errorf("Diff (-expected +actual):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestTable_SortKeys(t *testing.T) {
+	w, h := 30, 5
+	tbl := newTable(w, h, []Column[person]{nameColumn(), cityColumn()})
+	tbl.SetTableKeyMap(KeyMap{
+		SortByNextColumn: key.NewBinding(key.WithKeys("s")),
+		SortByPrevColumn: key.NewBinding(key.WithKeys("shift+s")),
+		ReverseSort:      key.NewBinding(key.WithKeys("r")),
+	})
+	tbl.SetSelectionEnabled(true)
+	tbl.SetSelectionComparator(func(a, b person) bool { return a.name == b.name })
+	tbl.SetRows(append(people, person{name: "Dave", age: "30", city: "Aachen"}))
+	tbl, _ = tbl.Update(downKeyMsg)
+
+	sortKeyMsg := tea.KeyPressMsg{Code: 's', Text: "s"}
+	prevSortKeyMsg := tea.KeyPressMsg{Code: 's', Text: "S", Mod: tea.ModShift}
+	reverseKeyMsg := tea.KeyPressMsg{Code: 'r', Text: "r"}
+	tests := []struct {
+		msg                tea.KeyPressMsg
+		expectedColumnIdx  int
+		expectedDescending bool
+		expectedSelected   int
+	}{
+		{msg: sortKeyMsg, expectedColumnIdx: 0, expectedSelected: 1},
+		{msg: reverseKeyMsg, expectedColumnIdx: 0, expectedDescending: true, expectedSelected: 2},
+		{msg: sortKeyMsg, expectedColumnIdx: 1, expectedDescending: true, expectedSelected: 1},
+		{msg: reverseKeyMsg, expectedColumnIdx: 1, expectedSelected: 2},
+		{msg: sortKeyMsg, expectedColumnIdx: -1, expectedSelected: 1},
+		{msg: prevSortKeyMsg, expectedColumnIdx: 1, expectedSelected: 2},
+	}
+	for _, tt := range tests {
+		tbl, _ = tbl.Update(tt.msg)
+		columnIdx, descending := tbl.GetSort()
+		if columnIdx != tt.expectedColumnIdx || descending != tt.expectedDescending {
+			t.Errorf("after %q expected sort (%d, %t), got (%d, %t)", tt.msg.String(), tt.expectedColumnIdx, tt.expectedDescending, columnIdx, descending)
+		}
+		// Bob stays selected through re-sorts
+		if selected := tbl.GetSelectedItem(); selected == nil || selected.name != "Bob" {
+			t.Errorf("after %q expected Bob to be selected, got %v", tt.msg.String(), selected)
+		}
+		if idx := tbl.GetSelectedItemIdx(); idx != tt.expectedSelected {
+			t.Errorf("after %q expected selected idx %d, got %d", tt.msg.String(), tt.expectedSelected, idx)
+		}
+	}
+}
+
+func TestTable_FrozenColumns(t *testing.T) {
+	w, h := 20, 5
+	tbl := newTable(w, h, []Column[person]{nameColumn(), ageColumn(), cityColumn()})
+	tbl.SetFrozenColumns(1)
+	tbl.SetStringToHighlight("er")
+	expectedView := testutil.Pad(w, h, []string{
+		"Name        Age City",
+		"Alice        30 A...",
+		"Bob           4 B...",
+		"Christoph\x1b[38;2;255;0;0mer\x1b[m 101 C...",
+		"100% (3/3)",
+	})
+	testutil.CmpStr(t, expectedView, tbl.View())
+
+	for range 5 {
+		tbl, _ = tbl.Update(rightKeyMsg)
+	}
+	expectedView = testutil.Pad(w, h, []string{
+		"Name        ...",
+		"Alice       ...\x1b[38;2;255;0;0mer\x1b[mdam",
+		"Bob         ...in",
+		"Christoph\x1b[38;2;255;0;0mer\x1b[m ... Town",
+		"100% (3/3)",
+	})
+	testutil.CmpStr(t, expectedView, tbl.View())
+}
@@ -0,0 +1,17 @@
+package tree
+
+import "github.com/charmbracelet/bubbles/v2/key"
+
+// KeyMap contains tree key bindings in addition to the viewport's navigation key bindings
+type KeyMap struct {
+	// Expand expands the selected node
+	Expand key.Binding
+	// Collapse collapses the selected node, or selects its parent if it is already collapsed or has no children
+	Collapse key.Binding
+	// Toggle expands the selected node if collapsed, or collapses it if expanded
+	Toggle key.Binding
+	// ExpandAll expands every node
+	ExpandAll key.Binding
+	// CollapseAll collapses every node
+	CollapseAll key.Binding
+}
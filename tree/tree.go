@@ -0,0 +1,351 @@
+package tree
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/v2/key"
+	tea "github.com/charmbracelet/bubbletea/v2"
+	"github.com/robinovitch61/bubbleo/viewport"
+	"github.com/robinovitch61/bubbleo/viewport/linebuffer"
+)
+
+const (
+	expandedIndicator  = "▼ "
+	collapsedIndicator = "▶ "
+	branchGuide        = "├── "
+	lastBranchGuide    = "└── "
+	continueGuide      = "│   "
+	emptyGuide         = "    "
+)
+
+// Node is a node in the tree
+type Node[T viewport.Renderable] struct {
+	// ID uniquely identifies the node across changes to the tree, keeping its expanded state and selection
+	ID string
+
+	// Item is rendered as the node's label
+	Item T
+
+	// Children are the child nodes, shown when the node is expanded
+	Children []Node[T]
+}
+
+// row is a single visible node of the tree
+type row[T viewport.Renderable] struct {
+	node       *Node[T]
+	lineBuffer linebuffer.LineBufferer
+}
+
+// Render returns the rendered row for the viewport
+func (r row[T]) Render() linebuffer.LineBufferer {
+	return r.lineBuffer
+}
+
+// assert row implements viewport.Renderable
+var _ viewport.Renderable = row[viewport.RenderableString]{}
+
+// Model represents a tree component, rendering nodes with indentation guides in a viewport
+type Model[T viewport.Renderable] struct {
+	// viewport renders the visible nodes and handles navigation, selection and highlighting
+	viewport viewport.Model[row[T]]
+
+	// keyMap contains the tree key bindings
+	keyMap KeyMap
+
+	// roots are the top level nodes of the tree
+	roots []Node[T]
+
+	// expanded is the set of IDs of expanded nodes
+	expanded map[string]bool
+
+	// parentIDs maps each node ID to its parent's ID, or "" for root nodes
+	parentIDs map[string]string
+
+	// rowIdxByID maps the ID of each visible node to its row index in the viewport
+	rowIdxByID map[string]int
+}
+
+// New creates a new tree model with reasonable defaults. Nodes start collapsed
+func New[T viewport.Renderable](width, height int, keyMap viewport.KeyMap, styles viewport.Styles) (m Model[T]) {
+	m.viewport = viewport.New[row[T]](width, height, keyMap, styles)
+	m.viewport.SetSelectionEnabled(true)
+	m.viewport.SetSelectionComparator(func(a, b row[T]) bool {
+		return a.node != nil && b.node != nil && a.node.ID == b.node.ID
+	})
+	m.expanded = make(map[string]bool)
+	m.parentIDs = make(map[string]string)
+	m.rowIdxByID = make(map[string]int)
+	return m
+}
+
+// Update processes messages and updates the model
+func (m *Model[T]) Update(msg tea.Msg) (Model[T], tea.Cmd) {
+	if msg, ok := msg.(tea.KeyMsg); ok {
+		selected := m.GetSelectedNode()
+		switch {
+		case key.Matches(msg, m.keyMap.Expand):
+			if selected != nil {
+				m.Expand(selected.ID)
+			}
+			return *m, nil
+		case key.Matches(msg, m.keyMap.Collapse):
+			if selected != nil {
+				if len(selected.Children) > 0 && m.expanded[selected.ID] {
+					m.Collapse(selected.ID)
+				} else if parentID := m.parentIDs[selected.ID]; parentID != "" {
+					m.SetSelectedID(parentID)
+				}
+			}
+			return *m, nil
+		case key.Matches(msg, m.keyMap.Toggle):
+			if selected != nil {
+				m.Toggle(selected.ID)
+			}
+			return *m, nil
+		case key.Matches(msg, m.keyMap.ExpandAll):
+			m.ExpandAll()
+			return *m, nil
+		case key.Matches(msg, m.keyMap.CollapseAll):
+			m.CollapseAll()
+			return *m, nil
+		}
+	}
+
+	var cmd tea.Cmd
+	m.viewport, cmd = m.viewport.Update(msg)
+	return *m, cmd
+}
+
+// View renders the tree
+func (m *Model[T]) View() string {
+	return m.viewport.View()
+}
+
+// SetNodes sets the root nodes of the tree. Nodes keep their expanded state and selection by ID. If the selected node
+// is removed or hidden, its nearest visible ancestor is selected
+func (m *Model[T]) SetNodes(roots []Node[T]) {
+	selectedAncestry := m.selectedAncestry()
+	m.roots = roots
+	m.parentIDs = make(map[string]string)
+	var addParentIDs func(nodes []Node[T], parentID string)
+	addParentIDs = func(nodes []Node[T], parentID string) {
+		for i := range nodes {
+			m.parentIDs[nodes[i].ID] = parentID
+			addParentIDs(nodes[i].Children, nodes[i].ID)
+		}
+	}
+	addParentIDs(roots, "")
+	m.renderWithSelection(selectedAncestry)
+}
+
+// GetNodes returns the root nodes of the tree
+func (m *Model[T]) GetNodes() []Node[T] {
+	return m.roots
+}
+
+// SetTreeKeyMap sets the tree key bindings, e.g. for expanding and collapsing nodes
+func (m *Model[T]) SetTreeKeyMap(keyMap KeyMap) {
+	m.keyMap = keyMap
+}
+
+// Expand expands the node with the given ID
+func (m *Model[T]) Expand(id string) {
+	m.setExpanded(id, true)
+}
+
+// Collapse collapses the node with the given ID
+func (m *Model[T]) Collapse(id string) {
+	m.setExpanded(id, false)
+}
+
+// Toggle expands the node with the given ID if collapsed, or collapses it if expanded
+func (m *Model[T]) Toggle(id string) {
+	m.setExpanded(id, !m.expanded[id])
+}
+
+// IsExpanded returns true if the node with the given ID is expanded
+func (m *Model[T]) IsExpanded(id string) bool {
+	return m.expanded[id]
+}
+
+// ExpandAll expands every node
+func (m *Model[T]) ExpandAll() {
+	for id := range m.parentIDs {
+		m.expanded[id] = true
+	}
+	m.render()
+}
+
+// CollapseAll collapses every node. If a hidden node was selected, its root node is selected
+func (m *Model[T]) CollapseAll() {
+	m.expanded = make(map[string]bool)
+	m.render()
+}
+
+// GetSelectedNode returns a pointer to the selected node, or nil if there is no selection
+func (m *Model[T]) GetSelectedNode() *Node[T] {
+	selected := m.viewport.GetSelectedItem()
+	if selected == nil {
+		return nil
+	}
+	return selected.node
+}
+
+// SetSelectedID selects the node with the given ID, expanding its ancestors so it is visible. Returns false if there is
+// no node with the ID
+func (m *Model[T]) SetSelectedID(id string) bool {
+	if _, ok := m.parentIDs[id]; !ok {
+		return false
+	}
+	for parentID := m.parentIDs[id]; parentID != ""; parentID = m.parentIDs[parentID] {
+		m.expanded[parentID] = true
+	}
+	m.render()
+	m.selectID(id)
+	return true
+}
+
+// SetWidth sets the width of the tree
+func (m *Model[T]) SetWidth(width int) {
+	m.viewport.SetWidth(width)
+}
+
+// SetHeight sets the height of the tree
+func (m *Model[T]) SetHeight(height int) {
+	m.viewport.SetHeight(height)
+}
+
+// GetWidth returns the width of the tree
+func (m *Model[T]) GetWidth() int {
+	return m.viewport.GetWidth()
+}
+
+// GetHeight returns the height of the tree
+func (m *Model[T]) GetHeight() int {
+	return m.viewport.GetHeight()
+}
+
+// SetKeyMap sets the key mapping for navigation controls
+func (m *Model[T]) SetKeyMap(keyMap viewport.KeyMap) {
+	m.viewport.SetKeyMap(keyMap)
+}
+
+// SetStyles sets the styling configuration
+func (m *Model[T]) SetStyles(styles viewport.Styles) {
+	m.viewport.SetStyles(styles)
+}
+
+// SetWrapText sets whether long labels wrap
+func (m *Model[T]) SetWrapText(wrapText bool) {
+	m.viewport.SetWrapText(wrapText)
+}
+
+// SetFooterEnabled sets whether the footer is shown when the nodes overflow
+func (m *Model[T]) SetFooterEnabled(footerEnabled bool) {
+	m.viewport.SetFooterEnabled(footerEnabled)
+}
+
+// SetHeader sets the header lines shown above the tree
+func (m *Model[T]) SetHeader(header []string) {
+	m.viewport.SetHeader(header)
+}
+
+// SetStringToHighlight sets a string to highlight in the tree
+func (m *Model[T]) SetStringToHighlight(h string) {
+	m.viewport.SetStringToHighlight(h)
+}
+
+// SetRegexToHighlight sets a regex to highlight in the tree
+func (m *Model[T]) SetRegexToHighlight(r *regexp.Regexp) {
+	m.viewport.SetRegexToHighlight(r)
+}
+
+func (m *Model[T]) setExpanded(id string, expanded bool) {
+	if _, ok := m.parentIDs[id]; !ok {
+		return
+	}
+	if expanded {
+		m.expanded[id] = true
+	} else {
+		delete(m.expanded, id)
+	}
+	m.render()
+}
+
+// render flattens the visible nodes into rows and sets them in the viewport, keeping the selection on the same node
+// or its nearest visible ancestor
+func (m *Model[T]) render() {
+	m.renderWithSelection(m.selectedAncestry())
+}
+
+// renderWithSelection flattens the visible nodes into rows with indentation guides and sets them in the viewport,
+// selecting the first visible node in selectedAncestry
+func (m *Model[T]) renderWithSelection(selectedAncestry []string) {
+	var rows []row[T]
+	m.rowIdxByID = make(map[string]int)
+	var addRows func(nodes []Node[T], guides string)
+	addRows = func(nodes []Node[T], guides string) {
+		for i := range nodes {
+			node := &nodes[i]
+			isLast := i == len(nodes)-1
+
+			var prefix strings.Builder
+			prefix.WriteString(guides)
+			childGuides := guides
+			if m.parentIDs[node.ID] != "" {
+				if isLast {
+					prefix.WriteString(lastBranchGuide)
+					childGuides += emptyGuide
+				} else {
+					prefix.WriteString(branchGuide)
+					childGuides += continueGuide
+				}
+			}
+			if len(node.Children) > 0 {
+				if m.expanded[node.ID] {
+					prefix.WriteString(expandedIndicator)
+				} else {
+					prefix.WriteString(collapsedIndicator)
+				}
+			}
+
+			m.rowIdxByID[node.ID] = len(rows)
+			rows = append(rows, row[T]{node: node, lineBuffer: linebuffer.New(prefix.String() + node.Item.Render().Content())})
+			if m.expanded[node.ID] {
+				addRows(node.Children, childGuides)
+			}
+		}
+	}
+	addRows(m.roots, "")
+	m.viewport.SetContent(rows)
+
+	// the selection comparator keeps the selected node selected if visible, otherwise select its nearest visible
+	// ancestor
+	for _, id := range selectedAncestry {
+		if _, ok := m.rowIdxByID[id]; ok {
+			m.selectID(id)
+			return
+		}
+	}
+}
+
+// selectedAncestry returns the ID of the selected node followed by the IDs of its ancestors, nearest first
+func (m *Model[T]) selectedAncestry() []string {
+	selected := m.GetSelectedNode()
+	if selected == nil {
+		return nil
+	}
+	ancestry := []string{selected.ID}
+	for id := m.parentIDs[selected.ID]; id != ""; id = m.parentIDs[id] {
+		ancestry = append(ancestry, id)
+	}
+	return ancestry
+}
+
+// selectID selects the visible node with the given ID
+func (m *Model[T]) selectID(id string) {
+	if idx, ok := m.rowIdxByID[id]; ok {
+		m.viewport.SetSelectedItemIdx(idx)
+	}
+}
@@ -0,0 +1,187 @@
+package tree
+
+import (
+	"testing"
+
+	"github.com/charmbracelet/bubbles/v2/key"
+	tea "github.com/charmbracelet/bubbletea/v2"
+	"github.com/charmbracelet/lipgloss/v2"
+	"github.com/robinovitch61/bubbleo/internal/testutil"
+	"github.com/robinovitch61/bubbleo/viewport"
+	"github.com/robinovitch61/bubbleo/viewport/linebuffer"
+)
+
+var (
+	downKeyMsg     = tea.KeyPressMsg{Code: 'j', Text: "j"}
+	expandKeyMsg   = tea.KeyPressMsg{Code: 'l', Text: "l"}
+	collapseKeyMsg = tea.KeyPressMsg{Code: 'h', Text: "h"}
+	toggleKeyMsg   = tea.KeyPressMsg{Code: tea.KeySpace, Text: " "}
+	selectionStyle = testutil.SelectionStyle
+)
+
+func newTree(width, height int) Model[viewport.RenderableString] {
+	km := viewport.KeyMap{
+		Up:   key.NewBinding(key.WithKeys("up", "k")),
+		Down: key.NewBinding(key.WithKeys("down", "j")),
+	}
+	styles := viewport.Styles{
+		FooterStyle:              lipgloss.NewStyle(),
+		HighlightStyle:           lipgloss.NewStyle(),
+		HighlightStyleIfSelected: lipgloss.NewStyle(),
+		SelectedItemStyle:        selectionStyle,
+	}
+	tr := New[viewport.RenderableString](width, height, km, styles)
+	tr.SetFooterEnabled(false)
+	tr.SetTreeKeyMap(KeyMap{
+		Expand:      key.NewBinding(key.WithKeys("l")),
+		Collapse:    key.NewBinding(key.WithKeys("h")),
+		Toggle:      key.NewBinding(key.WithKeys("space")),
+		ExpandAll:   key.NewBinding(key.WithKeys("E")),
+		CollapseAll: key.NewBinding(key.WithKeys("C")),
+	})
+	return tr
+}
+
+func node(id string, children ...Node[viewport.RenderableString]) Node[viewport.RenderableString] {
+	return Node[viewport.RenderableString]{
+		ID:       id,
+		Item:     viewport.RenderableString{LineBuffer: linebuffer.New(id)},
+		Children: children,
+	}
+}
+
+func jobs() []Node[viewport.RenderableString] {
+	return []Node[viewport.RenderableString]{
+		node("job-a",
+			node("group-1",
+				node("alloc-1"),
+				node("alloc-2"),
+			),
+			node("group-2",
+				node("alloc-3"),
+			),
+		),
+		node("job-b"),
+	}
+}
+
+func TestTree_View(t *testing.T) {
+	w, h := 25, 8
+	tr := newTree(w, h)
+	tr.SetNodes(jobs())
+	expectedView := testutil.Pad(w, h, []string{
+		testutil.Selected("▶ job-a"),
+		"job-b",
+	})
+	testutil.CmpStr(t, expectedView, tr.View())
+
+	tr.ExpandAll()
+	expectedView = testutil.Pad(w, h, []string{
+		testutil.Selected("▼ job-a"),
+		"├── ▼ group-1",
+		"│   ├── alloc-1",
+		"│   └── alloc-2",
+		"└── ▼ group-2",
+		"    └── alloc-3",
+		"job-b",
+	})
+	testutil.CmpStr(t, expectedView, tr.View())
+}
+
+func TestTree_ExpandCollapseKeys(t *testing.T) {
+	w, h := 25, 8
+	tr := newTree(w, h)
+	tr.SetNodes(jobs())
+
+	tr, _ = tr.Update(expandKeyMsg)
+	tr, _ = tr.Update(downKeyMsg)
+	tr, _ = tr.Update(toggleKeyMsg)
+	tr, _ = tr.Update(downKeyMsg)
+	expectedView := testutil.Pad(w, h, []string{
+		"▼ job-a",
+		"├── ▼ group-1",
+		testutil.Selected("│   ├── alloc-1"),
+		"│   └── alloc-2",
+		"└── ▶ group-2",
+		"job-b",
+	})
+	testutil.CmpStr(t, expectedView, tr.View())
+
+	// collapsing a leaf selects its parent
+	tr, _ = tr.Update(collapseKeyMsg)
+	if n := tr.GetSelectedNode(); n == nil || n.ID != "group-1" {
+		t.Fatalf("expected group-1 to be selected, got %v", n)
+	}
+
+	// collapsing an expanded node collapses it, keeping it selected
+	tr, _ = tr.Update(collapseKeyMsg)
+	expectedView = testutil.Pad(w, h, []string{
+		"▼ job-a",
+		testutil.Selected("├── ▶ group-1"),
+		"└── ▶ group-2",
+		"job-b",
+	})
+	testutil.CmpStr(t, expectedView, tr.View())
+}
+
+func TestTree_SelectionStaysOnNode(t *testing.T) {
+	w, h := 25, 8
+	tr := newTree(w, h)
+	tr.SetNodes(jobs())
+	if !tr.SetSelectedID("alloc-2") {
+		t.Fatalf("expected alloc-2 to exist")
+	}
+	if tr.SetSelectedID("missing") {
+		t.Errorf("expected missing node not to exist")
+	}
+	expectedView := testutil.Pad(w, h, []string{
+		"▼ job-a",
+		"├── ▼ group-1",
+		"│   ├── alloc-1",
+		testutil.Selected("│   └── alloc-2"),
+		"└── ▶ group-2",
+		"job-b",
+	})
+	testutil.CmpStr(t, expectedView, tr.View())
+
+	// selection follows the node when the tree changes
+	tr.SetNodes([]Node[viewport.RenderableString]{
+		node("job-0"),
+		node("job-a",
+			node("group-1",
+				node("alloc-0"),
+				node("alloc-1"),
+				node("alloc-2"),
+			),
+		),
+	})
+	expectedView = testutil.Pad(w, h, []string{
+		"job-0",
+		"▼ job-a",
+		"└── ▼ group-1",
+		"    ├── alloc-0",
+		"    ├── alloc-1",
+		testutil.Selected("    └── alloc-2"),
+	})
+	testutil.CmpStr(t, expectedView, tr.View())
+
+	// collapsing an ancestor of the selection selects the nearest visible ancestor
+	tr.Collapse("job-a")
+	if n := tr.GetSelectedNode(); n == nil || n.ID != "job-a" {
+		t.Errorf("expected job-a to be selected, got %v", n)
+	}
+
+	// removing the selected node selects its nearest remaining ancestor
+	tr.SetSelectedID("alloc-2")
+	tr.SetNodes([]Node[viewport.RenderableString]{
+		node("job-0"),
+		node("job-a",
+			node("group-1",
+				node("alloc-0"),
+			),
+		),
+	})
+	if n := tr.GetSelectedNode(); n == nil || n.ID != "group-1" {
+		t.Errorf("expected group-1 to be selected, got %v", n)
+	}
+}
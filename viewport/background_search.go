@@ -0,0 +1,197 @@
+package viewport
+
+import (
+	"context"
+	"slices"
+	"sort"
+
+	tea "github.com/charmbracelet/bubbletea/v2"
+	"github.com/robinovitch61/bubbleo/viewport/linebuffer"
+)
+
+// defaultSearchBatchSize is the number of items a background search scans between progress messages by default
+const defaultSearchBatchSize = 10_000
+
+// backgroundSearch is the state of the latest search for items matching the highlight, scanned in a goroutine
+type backgroundSearch struct {
+	// active is true while the search is in progress
+	active bool
+
+	// tag increments for each search so messages from previous searches are ignored
+	tag int
+
+	// cancel stops the goroutine scanning items
+	cancel context.CancelFunc
+
+	// matches are the indexes of the matching items found so far, ascending
+	matches []int
+
+	// numScanned is the number of items scanned so far
+	numScanned int
+
+	// numItems is the number of items being searched
+	numItems int
+
+	// done is true once every item has been scanned. The matches are complete until the content or highlight changes
+	done bool
+}
+
+// SearchProgressMsg reports the progress of a background search started with StartSearch. It must be passed to the
+// viewport's Update, which updates the search results and returns the command waiting for the next message
+type SearchProgressMsg struct {
+	navigationManagerID int64
+	tag                 int
+
+	// msgs sends the rest of the search's messages, and is closed once the search stops
+	msgs <-chan SearchProgressMsg
+
+	// matches are the indexes of the matching items found since the previous message, ascending
+	matches []int
+
+	// NumMatches is the number of matching items found so far
+	NumMatches int
+
+	// NumScanned is the number of items scanned so far
+	NumScanned int
+
+	// NumItems is the number of items being searched
+	NumItems int
+
+	// Done is true for the last message of a search that scanned every item
+	Done bool
+}
+
+// StartSearch starts finding the items matching the highlight in a goroutine, cancelling any search in progress. The
+// returned command streams SearchProgressMsgs as batches of items are scanned. Changing the content or highlight
+// cancels the search. Items are rendered in the goroutine, so their Render must be safe to call concurrently
+func (m *Model[T]) StartSearch() tea.Cmd {
+	m.CancelSearch()
+	search := &m.content.search
+	search.tag++
+	search.active = true
+	search.numItems = m.content.NumItems()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	search.cancel = cancel
+	msgs := make(chan SearchProgressMsg)
+	base := SearchProgressMsg{navigationManagerID: m.navigation.id, tag: search.tag, msgs: msgs, NumItems: search.numItems}
+	// the goroutine gets its own copy of the items, leaving the content manager to the main goroutine
+	go runSearch(ctx, slices.Clone(m.content.Items), m.content.ToHighlight, max(1, m.config.SearchBatchSize), base, msgs)
+	return waitForSearchProgress(msgs)
+}
+
+// CancelSearch stops any search in progress and discards its results
+func (m *Model[T]) CancelSearch() {
+	search := &m.content.search
+	if search.cancel != nil {
+		search.cancel()
+	}
+	m.content.search = backgroundSearch{tag: search.tag}
+}
+
+// IsSearching returns true while a search started with StartSearch is in progress
+func (m *Model[T]) IsSearching() bool {
+	return m.content.search.active
+}
+
+// GetSearchMatches returns the indexes of the matching items found by the latest search so far, ascending
+func (m *Model[T]) GetSearchMatches() []int {
+	return slices.Clone(m.content.search.matches)
+}
+
+// GetSearchProgress returns the number of items scanned and being searched by the latest search, and whether it
+// scanned every item
+func (m *Model[T]) GetSearchProgress() (numScanned, numItems int, done bool) {
+	search := m.content.search
+	return search.numScanned, search.numItems, search.done
+}
+
+// SetSearchBatchSize sets the number of items a search scans between progress messages
+func (m *Model[T]) SetSearchBatchSize(batchSize int) {
+	m.config.SearchBatchSize = batchSize
+}
+
+// processSearchProgress adds the results in msg to the search, returning the command waiting for the next message if
+// the search isn't finished
+func (m *Model[T]) processSearchProgress(msg SearchProgressMsg) tea.Cmd {
+	search := &m.content.search
+	if !search.active || msg.navigationManagerID != m.navigation.id || msg.tag != search.tag {
+		return nil
+	}
+	search.matches = append(search.matches, msg.matches...)
+	search.numScanned = msg.NumScanned
+	if msg.Done {
+		search.active = false
+		search.done = true
+		search.cancel()
+		return nil
+	}
+	return waitForSearchProgress(msg.msgs)
+}
+
+// searchMatchIdx returns the index of the nearest item found by a finished search in the direction of step from
+// itemIdx, wrapping around, or -1 if there are no matches
+func (m *Model[T]) searchMatchIdx(itemIdx, step int) int {
+	matches := m.content.search.matches
+	if len(matches) == 0 {
+		return -1
+	}
+	if step > 0 {
+		i := sort.SearchInts(matches, itemIdx+1)
+		return matches[i%len(matches)]
+	}
+	i := sort.SearchInts(matches, itemIdx)
+	return matches[(i-1+len(matches))%len(matches)]
+}
+
+// runSearch scans items for toHighlight, sending a message with the new matches after each batch until every item is
+// scanned or ctx is cancelled. Closes msgs when it returns
+func runSearch[T Renderable](
+	ctx context.Context,
+	items []T,
+	toHighlight linebuffer.HighlightData,
+	batchSize int,
+	base SearchProgressMsg,
+	msgs chan<- SearchProgressMsg,
+) {
+	defer close(msgs)
+	numMatches := 0
+	for start := 0; ; start += batchSize {
+		end := min(start+batchSize, len(items))
+		var matches []int
+		for i := start; i < end; i++ {
+			if ctx.Err() != nil {
+				return
+			}
+			if linebuffer.MatchesHighlight(items[i].Render(), toHighlight) {
+				matches = append(matches, i)
+			}
+		}
+		numMatches += len(matches)
+
+		msg := base
+		msg.matches = matches
+		msg.NumMatches = numMatches
+		msg.NumScanned = end
+		msg.Done = end == len(items)
+		select {
+		case msgs <- msg:
+		case <-ctx.Done():
+			return
+		}
+		if msg.Done {
+			return
+		}
+	}
+}
+
+// waitForSearchProgress returns a command receiving the next message of a search, or nothing once it stops
+func waitForSearchProgress(msgs <-chan SearchProgressMsg) tea.Cmd {
+	return func() tea.Msg {
+		msg, ok := <-msgs
+		if !ok {
+			return nil
+		}
+		return msg
+	}
+}
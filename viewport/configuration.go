@@ -1,5 +1,7 @@
 package viewport
 
+import "time"
+
 // Configuration consolidates all configuration options for the viewport
 type Configuration struct {
 	// wrapText is true if the viewport wraps text rather than showing that a line is truncated/horizontally scrollable
@@ -8,8 +10,38 @@ type Configuration struct {
 	// footerEnabled is true if the viewport will show the footer when it overflows
 	FooterEnabled bool
 
+	// panHeader is true if header lines pan horizontally with the content when wrapping is off
+	PanHeader bool
+
+	// frozenWidth is the number of leading terminal cells of each line that stay in place when panning horizontally
+	FrozenWidth int
+
 	// continuationIndicator is the string to use to indicate that a line has been truncated from the left or right
 	ContinuationIndicator string
+
+	// scrollOff is the minimum number of lines to keep visible above and below the selection when navigating
+	ScrollOff int
+
+	// keepSelectionCentered is true if the viewport scrolls to keep the selection vertically centered when navigating
+	KeepSelectionCentered bool
+
+	// smoothScroll is true if paging up and down is animated over several frames rather than jumping instantly
+	SmoothScroll bool
+
+	// smoothScrollDuration is how long a smooth scroll takes
+	SmoothScrollDuration time.Duration
+
+	// smoothScrollEasing maps elapsed time to distance scrolled during a smooth scroll
+	SmoothScrollEasing EasingFn
+
+	// scrollLinkGroup is the group of viewports that scroll together, or empty if the viewport isn't linked
+	ScrollLinkGroup string
+
+	// fullWidthSelection is true if the selection style fills each line of the selected item to the viewport width
+	FullWidthSelection bool
+
+	// searchBatchSize is the number of items a background search scans between progress messages
+	SearchBatchSize int
 }
 
 // NewConfiguration creates a new Configuration with default settings.
@@ -17,6 +49,16 @@ func NewConfiguration() *Configuration {
 	return &Configuration{
 		WrapText:              false,
 		FooterEnabled:         true,
+		PanHeader:             false,
+		FrozenWidth:           0,
 		ContinuationIndicator: "...",
+		ScrollOff:             0,
+		KeepSelectionCentered: false,
+		SmoothScroll:          false,
+		SmoothScrollDuration:  defaultSmoothScrollDuration,
+		SmoothScrollEasing:    EaseOutCubic,
+		ScrollLinkGroup:       "",
+		FullWidthSelection:    false,
+		SearchBatchSize:       defaultSearchBatchSize,
 	}
 }
@@ -1,6 +1,10 @@
 package viewport
 
-import "github.com/robinovitch61/bubbleo/viewport/linebuffer"
+import (
+	"sort"
+
+	"github.com/robinovitch61/bubbleo/viewport/linebuffer"
+)
 
 // ContentManager manages the actual content and selection state
 type ContentManager[T Renderable] struct {
@@ -20,22 +24,123 @@ type ContentManager[T Renderable] struct {
 	// CompareFn is an optional function to compare items for maintaining the selection when content changes
 	// if set, the viewport will try to maintain the previous selected item when content changes
 	CompareFn CompareFn[T]
+
+	// marks maps a mark name to the index in Items of the marked item
+	marks map[rune]int
+
+	// allFolded is true if items are folded by default, as after folding all of them
+	allFolded bool
+
+	// foldExceptions is the set of indexes in Items of items folded differently than allFolded says
+	foldExceptions map[int]struct{}
+
+	// LinkKeyFn is an optional function returning a key for an item, used to align linked viewports by item rather
+	// than by index
+	LinkKeyFn func(T) string
+
+	// ItemStyleFn is an optional function returning the style of every line of an item
+	ItemStyleFn ItemStyleFunc[T]
+
+	// search is the latest background search for items matching ToHighlight
+	search backgroundSearch
 }
 
 // NewContentManager creates a new ContentManager with empty initial state.
 func NewContentManager[T Renderable]() *ContentManager[T] {
 	return &ContentManager[T]{
-		Items:       []T{},
-		Header:      []string{},
-		selectedIdx: 0,
+		Items:          []T{},
+		Header:         []string{},
+		selectedIdx:    0,
+		marks:          map[rune]int{},
+		foldExceptions: map[int]struct{}{},
 	}
 }
 
+// SetItems replaces Items. If CompareFn is set, marks and folds stay on the same items, otherwise they stay on the same
+// indexes. After SetAllFolded, items new in items are folded or unfolded like the rest.
+func (cm *ContentManager[T]) SetItems(items []T) {
+	prevItems := cm.Items
+	cm.Items = items
+	prevIdxs := make(map[int]struct{}, len(cm.marks)+len(cm.foldExceptions))
+	for _, idx := range cm.marks {
+		prevIdxs[idx] = struct{}{}
+	}
+	for idx := range cm.foldExceptions {
+		prevIdxs[idx] = struct{}{}
+	}
+	newIdxs := cm.remapIdxs(prevItems, prevIdxs)
+
+	for name, idx := range cm.marks {
+		if newIdx, ok := newIdxs[idx]; ok {
+			cm.marks[name] = newIdx
+		} else {
+			delete(cm.marks, name)
+		}
+	}
+	prevFoldExceptions := cm.foldExceptions
+	cm.foldExceptions = make(map[int]struct{}, len(prevFoldExceptions))
+	for idx := range prevFoldExceptions {
+		if newIdx, ok := newIdxs[idx]; ok {
+			cm.foldExceptions[newIdx] = struct{}{}
+		}
+	}
+}
+
+// remapIdxs returns the indexes in Items of the items that were at idxs in prevItems, keyed by their index in
+// prevItems and leaving out those that are gone. Each item is compared once against the previous items not yet found,
+// rather than searching all items for each previous one
+func (cm *ContentManager[T]) remapIdxs(prevItems []T, idxs map[int]struct{}) map[int]int {
+	res := make(map[int]int, len(idxs))
+	if cm.CompareFn == nil {
+		for idx := range idxs {
+			if idx < len(cm.Items) {
+				res[idx] = idx
+			}
+		}
+		return res
+	}
+	pending := make([]int, 0, len(idxs))
+	for idx := range idxs {
+		pending = append(pending, idx)
+	}
+	for i := 0; i < len(cm.Items) && len(pending) > 0; i++ {
+		notFound := pending[:0]
+		for _, idx := range pending {
+			if cm.CompareFn(cm.Items[i], prevItems[idx]) {
+				res[idx] = i
+			} else {
+				notFound = append(notFound, idx)
+			}
+		}
+		pending = notFound
+	}
+	return res
+}
+
 // SetSelectedIdx sets the selected item index.
 func (cm *ContentManager[T]) SetSelectedIdx(idx int) {
 	cm.selectedIdx = clampValZeroToMax(idx, len(cm.Items)-1)
 }
 
+// IndexOfLinkKey returns the index of the first item whose LinkKeyFn key is key, or -1 if none.
+func (cm *ContentManager[T]) IndexOfLinkKey(key string) int {
+	if cm.LinkKeyFn == nil {
+		return -1
+	}
+	for i := range cm.Items {
+		if cm.LinkKeyFn(cm.Items[i]) == key {
+			return i
+		}
+	}
+	return -1
+}
+
+// ItemMatchesHighlight returns true if the item at idx contains ToHighlight, ignoring ansi styling. Returns false if
+// there is nothing to highlight.
+func (cm *ContentManager[T]) ItemMatchesHighlight(idx int) bool {
+	return linebuffer.MatchesHighlight(cm.Items[idx].Render(), cm.ToHighlight)
+}
+
 // GetSelectedIdx returns the current selected item index.
 func (cm *ContentManager[T]) GetSelectedIdx() int {
 	return cm.selectedIdx
@@ -67,3 +172,100 @@ func (cm *ContentManager[T]) ValidateSelectedIdx() {
 	}
 	cm.selectedIdx = clampValZeroToMax(cm.selectedIdx, len(cm.Items)-1)
 }
+
+// SetMark sets the mark with the given name on the item at idx, replacing any existing mark with that name.
+func (cm *ContentManager[T]) SetMark(name rune, idx int) {
+	if idx < 0 || idx >= len(cm.Items) {
+		return
+	}
+	cm.marks[name] = idx
+}
+
+// ClearMark removes the mark with the given name.
+func (cm *ContentManager[T]) ClearMark(name rune) {
+	delete(cm.marks, name)
+}
+
+// ClearMarks removes all marks.
+func (cm *ContentManager[T]) ClearMarks() {
+	cm.marks = map[rune]int{}
+}
+
+// GetMarkIdx returns the item index of the mark with the given name and whether the mark exists.
+func (cm *ContentManager[T]) GetMarkIdx(name rune) (int, bool) {
+	idx, ok := cm.marks[name]
+	return idx, ok
+}
+
+// GetMarks returns the marked items by mark name.
+func (cm *ContentManager[T]) GetMarks() map[rune]T {
+	res := make(map[rune]T, len(cm.marks))
+	for name, idx := range cm.marks {
+		res[name] = cm.Items[idx]
+	}
+	return res
+}
+
+// IsMarked returns true if the item at idx has at least one mark.
+func (cm *ContentManager[T]) IsMarked(idx int) bool {
+	for _, markedIdx := range cm.marks {
+		if markedIdx == idx {
+			return true
+		}
+	}
+	return false
+}
+
+// GetMarkedIdxs returns the sorted, distinct indexes of marked items.
+func (cm *ContentManager[T]) GetMarkedIdxs() []int {
+	seen := make(map[int]struct{}, len(cm.marks))
+	res := make([]int, 0, len(cm.marks))
+	for _, idx := range cm.marks {
+		if _, ok := seen[idx]; !ok {
+			seen[idx] = struct{}{}
+			res = append(res, idx)
+		}
+	}
+	sort.Ints(res)
+	return res
+}
+
+// SetFolded sets whether the item at idx is folded.
+func (cm *ContentManager[T]) SetFolded(idx int, folded bool) {
+	if idx < 0 || idx >= len(cm.Items) {
+		return
+	}
+	if folded != cm.allFolded {
+		cm.foldExceptions[idx] = struct{}{}
+	} else {
+		delete(cm.foldExceptions, idx)
+	}
+}
+
+// IsFolded returns true if the item at idx is folded.
+func (cm *ContentManager[T]) IsFolded(idx int) bool {
+	if idx < 0 || idx >= len(cm.Items) {
+		return false
+	}
+	_, isException := cm.foldExceptions[idx]
+	return isException != cm.allFolded
+}
+
+// SetAllFolded folds or unfolds all items, including those set later.
+func (cm *ContentManager[T]) SetAllFolded(folded bool) {
+	cm.allFolded = folded
+	cm.foldExceptions = map[int]struct{}{}
+}
+
+// findItemIdx returns the index of the first item equal to item according to CompareFn, or -1 if none
+func (cm *ContentManager[T]) findItemIdx(item T) int {
+	if cm.CompareFn == nil {
+		return -1
+	}
+	for i := range cm.Items {
+		if cm.CompareFn(cm.Items[i], item) {
+			return i
+		}
+	}
+	return -1
+}
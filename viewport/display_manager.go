@@ -22,6 +22,12 @@ type DisplayManager struct {
 
 	// Styles contains the styling configuration
 	Styles Styles
+
+	// BlurredStyles contains the styling configuration used instead of Styles while the viewport is blurred, if set
+	BlurredStyles *Styles
+
+	// animation is the in-progress smooth scroll, if any
+	animation scrollAnimation
 }
 
 // NewDisplayManager creates a new DisplayManager with the specified dimensions and styles.
@@ -44,12 +50,21 @@ func (dm *DisplayManager) SetBounds(width, height int) {
 	dm.Bounds.Height = max(0, height)
 }
 
-// GetHighlightStyle returns the appropriate highlight style based on selection state.
-func (dm *DisplayManager) GetHighlightStyle(isSelected bool) lipgloss.Style {
+// GetStyles returns the styling configuration for the focus state.
+func (dm *DisplayManager) GetStyles(focused bool) Styles {
+	if !focused && dm.BlurredStyles != nil {
+		return *dm.BlurredStyles
+	}
+	return dm.Styles
+}
+
+// GetHighlightStyle returns the appropriate highlight style based on selection and focus state.
+func (dm *DisplayManager) GetHighlightStyle(isSelected, focused bool) lipgloss.Style {
+	styles := dm.GetStyles(focused)
 	if isSelected {
-		return dm.Styles.HighlightStyleIfSelected
+		return styles.HighlightStyleIfSelected
 	}
-	return dm.Styles.HighlightStyle
+	return styles.HighlightStyle
 }
 
 // SafelySetTopItemIdxAndOffset safely sets the top item index and offset within bounds.
@@ -2,7 +2,8 @@ package viewport
 
 import "github.com/charmbracelet/bubbles/v2/key"
 
-// KeyMap contains viewport key bindings
+// KeyMap contains viewport key bindings.
+// A key with spaces is a sequence of keys pressed one after another, e.g. key.WithKeys("g g")
 type KeyMap struct {
 	PageDown     key.Binding
 	PageUp       key.Binding
@@ -14,4 +15,28 @@ type KeyMap struct {
 	Right        key.Binding
 	Top          key.Binding
 	Bottom       key.Binding
+
+	// Percent jumps to the percentage of the content given by the count typed before it, e.g. `50%`.
+	// Only used when count prefixes are enabled
+	Percent key.Binding
+
+	// SetMark sets a mark named by the next key pressed, e.g. `m` then `a`
+	SetMark key.Binding
+	// JumpToMark jumps to the mark named by the next key pressed, e.g. `'` then `a`
+	JumpToMark key.Binding
+	// NextMark jumps to the next marked item, wrapping around to the first
+	NextMark key.Binding
+	// PrevMark jumps to the previous marked item, wrapping around to the last
+	PrevMark key.Binding
+
+	// CenterSelection scrolls the selection to the vertical center of the viewport, e.g. `z z`
+	CenterSelection key.Binding
+	// TopAlignSelection scrolls the selection to the top of the viewport, e.g. `z t`
+	TopAlignSelection key.Binding
+	// BottomAlignSelection scrolls the selection to the bottom of the viewport, e.g. `z b`
+	BottomAlignSelection key.Binding
+
+	// ToggleFold folds the selected item to a single summary line, or unfolds it, e.g. `z a`. Only applies when
+	// wrapping is on. When selection is disabled, applies to the top visible item
+	ToggleFold key.Binding
 }
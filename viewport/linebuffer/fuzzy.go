@@ -0,0 +1,136 @@
+package linebuffer
+
+import (
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/charmbracelet/lipgloss/v2"
+)
+
+// fuzzy match scoring, loosely following fzf: every matched rune scores, runes starting words or continuing a run
+// of matched runes score more, and gaps between matched runes cost
+const (
+	fuzzyScoreMatch          = 16
+	fuzzyBonusBoundary       = 8
+	fuzzyBonusCamel          = 7
+	fuzzyBonusConsecutive    = 4
+	fuzzyPenaltyGapStart     = 3
+	fuzzyPenaltyGapExtension = 1
+)
+
+// FuzzyResult is how a pattern fuzzy matches some text.
+type FuzzyResult struct {
+	// Score is higher for better matches, favoring matched runes that are consecutive or start words
+	Score int
+
+	// Positions are the byte offsets in the text of the matched runes, in order
+	Positions []int
+}
+
+// FuzzyMatch matches the runes of pattern in order anywhere in text, which must not contain ansi codes, like fzf.
+// Among the matches, it prefers the shortest one ending earliest. caseMode is how letter case is compared. Returns
+// false if text doesn't contain the runes of pattern in order, or pattern is empty.
+func FuzzyMatch(pattern, text string, caseMode CaseMode) (FuzzyResult, bool) {
+	if pattern == "" {
+		return FuzzyResult{}, false
+	}
+	ignoreCase := HighlightData{StringToHighlight: pattern, CaseMode: caseMode}.ignoreCase()
+	equal := func(a, b rune) bool {
+		return a == b || (ignoreCase && equalFoldRune(a, b))
+	}
+	patternRunes := []rune(pattern)
+
+	// find where the earliest match ends
+	patternIdx, end := 0, -1
+	for i, r := range text {
+		if equal(r, patternRunes[patternIdx]) {
+			patternIdx++
+			if patternIdx == len(patternRunes) {
+				end = i + utf8.RuneLen(r)
+				break
+			}
+		}
+	}
+	if end < 0 {
+		return FuzzyResult{}, false
+	}
+
+	// scan back from there for the latest start, making the match as short as possible
+	start := 0
+	patternIdx = len(patternRunes) - 1
+	for i := end; i > 0; {
+		r, size := utf8.DecodeLastRuneInString(text[:i])
+		i -= size
+		if equal(r, patternRunes[patternIdx]) {
+			patternIdx--
+			if patternIdx < 0 {
+				start = i
+				break
+			}
+		}
+	}
+
+	positions := make([]int, 0, len(patternRunes))
+	patternIdx = 0
+	for i, r := range text[start:end] {
+		if patternIdx < len(patternRunes) && equal(r, patternRunes[patternIdx]) {
+			positions = append(positions, start+i)
+			patternIdx++
+		}
+	}
+	return FuzzyResult{Score: fuzzyScore(text, positions), Positions: positions}, true
+}
+
+// fuzzyScore scores the runes at positions in text as a fuzzy match
+func fuzzyScore(text string, positions []int) int {
+	score := 0
+	prevEnd, chunkBonus := -1, 0
+	for k, pos := range positions {
+		prev, _ := utf8.DecodeLastRuneInString(text[:pos])
+		r, size := utf8.DecodeRuneInString(text[pos:])
+		bonus := 0
+		switch {
+		case pos == 0 || (!isWordRune(prev) && isWordRune(r)):
+			bonus = fuzzyBonusBoundary
+		case (unicode.IsLower(prev) && unicode.IsUpper(r)) || (!unicode.IsDigit(prev) && unicode.IsDigit(r)):
+			bonus = fuzzyBonusCamel
+		}
+		if k > 0 {
+			if pos == prevEnd {
+				// a run of matched runes keeps the bonus of its first rune, so it isn't outscored by separate runes
+				// that each start a word
+				bonus = max(bonus, chunkBonus, fuzzyBonusConsecutive)
+			} else {
+				gap := utf8.RuneCountInString(text[prevEnd:pos])
+				score -= fuzzyPenaltyGapStart + (gap-1)*fuzzyPenaltyGapExtension
+				chunkBonus = bonus
+			}
+		} else {
+			chunkBonus = bonus
+			// the first rune starting a word matters most
+			bonus *= 2
+		}
+		score += fuzzyScoreMatch + bonus
+		prevEnd = pos + size
+	}
+	return score
+}
+
+// FuzzyHighlightRanges returns the HighlightRanges styling the runes of the content of lb at positions, merging
+// consecutive runes. positions are byte offsets in the content without ansi codes, like FuzzyResult.Positions from
+// FuzzyMatchContent, so the matched runes can be highlighted with WithHighlightRanges without matching again
+func FuzzyHighlightRanges(lb LineBufferer, positions []int, style lipgloss.Style) []HighlightRange {
+	text := noAnsiContent(lb)
+	var ranges []HighlightRange
+	runeIdx, prevPos := 0, 0
+	for _, pos := range positions {
+		runeIdx += utf8.RuneCountInString(text[prevPos:pos])
+		prevPos = pos
+		if n := len(ranges); n > 0 && ranges[n-1].End == runeIdx {
+			ranges[n-1].End++
+			continue
+		}
+		ranges = append(ranges, HighlightRange{Start: runeIdx, End: runeIdx + 1, Unit: Runes, Style: style})
+	}
+	return ranges
+}
@@ -0,0 +1,185 @@
+package linebuffer
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/robinovitch61/bubbleo/internal/testutil"
+)
+
+func TestFuzzyMatch(t *testing.T) {
+	for _, tt := range []struct {
+		name      string
+		pattern   string
+		text      string
+		caseMode  CaseMode
+		expected  []int
+		noMatches bool
+	}{
+		{
+			name:      "empty pattern",
+			pattern:   "",
+			text:      "foo bar",
+			noMatches: true,
+		},
+		{
+			name:     "word starts",
+			pattern:  "fb",
+			text:     "foo bar",
+			expected: []int{0, 4},
+		},
+		{
+			name:      "out of order",
+			pattern:   "bf",
+			text:      "foo bar",
+			noMatches: true,
+		},
+		{
+			name:     "shortest match ending earliest",
+			pattern:  "ab",
+			text:     "a_a_ab",
+			expected: []int{4, 5},
+		},
+		{
+			name:     "earliest end over later consecutive",
+			pattern:  "abc",
+			text:     "xaxbxcabc",
+			expected: []int{1, 3, 5},
+		},
+		{
+			name:      "case-sensitive",
+			pattern:   "fb",
+			text:      "Foo Bar",
+			noMatches: true,
+		},
+		{
+			name:     "case-insensitive",
+			pattern:  "FB",
+			text:     "foo bar",
+			caseMode: CaseInsensitive,
+			expected: []int{0, 4},
+		},
+		{
+			name:     "smart case lowercase",
+			pattern:  "fb",
+			text:     "Foo Bar",
+			caseMode: SmartCase,
+			expected: []int{0, 4},
+		},
+		{
+			name:      "smart case uppercase",
+			pattern:   "Fb",
+			text:      "foo bar",
+			caseMode:  SmartCase,
+			noMatches: true,
+		},
+		{
+			name:     "byte offsets of multi-byte runes",
+			pattern:  "éa",
+			text:     "café bar",
+			expected: []int{3, 7},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			result, ok := FuzzyMatch(tt.pattern, tt.text, tt.caseMode)
+			if ok == tt.noMatches {
+				t.Fatalf("expected match %v, got %v", !tt.noMatches, ok)
+			}
+			if diff := cmp.Diff(tt.expected, result.Positions); diff != "" {
+				t.Errorf("Diff (-expected +actual):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestFuzzyMatch_Score(t *testing.T) {
+	for _, tt := range []struct {
+		name   string
+		better string
+		worse  string
+	}{
+		{
+			name:   "consecutive",
+			better: "xx abc",
+			worse:  "xx a-b-c",
+		},
+		{
+			name:   "word starts",
+			better: "xx all big cats",
+			worse:  "xx dabbcc",
+		},
+		{
+			name:   "camel case",
+			better: "xx aBigCat",
+			worse:  "xx abigcat",
+		},
+		{
+			name:   "after separator",
+			better: "xx abc",
+			worse:  "xxabc",
+		},
+		{
+			name:   "shorter gaps",
+			better: "a-b-c",
+			worse:  "a---b---c",
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			better, _ := FuzzyMatch("abc", tt.better, CaseInsensitive)
+			worse, _ := FuzzyMatch("abc", tt.worse, CaseInsensitive)
+			if better.Score <= worse.Score {
+				t.Errorf("expected %q (%d) to score higher than %q (%d)", tt.better, better.Score, tt.worse, worse.Score)
+			}
+		})
+	}
+}
+
+func TestFuzzyHighlightRanges(t *testing.T) {
+	for _, tt := range []struct {
+		name     string
+		s        string
+		pattern  string
+		caseMode CaseMode
+		expected string
+	}{
+		{
+			name:     "matched runes",
+			s:        "foo bar",
+			pattern:  "fb",
+			expected: redFg.Render("f") + "oo " + redFg.Render("b") + "ar",
+		},
+		{
+			name:     "consecutive runes",
+			s:        "foo bar",
+			pattern:  "fba",
+			expected: redFg.Render("f") + "oo " + redFg.Render("ba") + "r",
+		},
+		{
+			name:     "multi-byte runes",
+			s:        "héllo wörld",
+			pattern:  "öd",
+			expected: "héllo w" + redFg.Render("ö") + "rl" + redFg.Render("d"),
+		},
+		{
+			name:     "styled",
+			s:        "\x1b[38;2;0;0;255mfoo\x1b[m bar",
+			pattern:  "FB",
+			caseMode: CaseInsensitive,
+			expected: redFg.Render("f") + "\x1b[38;2;0;0;255moo\x1b[m " + redFg.Render("b") + "ar",
+		},
+		{
+			name:     "no match",
+			s:        "foo bar",
+			pattern:  "bf",
+			expected: "foo bar",
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			lb := New(tt.s)
+			result, _ := FuzzyMatchContent(lb, tt.pattern, tt.caseMode)
+			lb = lb.WithHighlightRanges(FuzzyHighlightRanges(lb, result.Positions, redFg)...)
+			actual, _ := lb.Take(0, 20, "", HighlightData{}, redFg)
+			testutil.CmpStr(t, tt.expected, actual)
+		})
+	}
+}
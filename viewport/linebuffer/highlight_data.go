@@ -1,10 +1,279 @@
 package linebuffer
 
-import "regexp"
+import (
+	"math"
+	"regexp"
+	"strings"
+	"sync"
+	"unicode"
+	"unicode/utf8"
+)
+
+// CaseMode is how letter case is compared when matching what to highlight.
+type CaseMode int
+
+const (
+	// CaseSensitive matches only text with the same case.
+	CaseSensitive CaseMode = iota
+	// CaseInsensitive matches text regardless of case.
+	CaseInsensitive
+	// SmartCase matches text regardless of case unless what to highlight contains an uppercase letter, like vim's
+	// smartcase. For regexes, uppercase letters directly after a backslash, like in \S, don't count.
+	SmartCase
+)
+
+// MatchMode is how StringToHighlight is matched.
+type MatchMode int
+
+const (
+	// MatchSubstring matches StringToHighlight anywhere.
+	MatchSubstring MatchMode = iota
+	// MatchWholeWord matches StringToHighlight only where it isn't preceded or followed by a letter, digit, or
+	// underscore.
+	MatchWholeWord
+	// MatchGlob matches StringToHighlight with * matching any run of characters and ? matching any one character.
+	// A backslash matches the character after it literally.
+	MatchGlob
+)
 
 // HighlightData contains information about what to highlight in each item in the viewport.
 type HighlightData struct {
 	StringToHighlight       string
 	RegexPatternToHighlight *regexp.Regexp
 	IsRegex                 bool
+	CaseMode                CaseMode
+	MatchMode               MatchMode
+}
+
+// maxDerivedRegexes is the most regexes derivedRegexCache holds before it is cleared
+const maxDerivedRegexes = 64
+
+// derivedRegexCache holds the regexes compiled from patterns derived from HighlightData, keyed by pattern, as the same
+// HighlightData is matched against every line rendered and several viewports can highlight different things. Patterns
+// that fail to compile are kept as nil so they aren't compiled again
+var derivedRegexCache = struct {
+	sync.Mutex
+	regexes map[string]*regexp.Regexp
+}{regexes: make(map[string]*regexp.Regexp)}
+
+// derivedRegex returns the compiled pattern, or nil if it fails to compile
+func derivedRegex(pattern string) *regexp.Regexp {
+	derivedRegexCache.Lock()
+	defer derivedRegexCache.Unlock()
+	regex, ok := derivedRegexCache.regexes[pattern]
+	if !ok {
+		if len(derivedRegexCache.regexes) >= maxDerivedRegexes {
+			clear(derivedRegexCache.regexes)
+		}
+		regex, _ = regexp.Compile(pattern)
+		derivedRegexCache.regexes[pattern] = regex
+	}
+	return regex
+}
+
+// ignoreCase returns true if matching ignores case.
+func (h HighlightData) ignoreCase() bool {
+	switch h.CaseMode {
+	case CaseInsensitive:
+		return true
+	case SmartCase:
+		if h.IsRegex {
+			return h.RegexPatternToHighlight != nil && !hasUpperOutsideEscapes(h.RegexPatternToHighlight.String())
+		}
+		return !strings.ContainsFunc(h.StringToHighlight, unicode.IsUpper)
+	default:
+		return false
+	}
+}
+
+// regex returns the regex to match, made case-insensitive if the case mode calls for it. Returns nil if there is no
+// regex or the case-insensitive version fails to compile.
+func (h HighlightData) regex() *regexp.Regexp {
+	if h.RegexPatternToHighlight == nil || !h.ignoreCase() {
+		return h.RegexPatternToHighlight
+	}
+	return derivedRegex("(?i)" + h.RegexPatternToHighlight.String())
+}
+
+// exact returns true if StringToHighlight is matched exactly as a substring, so it can be compared to the text directly
+// rather than by finding the ranges of its matches.
+func (h HighlightData) exact() bool {
+	return !h.IsRegex && h.MatchMode == MatchSubstring && !h.ignoreCase()
+}
+
+// matches returns true if s, without ansi codes, contains something to highlight.
+func (h HighlightData) matches(s string) bool {
+	if h.IsRegex {
+		regex := h.regex()
+		return regex != nil && regex.MatchString(s)
+	}
+	if h.exact() {
+		return h.StringToHighlight != "" && strings.Contains(s, h.StringToHighlight)
+	}
+	return len(h.findAll(s, 0, len(s), 1)) > 0
+}
+
+// findAll returns the byte ranges of up to n non-overlapping matches of StringToHighlight in s, without ansi codes,
+// that start in [from, to). Matches can extend past to. If n is negative, all matches are returned.
+func (h HighlightData) findAll(s string, from, to, n int) [][]int {
+	if h.StringToHighlight == "" || n == 0 {
+		return nil
+	}
+	from, to = max(0, from), min(len(s), to)
+	var res [][]int
+
+	if h.MatchMode == MatchGlob {
+		regex := derivedRegex(globToRegex(h.StringToHighlight, h.ignoreCase()))
+		if regex == nil || from >= to {
+			return nil
+		}
+		for _, match := range regex.FindAllStringIndex(s[from:], -1) {
+			start, end := from+match[0], from+match[1]
+			if start >= to || len(res) == n {
+				break
+			}
+			if start < end {
+				res = append(res, []int{start, end})
+			}
+		}
+		return res
+	}
+
+	ignoreCase := h.ignoreCase()
+	for i := from; i < to && len(res) != n; {
+		var start, end int
+		if ignoreCase {
+			start, end = indexFold(s, h.StringToHighlight, i)
+		} else if idx := strings.Index(s[i:], h.StringToHighlight); idx >= 0 {
+			start, end = i+idx, i+idx+len(h.StringToHighlight)
+		} else {
+			start = -1
+		}
+		if start < 0 || start >= to {
+			break
+		}
+		if h.MatchMode == MatchWholeWord && !isWholeWord(s, start, end) {
+			// a match can start within a rejected one, e.g. "ab" in "aab ab"
+			_, size := utf8.DecodeRuneInString(s[start:])
+			i = start + size
+			continue
+		}
+		res = append(res, []int{start, end})
+		i = end
+	}
+	return res
+}
+
+// maxMatchBytes returns the most bytes a match of StringToHighlight can span, or math.MaxInt if unbounded. Ignoring
+// case, a match can have more bytes than StringToHighlight, e.g. the 3 byte Kelvin sign matches a "k".
+func (h HighlightData) maxMatchBytes() int {
+	switch {
+	case h.MatchMode == MatchGlob && strings.Contains(h.StringToHighlight, "*"):
+		return math.MaxInt
+	case h.MatchMode == MatchGlob || h.ignoreCase():
+		return utf8.RuneCountInString(h.StringToHighlight) * utf8.UTFMax
+	default:
+		return len(h.StringToHighlight)
+	}
+}
+
+// globToRegex returns a regex pattern matching the same text as glob
+func globToRegex(glob string, ignoreCase bool) string {
+	var builder strings.Builder
+	if ignoreCase {
+		builder.WriteString("(?i)")
+	}
+	escaped := false
+	for _, r := range glob {
+		switch {
+		case escaped:
+			builder.WriteString(regexp.QuoteMeta(string(r)))
+			escaped = false
+		case r == '\\':
+			escaped = true
+		case r == '*':
+			builder.WriteString(".*")
+		case r == '?':
+			builder.WriteString(".")
+		default:
+			builder.WriteString(regexp.QuoteMeta(string(r)))
+		}
+	}
+	if escaped {
+		builder.WriteString(regexp.QuoteMeta("\\"))
+	}
+	return builder.String()
+}
+
+// isWholeWord returns true if the range [start, end) of s isn't preceded or followed by a word character
+func isWholeWord(s string, start, end int) bool {
+	before, _ := utf8.DecodeLastRuneInString(s[:start])
+	after, _ := utf8.DecodeRuneInString(s[end:])
+	return !isWordRune(before) && !isWordRune(after)
+}
+
+// isWordRune returns true if r is a letter, digit, or underscore
+func isWordRune(r rune) bool {
+	return r == '_' || unicode.IsLetter(r) || unicode.IsDigit(r)
+}
+
+// hasUpperOutsideEscapes returns true if pattern has an uppercase letter that isn't directly after a backslash.
+func hasUpperOutsideEscapes(pattern string) bool {
+	escaped := false
+	for _, r := range pattern {
+		if !escaped && unicode.IsUpper(r) {
+			return true
+		}
+		escaped = !escaped && r == '\\'
+	}
+	return false
+}
+
+// indexFold returns the byte range in s of the first match of substr at or after byte offset from, comparing runes
+// with Unicode simple case folding like strings.EqualFold. The length of the range can differ from len(substr), as
+// runes of different case can have different lengths. Returns -1, -1 if there is no match.
+func indexFold(s, substr string, from int) (int, int) {
+	if substr == "" {
+		return -1, -1
+	}
+	for i := from; i < len(s); {
+		if n, ok := hasPrefixFold(s[i:], substr); ok {
+			return i, i + n
+		}
+		_, size := utf8.DecodeRuneInString(s[i:])
+		i += size
+	}
+	return -1, -1
+}
+
+// hasPrefixFold returns the number of bytes of s matching prefix under simple case folding, and whether it matches.
+func hasPrefixFold(s, prefix string) (int, bool) {
+	i := 0
+	for _, pr := range prefix {
+		if i >= len(s) {
+			return 0, false
+		}
+		r, size := utf8.DecodeRuneInString(s[i:])
+		if !equalFoldRune(r, pr) {
+			return 0, false
+		}
+		i += size
+	}
+	return i, true
+}
+
+// equalFoldRune returns true if a and b are equal under simple case folding.
+func equalFoldRune(a, b rune) bool {
+	if a == b {
+		return true
+	}
+	if a < utf8.RuneSelf && b < utf8.RuneSelf {
+		return ('A' <= a && a <= 'Z' && a+'a'-'A' == b) || ('A' <= b && b <= 'Z' && b+'a'-'A' == a)
+	}
+	for r := unicode.SimpleFold(a); r != a; r = unicode.SimpleFold(r) {
+		if r == b {
+			return true
+		}
+	}
+	return false
 }
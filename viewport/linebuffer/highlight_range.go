@@ -0,0 +1,79 @@
+package linebuffer
+
+import (
+	"github.com/charmbracelet/lipgloss/v2"
+)
+
+// RangeUnit is what the bounds of a HighlightRange count.
+type RangeUnit int
+
+const (
+	// Cells counts terminal cells. A rune is in the range if its first cell is.
+	Cells RangeUnit = iota
+	// Runes counts runes.
+	Runes
+)
+
+// HighlightRange styles a span of a LineBuffer's content, not counting ansi codes, e.g. one found by a parser that
+// already knows where the interesting parts of a line are. See LineBuffer.WithHighlightRanges.
+type HighlightRange struct {
+	// Start and End bound the span [Start, End), clamped to the content.
+	Start, End int
+	// Unit is what Start and End count.
+	Unit RangeUnit
+	// Style is the style of the span, replacing any ansi styling of the content within it.
+	Style lipgloss.Style
+}
+
+// styledByteRange is a HighlightRange resolved to byte offsets in lineNoAnsi.
+type styledByteRange struct {
+	start, end int
+	style      lipgloss.Style
+}
+
+// WithHighlightRanges returns a copy of the LineBuffer that styles the given spans of its content wherever it's taken
+// or wrapped, replacing any ranges set before. Later ranges are drawn over earlier ones where they overlap, and what is
+// highlighted with HighlightData is drawn over all of them.
+func (l LineBuffer) WithHighlightRanges(ranges ...HighlightRange) LineBuffer {
+	l.highlightRanges = make([]styledByteRange, 0, len(ranges))
+	for _, r := range ranges {
+		start, end := l.rangeByteOffsets(r)
+		if start < end {
+			l.highlightRanges = append(l.highlightRanges, styledByteRange{start: start, end: end, style: r.Style})
+		}
+	}
+	return l
+}
+
+// rangeByteOffsets returns the byte offsets in lineNoAnsi bounding r.
+func (l LineBuffer) rangeByteOffsets(r HighlightRange) (int, int) {
+	if r.Unit == Cells {
+		clampWidth := func(width int) int { return max(0, min(width, l.Width())) }
+		return l.byteOffsetAtRuneIdx(l.findRuneIndexWithWidthToLeft(clampWidth(r.Start))),
+			l.byteOffsetAtRuneIdx(l.findRuneIndexWithWidthToLeft(clampWidth(r.End)))
+	}
+	return l.byteOffsetAtRuneIdx(r.Start), l.byteOffsetAtRuneIdx(r.End)
+}
+
+// byteOffsetAtRuneIdx is getByteOffsetAtRuneIdx clamped to the content.
+func (l LineBuffer) byteOffsetAtRuneIdx(runeIdx int) int {
+	if runeIdx >= l.numNoAnsiRunes {
+		return len(l.lineNoAnsi)
+	}
+	return int(l.getByteOffsetAtRuneIdx(max(0, runeIdx)))
+}
+
+// styleHighlightRanges styles the parts of the highlight ranges within a segment of the content spanning the byte
+// offsets [segmentStart, segmentEnd) of lineNoAnsi.
+func (l LineBuffer) styleHighlightRanges(segment string, segmentStart, segmentEnd int) string {
+	for _, r := range l.highlightRanges {
+		start, end := max(r.start, segmentStart), min(r.end, segmentEnd)
+		if start >= end {
+			continue
+		}
+		// pin the style to where the range starts in the segment, as the same text may appear earlier
+		segmentOffset := start - segmentStart
+		segment = highlightLine(segment, l.lineNoAnsi[start:end], r.style, segmentOffset, segmentOffset+1)
+	}
+	return segment
+}
@@ -23,6 +23,8 @@ type LineBuffer struct {
 	sparsity                        int      // interval for which to store cumulative cell width
 	sparseRuneIdxToNoAnsiByteOffset []uint32 // rune idx to byte offset of lineNoAnsi, stored every sparsity runes
 	sparseLineNoAnsiCumRuneWidths   []uint32 // cumulative terminal cell width, stored every sparsity runes
+
+	highlightRanges []styledByteRange // spans of lineNoAnsi to style, in the order they're drawn
 }
 
 // type assertion that LineBuffer implements LineBufferer
@@ -184,12 +186,21 @@ func (l LineBuffer) Take(
 	}
 
 	res := result.String()
+	var endByteOffset int
+	if leftRuneIdx < l.numNoAnsiRunes {
+		endByteOffset = int(l.getByteOffsetAtRuneIdx(leftRuneIdx))
+	} else {
+		endByteOffset = len(l.lineNoAnsi)
+	}
 
 	// reapply original styling
 	if len(l.ansiCodeIndexes) > 0 {
 		res = reapplyAnsi(l.line, res, int(startByteOffset), l.ansiCodeIndexes)
 	}
 
+	// style the highlight ranges over it
+	res = l.styleHighlightRanges(res, int(startByteOffset), endByteOffset)
+
 	// apply left/right line continuation indicators
 	if len(continuation) > 0 && (startRuneIdx > 0 || leftRuneIdx < l.numNoAnsiRunes) {
 		continuationRunes := []rune(continuation)
@@ -206,12 +217,6 @@ func (l LineBuffer) Take(
 	}
 
 	// highlight the desired string
-	var endByteOffset int
-	if leftRuneIdx < l.numNoAnsiRunes {
-		endByteOffset = int(l.getByteOffsetAtRuneIdx(leftRuneIdx))
-	} else {
-		endByteOffset = len(l.lineNoAnsi)
-	}
 	res = highlightString(
 		res,
 		toHighlight,
@@ -258,6 +263,11 @@ func (l LineBuffer) Matches(s string) bool {
 	return strings.Contains(l.lineNoAnsi, s)
 }
 
+// contentNoAnsi returns the content without ansi codes.
+func (l LineBuffer) contentNoAnsi() string {
+	return l.lineNoAnsi
+}
+
 // MatchesRegex returns true if the content matches the specified regular expression.
 func (l LineBuffer) MatchesRegex(r regexp.Regexp) bool {
 	return r.MatchString(l.lineNoAnsi)
@@ -5,7 +5,7 @@ import (
 	"testing"
 
 	"github.com/charmbracelet/lipgloss/v2"
-	"github.com/robinovitch61/bubbleo/viewport/internal"
+	"github.com/robinovitch61/bubbleo/internal/testutil"
 )
 
 func TestLineBuffer_Width(t *testing.T) {
@@ -880,7 +880,7 @@ func TestLineBuffer_Take(t *testing.T) {
 			}
 			for i := 0; i < tt.numTakes; i++ {
 				actual, actualWidth := lb.Take(startWidth, tt.width, tt.continuation, toHighlight, tt.highlightStyle)
-				internal.CmpStr(t, tt.expected[i], actual)
+				testutil.CmpStr(t, tt.expected[i], actual)
 				startWidth += actualWidth
 			}
 		})
@@ -1074,6 +1074,52 @@ func TestLineBuffer_WrappedLines(t *testing.T) {
 	}
 }
 
+func TestLineBuffer_WrappedLinesMatchMode(t *testing.T) {
+	tests := []struct {
+		name        string
+		s           string
+		width       int
+		toHighlight HighlightData
+		want        []string
+	}{
+		{
+			name:        "whole word wrapped between lines",
+			s:           "the id is hidden",
+			width:       5,
+			toHighlight: HighlightData{StringToHighlight: "id", MatchMode: MatchWholeWord},
+			want:        []string{"the " + greenBg.Render("i"), greenBg.Render("d") + " is ", "hidde", "n"},
+		},
+		{
+			name:        "whole word case-insensitive",
+			s:           "ID hidden",
+			width:       5,
+			toHighlight: HighlightData{StringToHighlight: "id", CaseMode: SmartCase, MatchMode: MatchWholeWord},
+			want:        []string{greenBg.Render("ID") + " hi", "dden"},
+		},
+		{
+			name:        "glob wrapped between lines",
+			s:           "error: disk full",
+			width:       5,
+			toHighlight: HighlightData{StringToHighlight: "d*f", MatchMode: MatchGlob},
+			want:        []string{"error", ": " + greenBg.Render("dis"), greenBg.Render("k f") + "ul", "l"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := New(tt.s).WrappedLines(tt.width, 0, tt.toHighlight, greenBg)
+			if len(got) != len(tt.want) {
+				t.Errorf("wrap() len = %d, want %d", len(got), len(tt.want))
+			}
+			for i := range got {
+				if i < len(tt.want) && got[i] != tt.want[i] {
+					t.Errorf("wrap() line %d got %q, expected %q", i, got[i], tt.want[i])
+				}
+			}
+		})
+	}
+}
+
 func TestLineBuffer_findRuneIndexWithWidthToLeft(t *testing.T) {
 	tests := []struct {
 		name            string
@@ -1249,3 +1295,132 @@ func TestLineBuffer_getByteOffsetAtRuneIdx(t *testing.T) {
 		})
 	}
 }
+
+func TestLineBuffer_TakeHighlightRanges(t *testing.T) {
+	tests := []struct {
+		name         string
+		s            string
+		ranges       []HighlightRange
+		widthToLeft  int
+		takeWidth    int
+		continuation string
+		toHighlight  HighlightData
+		expected     string
+	}{
+		{
+			name:      "cells",
+			s:         "INFO starting",
+			ranges:    []HighlightRange{{Start: 0, End: 4, Style: redBg}},
+			takeWidth: 13,
+			expected:  redBg.Render("INFO") + " starting",
+		},
+		{
+			name:      "runes",
+			s:         "日本語 text",
+			ranges:    []HighlightRange{{Start: 1, End: 3, Unit: Runes, Style: redBg}},
+			takeWidth: 11,
+			expected:  "日" + redBg.Render("本語") + " text",
+		},
+		{
+			name:      "cells of wide runes",
+			s:         "日本語 text",
+			ranges:    []HighlightRange{{Start: 2, End: 6, Style: redBg}},
+			takeWidth: 11,
+			expected:  "日" + redBg.Render("本語") + " text",
+		},
+		{
+			name:      "cell within wide rune",
+			s:         "日本語 text",
+			ranges:    []HighlightRange{{Start: 1, End: 4, Style: redBg}},
+			takeWidth: 11,
+			expected:  "日" + redBg.Render("本") + "語 text",
+		},
+		{
+			name:      "same text earlier",
+			s:         "ab ab",
+			ranges:    []HighlightRange{{Start: 3, End: 5, Style: redBg}},
+			takeWidth: 5,
+			expected:  "ab " + redBg.Render("ab"),
+		},
+		{
+			name:      "clamped",
+			s:         "abc",
+			ranges:    []HighlightRange{{Start: -2, End: 1, Style: redBg}, {Start: 2, End: 10, Style: blueBg}},
+			takeWidth: 3,
+			expected:  redBg.Render("a") + "b" + blueBg.Render("c"),
+		},
+		{
+			name:      "empty range",
+			s:         "abc",
+			ranges:    []HighlightRange{{Start: 2, End: 1, Style: redBg}},
+			takeWidth: 3,
+			expected:  "abc",
+		},
+		{
+			name:        "partly taken",
+			s:           "INFO starting",
+			ranges:      []HighlightRange{{Start: 0, End: 4, Style: redBg}},
+			widthToLeft: 2,
+			takeWidth:   5,
+			expected:    redBg.Render("FO") + " st",
+		},
+		{
+			name:      "overlapping",
+			s:         "abcdef",
+			ranges:    []HighlightRange{{Start: 0, End: 4, Style: redBg}, {Start: 2, End: 6, Style: blueBg}},
+			takeWidth: 6,
+			expected:  redBg.Render("ab") + blueBg.Render("cdef"),
+		},
+		{
+			name:      "over ansi",
+			s:         "\x1b[38;2;0;255;0mabc\x1b[m def",
+			ranges:    []HighlightRange{{Start: 1, End: 5, Style: redBg}},
+			takeWidth: 7,
+			expected:  "\x1b[38;2;0;255;0ma\x1b[m" + redBg.Render("bc d") + "ef",
+		},
+		{
+			name:         "continuation",
+			s:            "INFO starting",
+			ranges:       []HighlightRange{{Start: 0, End: 4, Style: redBg}},
+			takeWidth:    6,
+			continuation: "..",
+			expected:     redBg.Render("INFO") + "..",
+		},
+		{
+			name:        "search highlight drawn over ranges",
+			s:           "INFO starting",
+			ranges:      []HighlightRange{{Start: 0, End: 8, Style: redBg}},
+			takeWidth:   13,
+			toHighlight: HighlightData{StringToHighlight: "sta"},
+			expected:    redBg.Render("INFO ") + blueBg.Render("sta") + "rting",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lb := New(tt.s).WithHighlightRanges(tt.ranges...)
+			actual, _ := lb.Take(tt.widthToLeft, tt.takeWidth, tt.continuation, tt.toHighlight, blueBg)
+			testutil.CmpStr(t, tt.expected, actual)
+		})
+	}
+}
+
+func TestLineBuffer_WrappedLinesHighlightRanges(t *testing.T) {
+	lb := New("2024-01-01 ERROR disk full").WithHighlightRanges(
+		HighlightRange{Start: 6, End: 12, Style: redBg},
+		HighlightRange{Start: 11, End: 16, Style: blueBg},
+	)
+	got := lb.WrappedLines(8, 0, HighlightData{StringToHighlight: "disk"}, greenBg)
+	want := []string{
+		"2024-0" + redBg.Render("1-"),
+		redBg.Render("01 ") + blueBg.Render("ERROR"),
+		" " + greenBg.Render("disk") + " fu",
+		"ll",
+	}
+	if len(got) != len(want) {
+		t.Fatalf("wrap() len = %d, want %d", len(got), len(want))
+	}
+	for i := range got {
+		testutil.CmpStr(t, want[i], got[i])
+	}
+}
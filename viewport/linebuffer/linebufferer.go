@@ -36,3 +36,27 @@ type LineBufferer interface {
 	// Repr returns a representation of the Linebufferer as a string for debugging
 	Repr() string
 }
+
+// noAnsiContenter is implemented by line buffers that already hold their content without ansi codes
+type noAnsiContenter interface {
+	contentNoAnsi() string
+}
+
+// noAnsiContent returns the content of lb without ansi codes
+func noAnsiContent(lb LineBufferer) string {
+	if c, ok := lb.(noAnsiContenter); ok {
+		return c.contentNoAnsi()
+	}
+	return stripAnsi(lb.Content())
+}
+
+// MatchesHighlight returns true if the content of lb contains what toHighlight highlights, honoring its case mode and
+// ignoring ansi styling
+func MatchesHighlight(lb LineBufferer, toHighlight HighlightData) bool {
+	return toHighlight.matches(noAnsiContent(lb))
+}
+
+// FuzzyMatchContent fuzzy matches pattern against the content of lb, ignoring ansi styling. See FuzzyMatch
+func FuzzyMatchContent(lb LineBufferer, pattern string, caseMode CaseMode) (FuzzyResult, bool) {
+	return FuzzyMatch(pattern, noAnsiContent(lb), caseMode)
+}
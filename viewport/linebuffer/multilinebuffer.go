@@ -101,13 +101,13 @@ func (m MultiLineBuffer) Take(
 	}
 
 	// get content before our start position for highlight context
-	nBytesLeftContext := len(toHighlight.StringToHighlight) * 2
+	nBytesLeftContext := m.highlightContextBytes(toHighlight)
 	leftContext := getBytesLeftOfWidth(nBytesLeftContext, m.buffers, firstBufferIdx, startWidthFirstBuffer)
 
 	// take from first buffer
 	res, takenWidth := m.buffers[firstBufferIdx].Take(startWidthFirstBuffer, takeWidth, "", HighlightData{}, lipgloss.NewStyle())
 	remainingTotalWidth := takeWidth - takenWidth
-	remainingBufferWidth := m.buffers[firstBufferIdx].Width() - takenWidth
+	remainingBufferWidth := m.buffers[firstBufferIdx].Width() - startWidthFirstBuffer - takenWidth
 
 	// if we have more width to take and more buffers available, continue
 	currentBufferIdx := firstBufferIdx + 1
@@ -124,7 +124,7 @@ func (m MultiLineBuffer) Take(
 
 	// get content after our result for highlight context
 	currentBufferIdx--
-	nBytesRightContext := len(toHighlight.StringToHighlight) * 2
+	nBytesRightContext := m.highlightContextBytes(toHighlight)
 	rightContext := getBytesRightOfWidth(nBytesRightContext, m.buffers, currentBufferIdx, remainingBufferWidth)
 
 	// apply continuation indicators if needed
@@ -158,6 +158,16 @@ func (m MultiLineBuffer) Take(
 	return res, takeWidth - remainingTotalWidth
 }
 
+// highlightContextBytes returns the number of bytes of content to consider on each side of a taken segment when
+// highlighting it, so matches overflowing the segment are found
+func (m MultiLineBuffer) highlightContextBytes(toHighlight HighlightData) int {
+	totalBytes := 0
+	for i := range m.buffers {
+		totalBytes += len(m.buffers[i].lineNoAnsi)
+	}
+	return min(toHighlight.maxMatchBytes(), totalBytes) * 2
+}
+
 // WrappedLines returns the content broken into lines that fit within the specified width.
 func (m MultiLineBuffer) WrappedLines(
 	width int,
@@ -195,6 +205,11 @@ func (m MultiLineBuffer) Matches(s string) bool {
 	return strings.Contains(m.concatenatedLineNoAnsi(), s)
 }
 
+// contentNoAnsi returns the concatenated content without ansi codes.
+func (m MultiLineBuffer) contentNoAnsi() string {
+	return m.concatenatedLineNoAnsi()
+}
+
 // MatchesRegex returns true if the content matches the specified regular expression.
 func (m MultiLineBuffer) MatchesRegex(r regexp.Regexp) bool {
 	return r.MatchString(m.concatenatedLineNoAnsi())
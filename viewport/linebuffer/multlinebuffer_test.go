@@ -1,6 +1,7 @@
 package linebuffer
 
 import (
+	"regexp"
 	"testing"
 
 	"github.com/charmbracelet/lipgloss/v2"
@@ -439,6 +440,37 @@ func TestMultiLineBuffer_Take(t *testing.T) {
 	}
 }
 
+func TestMultiLineBuffer_TakeRightContextAfterWidthToLeft(t *testing.T) {
+	// the highlight context to the right of a segment taken from within the first buffer starts after the segment, not
+	// widthToLeft cells before its end
+	mlb := NewMulti(New("abcdef"), New("gh"))
+	tests := []struct {
+		name        string
+		toHighlight string
+		expected    string
+	}{
+		{
+			name:        "match overflowing into the right context",
+			toHighlight: "de",
+			expected:    "c" + redFg.Render("d"),
+		},
+		{
+			name:        "no match with the segment repeated as context",
+			toHighlight: "dc",
+			expected:    "cd",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			actual, _ := mlb.Take(2, 2, "", HighlightData{StringToHighlight: tt.toHighlight}, redFg)
+			if actual != tt.expected {
+				t.Errorf("expected %q, got %q", tt.expected, actual)
+			}
+		})
+	}
+}
+
 func TestMultiLineBuffer_WrappedLines(t *testing.T) {
 	tests := []struct {
 		name            string
@@ -673,3 +705,243 @@ func TestMultiLineBuffer_WrappedLines(t *testing.T) {
 		})
 	}
 }
+
+func TestMultiLineBuffer_TakeCaseInsensitive(t *testing.T) {
+	tests := []struct {
+		name        string
+		key         string
+		widthToLeft int
+		takeWidth   int
+		toHighlight HighlightData
+		expected    string
+	}{
+		{
+			name:        "hello world across buffer boundaries",
+			key:         "hello world",
+			widthToLeft: 0,
+			takeWidth:   11,
+			toHighlight: HighlightData{StringToHighlight: "LO WO", CaseMode: CaseInsensitive},
+			expected:    "hel" + greenBg.Render("lo wo") + "rld",
+		},
+		{
+			name:        "hello world overflowing left",
+			key:         "hello world",
+			widthToLeft: 4,
+			takeWidth:   7,
+			toHighlight: HighlightData{StringToHighlight: "LO WO", CaseMode: CaseInsensitive},
+			expected:    greenBg.Render("o wo") + "rld",
+		},
+		{
+			name:        "hello world overflowing right",
+			key:         "hello world",
+			widthToLeft: 0,
+			takeWidth:   5,
+			toHighlight: HighlightData{StringToHighlight: "LO WO", CaseMode: SmartCase},
+			expected:    "hello",
+		},
+		{
+			name:        "ansi smart case",
+			key:         "ansi",
+			widthToLeft: 0,
+			takeWidth:   11,
+			toHighlight: HighlightData{StringToHighlight: "o w", CaseMode: SmartCase},
+			expected:    redBg.Render("hell") + greenBg.Render("o w") + blueBg.Render("orld"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, eq := range getEquivalentLineBuffers()[tt.key] {
+				actual, _ := eq.Take(tt.widthToLeft, tt.takeWidth, "", tt.toHighlight, greenBg)
+				if actual != tt.expected {
+					t.Errorf("for %s, expected %q, got %q", eq.Repr(), tt.expected, actual)
+				}
+			}
+		})
+	}
+}
+
+func TestMultiLineBuffer_TakeMatchMode(t *testing.T) {
+	tests := []struct {
+		name        string
+		key         string
+		widthToLeft int
+		takeWidth   int
+		toHighlight HighlightData
+		expected    string
+	}{
+		{
+			name:        "whole word",
+			key:         "hello world",
+			widthToLeft: 0,
+			takeWidth:   11,
+			toHighlight: HighlightData{StringToHighlight: "world", MatchMode: MatchWholeWord},
+			expected:    "hello " + greenBg.Render("world"),
+		},
+		{
+			name:        "whole word overflowing left",
+			key:         "hello world",
+			widthToLeft: 8,
+			takeWidth:   3,
+			toHighlight: HighlightData{StringToHighlight: "world", MatchMode: MatchWholeWord},
+			expected:    greenBg.Render("rld"),
+		},
+		{
+			name:        "not a whole word",
+			key:         "hello world",
+			widthToLeft: 0,
+			takeWidth:   11,
+			toHighlight: HighlightData{StringToHighlight: "wor", MatchMode: MatchWholeWord},
+			expected:    "hello world",
+		},
+		{
+			name:        "glob across buffer boundaries",
+			key:         "hello world",
+			widthToLeft: 0,
+			takeWidth:   11,
+			toHighlight: HighlightData{StringToHighlight: "l?o*r", MatchMode: MatchGlob},
+			expected:    "he" + greenBg.Render("llo wor") + "ld",
+		},
+		{
+			name:        "glob overflowing both sides",
+			key:         "hello world",
+			widthToLeft: 4,
+			takeWidth:   3,
+			toHighlight: HighlightData{StringToHighlight: "e*l", MatchMode: MatchGlob},
+			expected:    greenBg.Render("o w"),
+		},
+		{
+			name:        "glob with ansi",
+			key:         "ansi",
+			widthToLeft: 0,
+			takeWidth:   11,
+			toHighlight: HighlightData{StringToHighlight: "o?w", MatchMode: MatchGlob},
+			expected:    redBg.Render("hell") + greenBg.Render("o w") + blueBg.Render("orld"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, eq := range getEquivalentLineBuffers()[tt.key] {
+				actual, _ := eq.Take(tt.widthToLeft, tt.takeWidth, "", tt.toHighlight, greenBg)
+				if actual != tt.expected {
+					t.Errorf("for %s, expected %q, got %q", eq.Repr(), tt.expected, actual)
+				}
+			}
+		})
+	}
+}
+
+func TestMultiLineBuffer_MatchesHighlight(t *testing.T) {
+	tests := []struct {
+		name        string
+		key         string
+		toHighlight HighlightData
+		expected    bool
+	}{
+		{
+			name:        "case-sensitive",
+			key:         "hello world",
+			toHighlight: HighlightData{StringToHighlight: "LO WO"},
+			expected:    false,
+		},
+		{
+			name:        "case-insensitive",
+			key:         "hello world",
+			toHighlight: HighlightData{StringToHighlight: "LO WO", CaseMode: CaseInsensitive},
+			expected:    true,
+		},
+		{
+			name:        "smart case uppercase",
+			key:         "ansi",
+			toHighlight: HighlightData{StringToHighlight: "World", CaseMode: SmartCase},
+			expected:    false,
+		},
+		{
+			name:        "regex case-insensitive",
+			key:         "ansi",
+			toHighlight: HighlightData{RegexPatternToHighlight: regexp.MustCompile("O W"), IsRegex: true, CaseMode: CaseInsensitive},
+			expected:    true,
+		},
+		{
+			name:        "whole word",
+			key:         "hello world",
+			toHighlight: HighlightData{StringToHighlight: "world", MatchMode: MatchWholeWord},
+			expected:    true,
+		},
+		{
+			name:        "not a whole word",
+			key:         "hello world",
+			toHighlight: HighlightData{StringToHighlight: "llo", MatchMode: MatchWholeWord},
+			expected:    false,
+		},
+		{
+			name:        "glob",
+			key:         "ansi",
+			toHighlight: HighlightData{StringToHighlight: "h*o?w", MatchMode: MatchGlob},
+			expected:    true,
+		},
+		{
+			name:        "glob no match",
+			key:         "ansi",
+			toHighlight: HighlightData{StringToHighlight: "w*h", MatchMode: MatchGlob},
+			expected:    false,
+		},
+		{
+			name:        "nothing to highlight",
+			key:         "hello world",
+			toHighlight: HighlightData{CaseMode: CaseInsensitive},
+			expected:    false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, eq := range getEquivalentLineBuffers()[tt.key] {
+				if actual := MatchesHighlight(eq, tt.toHighlight); actual != tt.expected {
+					t.Errorf("for %s, expected %v, got %v", eq.Repr(), tt.expected, actual)
+				}
+			}
+		})
+	}
+}
+
+func TestMultiLineBuffer_TakeHighlightRanges(t *testing.T) {
+	mlb := NewMulti(
+		New("key: ").WithHighlightRanges(HighlightRange{Start: 0, End: 3, Style: redBg}),
+		New("value").WithHighlightRanges(HighlightRange{Start: 0, End: 5, Style: blueBg}),
+	)
+	tests := []struct {
+		name        string
+		widthToLeft int
+		takeWidth   int
+		toHighlight HighlightData
+		expected    string
+	}{
+		{
+			name:      "all",
+			takeWidth: 10,
+			expected:  redBg.Render("key") + ": " + blueBg.Render("value"),
+		},
+		{
+			name:        "across buffers",
+			widthToLeft: 2,
+			takeWidth:   5,
+			expected:    redBg.Render("y") + ": " + blueBg.Render("va"),
+		},
+		{
+			name:        "search highlight across buffers",
+			takeWidth:   10,
+			toHighlight: HighlightData{StringToHighlight: " v"},
+			expected:    redBg.Render("key") + ":" + greenBg.Render(" v") + blueBg.Render("alue"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			actual, _ := mlb.Take(tt.widthToLeft, tt.takeWidth, "", tt.toHighlight, greenBg)
+			if actual != tt.expected {
+				t.Errorf("for %s, expected %q, got %q", mlb.Repr(), tt.expected, actual)
+			}
+		})
+	}
+}
@@ -180,7 +180,7 @@ func highlightLine(line, highlight string, highlightStyle lipgloss.Style, start,
 //
 // Parameters:
 //   - styledSegment: the text segment to highlight, which may contain ANSI codes
-//   - toHighlight: the substring to search for and highlight
+//   - toHighlight: the substring or regex to search for and highlight, and how to compare case
 //   - highlightStyle: the style to apply to matched substrings
 //   - plainLine: the complete line without any ANSI codes, used for overflow detection
 //   - segmentStart: byte offset where this segment starts in plainLine
@@ -197,7 +197,11 @@ func highlightString(
 ) string {
 	// regex case, highlight matches in the specific segment, without consideration for overflow
 	if toHighlight.IsRegex {
-		matches := toHighlight.RegexPatternToHighlight.FindAllStringIndex(plainLine[segmentStart:segmentEnd], -1)
+		regex := toHighlight.regex()
+		if regex == nil {
+			return styledSegment
+		}
+		matches := regex.FindAllStringIndex(plainLine[segmentStart:segmentEnd], -1)
 		if matches == nil {
 			return styledSegment // no matches, return as is
 		}
@@ -212,6 +216,9 @@ func highlightString(
 	}
 	// non-regex highlighting
 	if toHighlight.StringToHighlight != "" && len(highlightStyle.String()) > 0 {
+		if !toHighlight.exact() {
+			return highlightStringRanges(styledSegment, toHighlight, highlightStyle, plainLine, segmentStart, segmentEnd)
+		}
 		styledSegment = highlightLine(styledSegment, toHighlight.StringToHighlight, highlightStyle, 0, len(styledSegment))
 
 		if left, endIdx := overflowsLeft(plainLine, segmentStart, toHighlight.StringToHighlight); left {
@@ -228,6 +235,31 @@ func highlightString(
 	return styledSegment
 }
 
+// highlightStringRanges is highlightString for strings matched ignoring case, as whole words, or as globs. As a match
+// can differ from the string to highlight, matches are found in plainLine and the part of each within the segment is
+// highlighted as it appears in the segment
+func highlightStringRanges(
+	styledSegment string,
+	toHighlight HighlightData,
+	highlightStyle lipgloss.Style,
+	plainLine string,
+	segmentStart int,
+	segmentEnd int,
+) string {
+	// only matches that can overlap the segment are needed
+	searchStart := max(0, segmentStart-toHighlight.maxMatchBytes())
+	for _, match := range toHighlight.findAll(plainLine, searchStart, segmentEnd, -1) {
+		start, end := max(match[0], segmentStart), min(match[1], segmentEnd)
+		if start >= end {
+			continue
+		}
+		// pin the highlight to where the match starts in the segment
+		segmentOffset := start - segmentStart
+		styledSegment = highlightLine(styledSegment, plainLine[start:end], highlightStyle, segmentOffset, segmentOffset+1)
+	}
+	return styledSegment
+}
+
 func stripAnsi(input string) string {
 	ranges := findAnsiByteRanges(input)
 	if len(ranges) == 0 {
@@ -6,7 +6,7 @@ import (
 	"testing"
 
 	"github.com/charmbracelet/lipgloss/v2"
-	"github.com/robinovitch61/bubbleo/viewport/internal"
+	"github.com/robinovitch61/bubbleo/internal/testutil"
 )
 
 func TestLineBuffer_reapplyAnsi(t *testing.T) {
@@ -250,7 +250,7 @@ func TestLineBuffer_reapplyAnsi(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			ansiCodeIndexes := toUInt32(ansiRegex.FindAllStringIndex(tt.original, -1))
 			actual := reapplyAnsi(tt.original, tt.truncated, tt.truncByteOffset, ansiCodeIndexes)
-			internal.CmpStr(t, tt.expected, actual)
+			testutil.CmpStr(t, tt.expected, actual)
 		})
 	}
 }
@@ -362,7 +362,7 @@ func TestLineBuffer_highlightLine(t *testing.T) {
 			if tt.start == 0 && tt.end == 0 {
 				tt.end = len(tt.line)
 			}
-			internal.CmpStr(t, tt.expected, highlightLine(tt.line, tt.highlight, tt.highlightStyle, tt.start, tt.end))
+			testutil.CmpStr(t, tt.expected, highlightLine(tt.line, tt.highlight, tt.highlightStyle, tt.start, tt.end))
 		})
 	}
 }
@@ -482,7 +482,7 @@ func TestHighlightString(t *testing.T) {
 				tt.segmentStart,
 				tt.segmentEnd,
 			)
-			internal.CmpStr(t, tt.expected, result)
+			testutil.CmpStr(t, tt.expected, result)
 		})
 	}
 }
@@ -613,7 +613,40 @@ func TestHighlightStringRegex(t *testing.T) {
 				tt.segmentStart,
 				tt.segmentEnd,
 			)
-			internal.CmpStr(t, tt.expected, result)
+			testutil.CmpStr(t, tt.expected, result)
+		})
+	}
+}
+
+func TestDerivedRegex(t *testing.T) {
+	for _, tt := range []struct {
+		name      string
+		pattern   string
+		expectNil bool
+	}{
+		{
+			name:    "compiles",
+			pattern: "(?i)foo",
+		},
+		{
+			name:      "fails to compile",
+			pattern:   "(?i)foo(",
+			expectNil: true,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			regex := derivedRegex(tt.pattern)
+			if (regex == nil) != tt.expectNil {
+				t.Fatalf("expected nil %v, got %v", tt.expectNil, regex)
+			}
+			// another pattern in between, e.g. from another viewport, doesn't evict it
+			derivedRegex("(?i)bar")
+			if _, ok := derivedRegexCache.regexes[tt.pattern]; !ok {
+				t.Errorf("expected %q to be cached", tt.pattern)
+			}
+			if again := derivedRegex(tt.pattern); again != regex {
+				t.Errorf("expected the cached regex %v, got %v", regex, again)
+			}
 		})
 	}
 }
@@ -1479,3 +1512,349 @@ func assertPanic(t *testing.T, f func()) {
 	}()
 	f()
 }
+
+func TestHighlightStringCaseMode(t *testing.T) {
+	for _, tt := range []struct {
+		name          string
+		styledSegment string // segment with ANSI codes
+		toHighlight   HighlightData
+		plainLine     string // full line without ANSI
+		segmentStart  int
+		segmentEnd    int
+		expected      string
+	}{
+		{
+			name:          "case-sensitive",
+			styledSegment: "Hello hello",
+			toHighlight:   HighlightData{StringToHighlight: "hello"},
+			plainLine:     "Hello hello",
+			segmentStart:  0,
+			segmentEnd:    11,
+			expected:      "Hello " + redFg.Render("hello"),
+		},
+		{
+			name:          "case-insensitive",
+			styledSegment: "Hello hello",
+			toHighlight:   HighlightData{StringToHighlight: "hELLO", CaseMode: CaseInsensitive},
+			plainLine:     "Hello hello",
+			segmentStart:  0,
+			segmentEnd:    11,
+			expected:      redFg.Render("Hello") + " " + redFg.Render("hello"),
+		},
+		{
+			name:          "case-insensitive with existing style",
+			styledSegment: blueBg.Render("Hello") + " world",
+			toHighlight:   HighlightData{StringToHighlight: "lo w", CaseMode: CaseInsensitive},
+			plainLine:     "Hello world",
+			segmentStart:  0,
+			segmentEnd:    11,
+			expected:      blueBg.Render("Hel") + redFg.Render("lo w") + "orld",
+		},
+		{
+			name:          "case-insensitive left overflow",
+			styledSegment: "LLO world",
+			toHighlight:   HighlightData{StringToHighlight: "hello", CaseMode: CaseInsensitive},
+			plainLine:     "HELLO world",
+			segmentStart:  2,
+			segmentEnd:    11,
+			expected:      redFg.Render("LLO") + " world",
+		},
+		{
+			name:          "case-insensitive right overflow",
+			styledSegment: "hello WO",
+			toHighlight:   HighlightData{StringToHighlight: "world", CaseMode: CaseInsensitive},
+			plainLine:     "hello WORLD",
+			segmentStart:  0,
+			segmentEnd:    8,
+			expected:      "hello " + redFg.Render("WO"),
+		},
+		{
+			name:          "case-insensitive match longer than string to highlight",
+			styledSegment: "5 K away",
+			toHighlight:   HighlightData{StringToHighlight: "k a", CaseMode: CaseInsensitive},
+			plainLine:     "5 K away",
+			segmentStart:  0,
+			segmentEnd:    len("5 K away"),
+			expected:      "5 " + redFg.Render("K a") + "way",
+		},
+		{
+			name:          "case-insensitive match shorter than string to highlight",
+			styledSegment: "großes haus",
+			toHighlight:   HighlightData{StringToHighlight: "GROẞES", CaseMode: CaseInsensitive},
+			plainLine:     "großes haus",
+			segmentStart:  0,
+			segmentEnd:    len("großes haus"),
+			expected:      redFg.Render("großes") + " haus",
+		},
+		{
+			name:          "smart case lowercase ignores case",
+			styledSegment: "Hello hello",
+			toHighlight:   HighlightData{StringToHighlight: "hello", CaseMode: SmartCase},
+			plainLine:     "Hello hello",
+			segmentStart:  0,
+			segmentEnd:    11,
+			expected:      redFg.Render("Hello") + " " + redFg.Render("hello"),
+		},
+		{
+			name:          "smart case uppercase is case-sensitive",
+			styledSegment: "Hello hello",
+			toHighlight:   HighlightData{StringToHighlight: "Hello", CaseMode: SmartCase},
+			plainLine:     "Hello hello",
+			segmentStart:  0,
+			segmentEnd:    11,
+			expected:      redFg.Render("Hello") + " hello",
+		},
+		{
+			name:          "regex case-insensitive",
+			styledSegment: "Hello hello",
+			toHighlight:   HighlightData{RegexPatternToHighlight: regexp.MustCompile("h.llo"), IsRegex: true, CaseMode: CaseInsensitive},
+			plainLine:     "Hello hello",
+			segmentStart:  0,
+			segmentEnd:    11,
+			expected:      redFg.Render("Hello") + " " + redFg.Render("hello"),
+		},
+		{
+			name:          "regex smart case ignores escapes",
+			styledSegment: "Hello hello",
+			toHighlight:   HighlightData{RegexPatternToHighlight: regexp.MustCompile(`h\Sllo`), IsRegex: true, CaseMode: SmartCase},
+			plainLine:     "Hello hello",
+			segmentStart:  0,
+			segmentEnd:    11,
+			expected:      redFg.Render("Hello") + " " + redFg.Render("hello"),
+		},
+		{
+			name:          "regex smart case uppercase is case-sensitive",
+			styledSegment: "Hello hello",
+			toHighlight:   HighlightData{RegexPatternToHighlight: regexp.MustCompile("H.llo"), IsRegex: true, CaseMode: SmartCase},
+			plainLine:     "Hello hello",
+			segmentStart:  0,
+			segmentEnd:    11,
+			expected:      redFg.Render("Hello") + " hello",
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			result := highlightString(
+				tt.styledSegment,
+				tt.toHighlight,
+				redFg,
+				tt.plainLine,
+				tt.segmentStart,
+				tt.segmentEnd,
+			)
+			testutil.CmpStr(t, tt.expected, result)
+		})
+	}
+}
+
+func TestIndexFold(t *testing.T) {
+	for _, tt := range []struct {
+		name          string
+		s             string
+		substr        string
+		from          int
+		expectedStart int
+		expectedEnd   int
+	}{
+		{
+			name:          "empty substr",
+			s:             "hello",
+			substr:        "",
+			expectedStart: -1,
+			expectedEnd:   -1,
+		},
+		{
+			name:          "ascii",
+			s:             "say HeLLo",
+			substr:        "hello",
+			expectedStart: 4,
+			expectedEnd:   9,
+		},
+		{
+			name:          "from skips earlier matches",
+			s:             "ab AB ab",
+			substr:        "ab",
+			from:          1,
+			expectedStart: 3,
+			expectedEnd:   5,
+		},
+		{
+			name:          "no match",
+			s:             "hello",
+			substr:        "world",
+			expectedStart: -1,
+			expectedEnd:   -1,
+		},
+		{
+			name:          "kelvin sign is longer than k",
+			s:             "1K",
+			substr:        "k",
+			expectedStart: 1,
+			expectedEnd:   4,
+		},
+		{
+			name:          "non-ascii",
+			s:             "ÉCOLE école",
+			substr:        "école",
+			expectedStart: 0,
+			expectedEnd:   len("ÉCOLE"),
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			start, end := indexFold(tt.s, tt.substr, tt.from)
+			if start != tt.expectedStart || end != tt.expectedEnd {
+				t.Errorf("expected (%d, %d), got (%d, %d)", tt.expectedStart, tt.expectedEnd, start, end)
+			}
+		})
+	}
+}
+
+func TestHighlightStringMatchMode(t *testing.T) {
+	for _, tt := range []struct {
+		name          string
+		styledSegment string // segment with ANSI codes
+		toHighlight   HighlightData
+		plainLine     string // full line without ANSI
+		segmentStart  int
+		segmentEnd    int
+		expected      string
+	}{
+		{
+			name:          "substring",
+			styledSegment: "id width hidden",
+			toHighlight:   HighlightData{StringToHighlight: "id"},
+			plainLine:     "id width hidden",
+			segmentStart:  0,
+			segmentEnd:    15,
+			expected:      redFg.Render("id") + " w" + redFg.Render("id") + "th h" + redFg.Render("id") + "den",
+		},
+		{
+			name:          "whole word",
+			styledSegment: "id width (id) hidden_id",
+			toHighlight:   HighlightData{StringToHighlight: "id", MatchMode: MatchWholeWord},
+			plainLine:     "id width (id) hidden_id",
+			segmentStart:  0,
+			segmentEnd:    23,
+			expected:      redFg.Render("id") + " width (" + redFg.Render("id") + ") hidden_id",
+		},
+		{
+			name:          "whole word after rejected overlapping match",
+			styledSegment: "aab ab",
+			toHighlight:   HighlightData{StringToHighlight: "ab", MatchMode: MatchWholeWord},
+			plainLine:     "aab ab",
+			segmentStart:  0,
+			segmentEnd:    6,
+			expected:      "aab " + redFg.Render("ab"),
+		},
+		{
+			name:          "whole word non-ascii letters",
+			styledSegment: "éid id",
+			toHighlight:   HighlightData{StringToHighlight: "id", MatchMode: MatchWholeWord},
+			plainLine:     "éid id",
+			segmentStart:  0,
+			segmentEnd:    len("éid id"),
+			expected:      "éid " + redFg.Render("id"),
+		},
+		{
+			name:          "whole word case-insensitive",
+			styledSegment: "ID width",
+			toHighlight:   HighlightData{StringToHighlight: "id", CaseMode: CaseInsensitive, MatchMode: MatchWholeWord},
+			plainLine:     "ID width",
+			segmentStart:  0,
+			segmentEnd:    8,
+			expected:      redFg.Render("ID") + " width",
+		},
+		{
+			name:          "whole word left overflow",
+			styledSegment: "d width",
+			toHighlight:   HighlightData{StringToHighlight: "id", MatchMode: MatchWholeWord},
+			plainLine:     "id width",
+			segmentStart:  1,
+			segmentEnd:    8,
+			expected:      redFg.Render("d") + " width",
+		},
+		{
+			name:          "whole word not a word outside segment",
+			styledSegment: "id",
+			toHighlight:   HighlightData{StringToHighlight: "id", MatchMode: MatchWholeWord},
+			plainLine:     "hidden",
+			segmentStart:  1,
+			segmentEnd:    3,
+			expected:      "id",
+		},
+		{
+			name:          "glob star",
+			styledSegment: "error: disk full",
+			toHighlight:   HighlightData{StringToHighlight: "d*k", MatchMode: MatchGlob},
+			plainLine:     "error: disk full",
+			segmentStart:  0,
+			segmentEnd:    16,
+			expected:      "error: " + redFg.Render("disk") + " full",
+		},
+		{
+			name:          "glob question mark",
+			styledSegment: "cat cut coat",
+			toHighlight:   HighlightData{StringToHighlight: "c?t", MatchMode: MatchGlob},
+			plainLine:     "cat cut coat",
+			segmentStart:  0,
+			segmentEnd:    12,
+			expected:      redFg.Render("cat") + " " + redFg.Render("cut") + " coat",
+		},
+		{
+			name:          "glob escaped wildcard",
+			styledSegment: "a*b acb",
+			toHighlight:   HighlightData{StringToHighlight: `a\*b`, MatchMode: MatchGlob},
+			plainLine:     "a*b acb",
+			segmentStart:  0,
+			segmentEnd:    7,
+			expected:      redFg.Render("a*b") + " acb",
+		},
+		{
+			name:          "glob regex characters are literal",
+			styledSegment: "a.c abc",
+			toHighlight:   HighlightData{StringToHighlight: "a.c", MatchMode: MatchGlob},
+			plainLine:     "a.c abc",
+			segmentStart:  0,
+			segmentEnd:    7,
+			expected:      redFg.Render("a.c") + " abc",
+		},
+		{
+			name:          "glob star overflowing both sides",
+			styledSegment: "isk fu",
+			toHighlight:   HighlightData{StringToHighlight: "d*l", MatchMode: MatchGlob},
+			plainLine:     "error: disk full",
+			segmentStart:  8,
+			segmentEnd:    14,
+			expected:      redFg.Render("isk fu"),
+		},
+		{
+			name:          "glob case-insensitive",
+			styledSegment: "Disk",
+			toHighlight:   HighlightData{StringToHighlight: "d?sk", CaseMode: CaseInsensitive, MatchMode: MatchGlob},
+			plainLine:     "Disk",
+			segmentStart:  0,
+			segmentEnd:    4,
+			expected:      redFg.Render("Disk"),
+		},
+		{
+			name:          "match mode doesn't apply to regex",
+			styledSegment: "hidden",
+			toHighlight:   HighlightData{RegexPatternToHighlight: regexp.MustCompile("id"), IsRegex: true, MatchMode: MatchWholeWord},
+			plainLine:     "hidden",
+			segmentStart:  0,
+			segmentEnd:    6,
+			expected:      "h" + redFg.Render("id") + "den",
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			result := highlightString(
+				tt.styledSegment,
+				tt.toHighlight,
+				redFg,
+				tt.plainLine,
+				tt.segmentStart,
+				tt.segmentEnd,
+			)
+			testutil.CmpStr(t, tt.expected, result)
+		})
+	}
+}
@@ -1,6 +1,10 @@
 package viewport
 
 import (
+	"strings"
+	"sync/atomic"
+	"time"
+
 	"github.com/charmbracelet/bubbles/v2/key"
 	tea "github.com/charmbracelet/bubbletea/v2"
 	"github.com/robinovitch61/bubbleo/viewport/internal"
@@ -14,20 +18,78 @@ type NavigationManager struct {
 	// SelectionEnabled is true if the viewport allows individual line selection
 	SelectionEnabled bool
 
+	// Focused is true when the viewport handles key input
+	Focused bool
+
 	// TopSticky is true when selection should remain at the top until user manually scrolls down
 	TopSticky bool
 
 	// BottomSticky is true when selection should remain at the bottom until user manually scrolls up
 	BottomSticky bool
+
+	// CountPrefixEnabled is true when digits typed before a key are parsed as a count, e.g. `42G` or `50%`
+	CountPrefixEnabled bool
+
+	// PendingTimeout is how long a partially typed count stays pending without further input before the message of
+	// CountTimeoutCmd clears it. Zero means no timeout
+	PendingTimeout time.Duration
+
+	// SequenceTimeout is how long to wait for the next key of a key sequence, e.g. the second `g` of `g g`.
+	// When the keys typed so far are both a complete binding and the start of a longer sequence, the complete
+	// binding fires after this timeout
+	SequenceTimeout time.Duration
+
+	// id distinguishes this NavigationManager's sequence and count timeouts from those of other viewports
+	id int64
+
+	// pendingMarkAction is the mark action waiting on the next key press for the mark name, or ActionNone
+	pendingMarkAction NavigationAction
+
+	// pendingCount is the count typed so far, or 0 if none
+	pendingCount int
+
+	// pendingCountTag increments each time a digit is typed so stale count timeouts are ignored
+	pendingCountTag int
+
+	// pendingKeys are the keys typed so far of an incomplete key sequence
+	pendingKeys []string
+
+	// pendingKeysAction is the action of the binding that pendingKeys completes, if any, fired on timeout
+	pendingKeysAction NavigationAction
+
+	// deferredKeyMsg is a key message that broke a key sequence and has yet to be processed
+	deferredKeyMsg tea.KeyMsg
+
+	// pendingKeysTag increments each time pendingKeys changes so stale timeouts are ignored
+	pendingKeysTag int
+
+	// now returns the current time, replaceable in tests
+	now func() time.Time
 }
 
+const (
+	// defaultPendingTimeout is how long a partially typed count stays pending by default
+	defaultPendingTimeout = 2 * time.Second
+
+	// defaultSequenceTimeout is how long to wait for the next key of a key sequence by default
+	defaultSequenceTimeout = time.Second
+)
+
+var lastNavigationManagerID int64
+
 // NewNavigationManager creates a new NavigationManager with the specified key mappings.
 func NewNavigationManager(keyMap KeyMap) *NavigationManager {
 	return &NavigationManager{
-		KeyMap:           keyMap,
-		SelectionEnabled: false,
-		TopSticky:        false,
-		BottomSticky:     false,
+		KeyMap:             keyMap,
+		SelectionEnabled:   false,
+		Focused:            true,
+		TopSticky:          false,
+		BottomSticky:       false,
+		CountPrefixEnabled: false,
+		PendingTimeout:     defaultPendingTimeout,
+		SequenceTimeout:    defaultSequenceTimeout,
+		id:                 atomic.AddInt64(&lastNavigationManagerID, 1),
+		now:                time.Now,
 	}
 }
 
@@ -57,6 +119,26 @@ const (
 	ActionTop
 	// ActionBottom represents moving to the bottom.
 	ActionBottom
+	// ActionSetMark represents setting the mark named NavigationResult.Mark.
+	ActionSetMark
+	// ActionJumpToMark represents jumping to the mark named NavigationResult.Mark.
+	ActionJumpToMark
+	// ActionNextMark represents jumping to the next marked item.
+	ActionNextMark
+	// ActionPrevMark represents jumping to the previous marked item.
+	ActionPrevMark
+	// ActionGoToItem represents jumping to item number NavigationResult.Count, starting at 1.
+	ActionGoToItem
+	// ActionGoToPercent represents jumping to NavigationResult.Count percent of the way through the items.
+	ActionGoToPercent
+	// ActionCenterSelection represents scrolling the selection to the vertical center.
+	ActionCenterSelection
+	// ActionTopAlignSelection represents scrolling the selection to the top.
+	ActionTopAlignSelection
+	// ActionBottomAlignSelection represents scrolling the selection to the bottom.
+	ActionBottomAlignSelection
+	// ActionToggleFold represents folding or unfolding the selected item.
+	ActionToggleFold
 )
 
 // NavigationContext contains the context needed for navigation calculations
@@ -70,55 +152,291 @@ type NavigationContext struct {
 // NavigationResult contains the result of processing a navigation action
 type NavigationResult struct {
 	Action          NavigationAction
-	ScrollAmount    int // lines to scroll
-	SelectionAmount int // items to move selection
+	ScrollAmount    int  // lines to scroll
+	SelectionAmount int  // items to move selection
+	Mark            rune // mark name for mark actions
+	Count           int  // count typed before the key, or 0 if none
+}
+
+// sequenceTimeoutMsg is sent when the wait for the next key of a key sequence is over
+type sequenceTimeoutMsg struct {
+	navigationManagerID int64
+	tag                 int
+}
+
+// countTimeoutMsg is sent when a partially typed count has been pending for PendingTimeout
+type countTimeoutMsg struct {
+	navigationManagerID int64
+	tag                 int
 }
 
 // ProcessKeyMsg processes a keyboard message and returns the corresponding navigation action
-func (nm NavigationManager) ProcessKeyMsg(msg tea.KeyMsg, ctx NavigationContext) NavigationResult {
-	switch {
-	case key.Matches(msg, nm.KeyMap.Up):
-		return NavigationResult{Action: ActionUp, ScrollAmount: 1, SelectionAmount: 1}
+func (nm *NavigationManager) ProcessKeyMsg(msg tea.KeyMsg, ctx NavigationContext) NavigationResult {
+	if msg.Key().Code == tea.KeyEscape && nm.hasPending() {
+		nm.clearPending()
+		return NavigationResult{Action: ActionNone}
+	}
+
+	if nm.pendingMarkAction != ActionNone {
+		action := nm.pendingMarkAction
+		nm.pendingMarkAction = ActionNone
+		if name, ok := markName(msg); ok {
+			return NavigationResult{Action: action, Mark: name}
+		}
+		return NavigationResult{Action: ActionNone}
+	}
 
-	case key.Matches(msg, nm.KeyMap.Down):
-		return NavigationResult{Action: ActionDown, ScrollAmount: 1, SelectionAmount: 1}
+	if nm.CountPrefixEnabled && len(nm.pendingKeys) == 0 {
+		count := nm.PendingCount()
+		if digit, ok := countDigit(msg, count); ok {
+			nm.pendingCount = min(count*10+digit, maxCount)
+			nm.pendingCountTag++
+			return NavigationResult{Action: ActionNone}
+		}
+	}
 
-	case key.Matches(msg, nm.KeyMap.Left):
-		if !ctx.WrapText {
-			return NavigationResult{Action: ActionLeft, ScrollAmount: ctx.Dimensions.Width / 4}
+	keys := append(nm.pendingKeys[:len(nm.pendingKeys):len(nm.pendingKeys)], msg.String())
+	action, isPrefix := nm.matchKeys(keys)
+	if !isPrefix && action == ActionNone && len(nm.pendingKeys) > 0 {
+		// the sequence was broken. If the keys before this one complete a binding, that fires and this key is deferred
+		// to be processed after it, otherwise the key is tried on its own
+		if nm.pendingKeysAction != ActionNone {
+			action = nm.pendingKeysAction
+			nm.clearPendingKeys()
+			nm.deferredKeyMsg = msg
+			return nm.result(action, ctx)
+		}
+		keys = []string{msg.String()}
+		action, isPrefix = nm.matchKeys(keys)
+	}
+	if isPrefix {
+		nm.pendingKeys = keys
+		nm.pendingKeysAction = action
+		nm.pendingKeysTag++
+		return NavigationResult{Action: ActionNone}
+	}
+	nm.clearPendingKeys()
+	return nm.result(action, ctx)
+}
+
+// ProcessSequenceTimeout processes the end of the wait for the next key of a key sequence. If the keys typed so far
+// complete a binding, its navigation action is returned
+func (nm *NavigationManager) ProcessSequenceTimeout(msg sequenceTimeoutMsg, ctx NavigationContext) NavigationResult {
+	if msg.navigationManagerID != nm.id || msg.tag != nm.pendingKeysTag || len(nm.pendingKeys) == 0 {
+		return NavigationResult{Action: ActionNone}
+	}
+	action := nm.pendingKeysAction
+	nm.clearPendingKeys()
+	return nm.result(action, ctx)
+}
+
+// SequenceTimeoutCmd returns a command that ends the wait for the next key of a key sequence after SequenceTimeout,
+// or nil if no sequence is pending
+func (nm *NavigationManager) SequenceTimeoutCmd() tea.Cmd {
+	if len(nm.pendingKeys) == 0 || nm.SequenceTimeout <= 0 {
+		return nil
+	}
+	msg := sequenceTimeoutMsg{navigationManagerID: nm.id, tag: nm.pendingKeysTag}
+	return tea.Tick(nm.SequenceTimeout, func(time.Time) tea.Msg {
+		return msg
+	})
+}
+
+// ProcessCountTimeout processes the end of the wait for the next digit or key of a partially typed count, clearing it
+func (nm *NavigationManager) ProcessCountTimeout(msg countTimeoutMsg) {
+	if msg.navigationManagerID != nm.id || msg.tag != nm.pendingCountTag {
+		return
+	}
+	nm.pendingCount = 0
+}
+
+// CountTimeoutCmd returns a command that clears a partially typed count after PendingTimeout, or nil if no count is
+// pending
+func (nm *NavigationManager) CountTimeoutCmd() tea.Cmd {
+	if nm.pendingCount == 0 || nm.PendingTimeout <= 0 {
+		return nil
+	}
+	msg := countTimeoutMsg{navigationManagerID: nm.id, tag: nm.pendingCountTag}
+	return tea.Tick(nm.PendingTimeout, func(time.Time) tea.Msg {
+		return msg
+	})
+}
+
+// TakeDeferredKeyMsg returns the key message that broke a key sequence, which should be processed after the
+// navigation result of the keys before it
+func (nm *NavigationManager) TakeDeferredKeyMsg() (tea.KeyMsg, bool) {
+	msg := nm.deferredKeyMsg
+	nm.deferredKeyMsg = nil
+	return msg, msg != nil
+}
+
+// PendingCount returns the count typed so far for the next action, or 0 if there is none or it timed out
+func (nm *NavigationManager) PendingCount() int {
+	return nm.pendingCount
+}
+
+// PendingKeys returns the keys typed so far of an incomplete key sequence, e.g. ["g"] on the way to `g g`
+func (nm *NavigationManager) PendingKeys() []string {
+	return nm.pendingKeys
+}
+
+func (nm *NavigationManager) hasPending() bool {
+	return nm.PendingCount() > 0 || nm.pendingMarkAction != ActionNone || len(nm.pendingKeys) > 0
+}
+
+func (nm *NavigationManager) clearPending() {
+	nm.pendingCount = 0
+	nm.pendingMarkAction = ActionNone
+	nm.clearPendingKeys()
+}
+
+func (nm *NavigationManager) clearPendingKeys() {
+	if len(nm.pendingKeys) > 0 {
+		nm.pendingKeysTag++
+	}
+	nm.pendingKeys = nil
+	nm.pendingKeysAction = ActionNone
+}
+
+// bindings returns the key bindings and their actions, in order of precedence
+func (nm *NavigationManager) bindings() []struct {
+	binding key.Binding
+	action  NavigationAction
+} {
+	return []struct {
+		binding key.Binding
+		action  NavigationAction
+	}{
+		{nm.KeyMap.Up, ActionUp},
+		{nm.KeyMap.Down, ActionDown},
+		{nm.KeyMap.Left, ActionLeft},
+		{nm.KeyMap.Right, ActionRight},
+		{nm.KeyMap.HalfPageUp, ActionHalfPageUp},
+		{nm.KeyMap.HalfPageDown, ActionHalfPageDown},
+		{nm.KeyMap.PageUp, ActionPageUp},
+		{nm.KeyMap.PageDown, ActionPageDown},
+		{nm.KeyMap.Top, ActionTop},
+		{nm.KeyMap.Bottom, ActionBottom},
+		{nm.KeyMap.Percent, ActionGoToPercent},
+		{nm.KeyMap.SetMark, ActionSetMark},
+		{nm.KeyMap.JumpToMark, ActionJumpToMark},
+		{nm.KeyMap.NextMark, ActionNextMark},
+		{nm.KeyMap.PrevMark, ActionPrevMark},
+		{nm.KeyMap.CenterSelection, ActionCenterSelection},
+		{nm.KeyMap.TopAlignSelection, ActionTopAlignSelection},
+		{nm.KeyMap.BottomAlignSelection, ActionBottomAlignSelection},
+		{nm.KeyMap.ToggleFold, ActionToggleFold},
+	}
+}
+
+// matchKeys returns the action of the first binding that keys completes, if any, and whether keys is the start of a
+// longer key sequence of any binding
+func (nm *NavigationManager) matchKeys(keys []string) (NavigationAction, bool) {
+	action := ActionNone
+	isPrefix := false
+	for _, b := range nm.bindings() {
+		if !b.binding.Enabled() {
+			continue
 		}
+		for _, k := range b.binding.Keys() {
+			sequence := strings.Fields(k)
+			if len(sequence) < len(keys) {
+				continue
+			}
+			matches := true
+			for i := range keys {
+				if sequence[i] != keys[i] {
+					matches = false
+					break
+				}
+			}
+			if !matches {
+				continue
+			}
+			if len(sequence) == len(keys) {
+				if action == ActionNone {
+					action = b.action
+				}
+			} else {
+				isPrefix = true
+			}
+		}
+	}
+	return action, isPrefix
+}
+
+// result returns the navigation result for an action, consuming the pending count
+func (nm *NavigationManager) result(action NavigationAction, ctx NavigationContext) NavigationResult {
+	count := nm.PendingCount()
+	nm.pendingCount = 0
+
+	// movement actions are repeated count times
+	n := max(1, count)
 
-	case key.Matches(msg, nm.KeyMap.Right):
+	switch action {
+	case ActionUp, ActionDown:
+		return NavigationResult{Action: action, ScrollAmount: n, SelectionAmount: n, Count: count}
+
+	case ActionLeft, ActionRight:
 		if !ctx.WrapText {
-			return NavigationResult{Action: ActionRight, ScrollAmount: ctx.Dimensions.Width / 4}
+			return NavigationResult{Action: action, ScrollAmount: n * (ctx.Dimensions.Width / 4), Count: count}
 		}
 
-	case key.Matches(msg, nm.KeyMap.HalfPageUp):
-		scrollAmount := ctx.NumContentLines / 2
-		selectionAmount := max(1, ctx.NumVisibleItems/2)
-		return NavigationResult{Action: ActionHalfPageUp, ScrollAmount: scrollAmount, SelectionAmount: selectionAmount}
+	case ActionHalfPageUp, ActionHalfPageDown:
+		scrollAmount := n * (ctx.NumContentLines / 2)
+		selectionAmount := n * max(1, ctx.NumVisibleItems/2)
+		return NavigationResult{Action: action, ScrollAmount: scrollAmount, SelectionAmount: selectionAmount, Count: count}
+
+	case ActionPageUp, ActionPageDown:
+		scrollAmount := n * ctx.NumContentLines
+		selectionAmount := n * ctx.NumVisibleItems
+		return NavigationResult{Action: action, ScrollAmount: scrollAmount, SelectionAmount: selectionAmount, Count: count}
 
-	case key.Matches(msg, nm.KeyMap.HalfPageDown):
-		scrollAmount := ctx.NumContentLines / 2
-		selectionAmount := max(1, ctx.NumVisibleItems/2)
-		return NavigationResult{Action: ActionHalfPageDown, ScrollAmount: scrollAmount, SelectionAmount: selectionAmount}
+	case ActionTop, ActionBottom:
+		if count > 0 {
+			return NavigationResult{Action: ActionGoToItem, Count: count}
+		}
+		return NavigationResult{Action: action}
 
-	case key.Matches(msg, nm.KeyMap.PageUp):
-		scrollAmount := ctx.NumContentLines
-		selectionAmount := ctx.NumVisibleItems
-		return NavigationResult{Action: ActionPageUp, ScrollAmount: scrollAmount, SelectionAmount: selectionAmount}
+	case ActionGoToPercent:
+		if count > 0 {
+			return NavigationResult{Action: action, Count: count}
+		}
 
-	case key.Matches(msg, nm.KeyMap.PageDown):
-		scrollAmount := ctx.NumContentLines
-		selectionAmount := ctx.NumVisibleItems
-		return NavigationResult{Action: ActionPageDown, ScrollAmount: scrollAmount, SelectionAmount: selectionAmount}
+	case ActionSetMark, ActionJumpToMark:
+		nm.pendingMarkAction = action
 
-	case key.Matches(msg, nm.KeyMap.Top):
-		return NavigationResult{Action: ActionTop}
+	case ActionNextMark, ActionPrevMark, ActionCenterSelection, ActionTopAlignSelection, ActionBottomAlignSelection,
+		ActionToggleFold:
+		return NavigationResult{Action: action}
 
-	case key.Matches(msg, nm.KeyMap.Bottom):
-		return NavigationResult{Action: ActionBottom}
+	default:
 	}
 
 	return NavigationResult{Action: ActionNone}
 }
+
+// maxCount caps the count typed before a key so it can't overflow
+const maxCount = 999_999
+
+// countDigit returns the digit for a key press that continues a count. A leading zero does not start a count
+func countDigit(msg tea.KeyMsg, pendingCount int) (int, bool) {
+	k := msg.Key()
+	if k.Mod != 0 || len(k.Text) != 1 || k.Text[0] < '0' || k.Text[0] > '9' {
+		return 0, false
+	}
+	digit := int(k.Text[0] - '0')
+	if digit == 0 && pendingCount == 0 {
+		return 0, false
+	}
+	return digit, true
+}
+
+// markName returns the mark name for a key press, which must be a single printable character
+func markName(msg tea.KeyMsg) (rune, bool) {
+	runes := []rune(msg.Key().Text)
+	if len(runes) != 1 {
+		return 0, false
+	}
+	return runes[0], true
+}
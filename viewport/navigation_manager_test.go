@@ -0,0 +1,299 @@
+package viewport
+
+import (
+	"testing"
+
+	"github.com/charmbracelet/bubbles/v2/key"
+	tea "github.com/charmbracelet/bubbletea/v2"
+	"github.com/robinovitch61/bubbleo/viewport/internal"
+)
+
+func newNavigationManager() *NavigationManager {
+	nm := NewNavigationManager(newViewport(0, 0).navigation.KeyMap)
+	nm.CountPrefixEnabled = true
+	return nm
+}
+
+func digitKeyMsg(d rune) tea.KeyPressMsg {
+	return tea.KeyPressMsg{Code: d, Text: string(d)}
+}
+
+func TestNavigationManager_CountPrefix(t *testing.T) {
+	ctx := NavigationContext{
+		Dimensions:      internal.Rectangle{Width: 20, Height: 10},
+		NumContentLines: 10,
+		NumVisibleItems: 8,
+	}
+	rightKeyMsg := tea.KeyPressMsg{Code: tea.KeyRight}
+	halfPgDownCtrlKeyMsg := tea.KeyPressMsg{Code: 'd', Mod: tea.ModCtrl}
+
+	tests := []struct {
+		name     string
+		msgs     []tea.KeyPressMsg
+		expected NavigationResult
+	}{
+		{
+			name:     "no count",
+			msgs:     []tea.KeyPressMsg{downKeyMsg},
+			expected: NavigationResult{Action: ActionDown, ScrollAmount: 1, SelectionAmount: 1},
+		},
+		{
+			name:     "count down",
+			msgs:     []tea.KeyPressMsg{digitKeyMsg('5'), downKeyMsg},
+			expected: NavigationResult{Action: ActionDown, ScrollAmount: 5, SelectionAmount: 5, Count: 5},
+		},
+		{
+			name:     "multi-digit count up",
+			msgs:     []tea.KeyPressMsg{digitKeyMsg('1'), digitKeyMsg('0'), upKeyMsg},
+			expected: NavigationResult{Action: ActionUp, ScrollAmount: 10, SelectionAmount: 10, Count: 10},
+		},
+		{
+			name:     "count half page down",
+			msgs:     []tea.KeyPressMsg{digitKeyMsg('3'), halfPgDownCtrlKeyMsg},
+			expected: NavigationResult{Action: ActionHalfPageDown, ScrollAmount: 15, SelectionAmount: 12, Count: 3},
+		},
+		{
+			name:     "count page up",
+			msgs:     []tea.KeyPressMsg{digitKeyMsg('2'), fullPgUpKeyMsg},
+			expected: NavigationResult{Action: ActionPageUp, ScrollAmount: 20, SelectionAmount: 16, Count: 2},
+		},
+		{
+			name:     "count right",
+			msgs:     []tea.KeyPressMsg{digitKeyMsg('1'), digitKeyMsg('0'), rightKeyMsg},
+			expected: NavigationResult{Action: ActionRight, ScrollAmount: 50, Count: 10},
+		},
+		{
+			name:     "count bottom",
+			msgs:     []tea.KeyPressMsg{digitKeyMsg('4'), digitKeyMsg('2'), goToBottomKeyMsg},
+			expected: NavigationResult{Action: ActionGoToItem, Count: 42},
+		},
+		{
+			name:     "leading zero is not a count",
+			msgs:     []tea.KeyPressMsg{digitKeyMsg('0'), downKeyMsg},
+			expected: NavigationResult{Action: ActionDown, ScrollAmount: 1, SelectionAmount: 1},
+		},
+		{
+			name:     "escape clears count",
+			msgs:     []tea.KeyPressMsg{digitKeyMsg('5'), {Code: tea.KeyEscape}, downKeyMsg},
+			expected: NavigationResult{Action: ActionDown, ScrollAmount: 1, SelectionAmount: 1},
+		},
+		{
+			name:     "unbound key clears count",
+			msgs:     []tea.KeyPressMsg{digitKeyMsg('5'), xKeyMsg, downKeyMsg},
+			expected: NavigationResult{Action: ActionDown, ScrollAmount: 1, SelectionAmount: 1},
+		},
+		{
+			name:     "escape clears pending mark",
+			msgs:     []tea.KeyPressMsg{setMarkKeyMsg, {Code: tea.KeyEscape}, aKeyMsg},
+			expected: NavigationResult{Action: ActionNone},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			nm := newNavigationManager()
+			var res NavigationResult
+			for _, msg := range tt.msgs {
+				res = nm.ProcessKeyMsg(msg, ctx)
+			}
+			if res != tt.expected {
+				t.Errorf("expected %+v, got %+v", tt.expected, res)
+			}
+		})
+	}
+}
+
+func TestNavigationManager_PendingCount(t *testing.T) {
+	nm := newNavigationManager()
+
+	nm.ProcessKeyMsg(digitKeyMsg('1'), NavigationContext{})
+	nm.ProcessKeyMsg(digitKeyMsg('2'), NavigationContext{})
+	if count := nm.PendingCount(); count != 12 {
+		t.Errorf("expected pending count 12, got %d", count)
+	}
+
+	// getting the count has no side effects, however long ago it was typed
+	for range 3 {
+		if count := nm.PendingCount(); count != 12 {
+			t.Errorf("expected pending count 12, got %d", count)
+		}
+	}
+
+	// count is cleared by the timeout message
+	nm.ProcessCountTimeout(countTimeoutMsg{navigationManagerID: nm.id, tag: nm.pendingCountTag})
+	if count := nm.PendingCount(); count != 0 {
+		t.Errorf("expected no pending count, got %d", count)
+	}
+	res := nm.ProcessKeyMsg(downKeyMsg, NavigationContext{})
+	if res.ScrollAmount != 1 {
+		t.Errorf("expected scroll amount 1 after timeout, got %d", res.ScrollAmount)
+	}
+
+	// counts are disabled
+	nm.CountPrefixEnabled = false
+	nm.KeyMap.Down = key.NewBinding(key.WithKeys("3"))
+	res = nm.ProcessKeyMsg(digitKeyMsg('3'), NavigationContext{})
+	if res.Action != ActionDown {
+		t.Errorf("expected digit to be a regular key when counts disabled, got %+v", res)
+	}
+}
+
+func TestNavigationManager_KeySequences(t *testing.T) {
+	gKeyMsg := tea.KeyPressMsg{Code: 'g', Text: "g"}
+	tKeyMsg := tea.KeyPressMsg{Code: 't', Text: "t"}
+	newSequenceNavigationManager := func() *NavigationManager {
+		nm := newNavigationManager()
+		nm.KeyMap.Top = key.NewBinding(key.WithKeys("g g"))
+		nm.KeyMap.NextMark = key.NewBinding(key.WithKeys("g"))
+		nm.KeyMap.CenterSelection = key.NewBinding(key.WithKeys("z z"))
+		nm.KeyMap.TopAlignSelection = key.NewBinding(key.WithKeys("z t"))
+		return nm
+	}
+
+	tests := []struct {
+		name            string
+		msgs            []tea.KeyPressMsg
+		timeout         bool
+		expected        NavigationResult
+		expectedPending []string
+	}{
+		{
+			name:            "start of sequence is pending",
+			msgs:            []tea.KeyPressMsg{zKeyMsg},
+			expected:        NavigationResult{Action: ActionNone},
+			expectedPending: []string{"z"},
+		},
+		{
+			name:     "complete sequence",
+			msgs:     []tea.KeyPressMsg{zKeyMsg, zKeyMsg},
+			expected: NavigationResult{Action: ActionCenterSelection},
+		},
+		{
+			name:     "other complete sequence with same prefix",
+			msgs:     []tea.KeyPressMsg{zKeyMsg, tKeyMsg},
+			expected: NavigationResult{Action: ActionTopAlignSelection},
+		},
+		{
+			name:     "broken sequence tries key on its own",
+			msgs:     []tea.KeyPressMsg{zKeyMsg, downKeyMsg},
+			expected: NavigationResult{Action: ActionDown, ScrollAmount: 1, SelectionAmount: 1},
+		},
+		{
+			name:            "escape clears sequence",
+			msgs:            []tea.KeyPressMsg{zKeyMsg, {Code: tea.KeyEscape}, zKeyMsg},
+			expected:        NavigationResult{Action: ActionNone},
+			expectedPending: []string{"z"},
+		},
+		{
+			name:     "sequence with count",
+			msgs:     []tea.KeyPressMsg{digitKeyMsg('5'), gKeyMsg, gKeyMsg},
+			expected: NavigationResult{Action: ActionGoToItem, Count: 5},
+		},
+		{
+			name:            "ambiguous key waits",
+			msgs:            []tea.KeyPressMsg{gKeyMsg},
+			expected:        NavigationResult{Action: ActionNone},
+			expectedPending: []string{"g"},
+		},
+		{
+			name:     "ambiguous key fires on timeout",
+			msgs:     []tea.KeyPressMsg{gKeyMsg},
+			timeout:  true,
+			expected: NavigationResult{Action: ActionNextMark},
+		},
+		{
+			name:     "timeout without complete binding does nothing",
+			msgs:     []tea.KeyPressMsg{zKeyMsg},
+			timeout:  true,
+			expected: NavigationResult{Action: ActionNone},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			nm := newSequenceNavigationManager()
+			var res NavigationResult
+			for _, msg := range tt.msgs {
+				res = nm.ProcessKeyMsg(msg, NavigationContext{})
+			}
+			if tt.timeout {
+				res = nm.ProcessSequenceTimeout(sequenceTimeoutMsg{navigationManagerID: nm.id, tag: nm.pendingKeysTag}, NavigationContext{})
+			}
+			if res != tt.expected {
+				t.Errorf("expected %+v, got %+v", tt.expected, res)
+			}
+			if pending := nm.PendingKeys(); len(pending) != len(tt.expectedPending) {
+				t.Errorf("expected pending keys %v, got %v", tt.expectedPending, pending)
+			}
+		})
+	}
+}
+
+func TestNavigationManager_CountTimeout(t *testing.T) {
+	nm := newNavigationManager()
+	if cmd := nm.CountTimeoutCmd(); cmd != nil {
+		t.Errorf("expected no count timeout command")
+	}
+
+	nm.ProcessKeyMsg(digitKeyMsg('1'), NavigationContext{})
+	if cmd := nm.CountTimeoutCmd(); cmd == nil {
+		t.Errorf("expected a count timeout command")
+	}
+	staleTimeout := countTimeoutMsg{navigationManagerID: nm.id, tag: nm.pendingCountTag}
+	nm.ProcessKeyMsg(digitKeyMsg('2'), NavigationContext{})
+
+	// stale timeouts and those of other navigation managers are ignored
+	nm.ProcessCountTimeout(staleTimeout)
+	nm.ProcessCountTimeout(countTimeoutMsg{navigationManagerID: nm.id + 1, tag: nm.pendingCountTag})
+	if nm.pendingCount != 12 {
+		t.Errorf("expected pending count 12, got %d", nm.pendingCount)
+	}
+
+	nm.ProcessCountTimeout(countTimeoutMsg{navigationManagerID: nm.id, tag: nm.pendingCountTag})
+	if nm.pendingCount != 0 {
+		t.Errorf("expected the count to be cleared on timeout, got %d", nm.pendingCount)
+	}
+	if cmd := nm.CountTimeoutCmd(); cmd != nil {
+		t.Errorf("expected no count timeout command")
+	}
+
+	// no timeout
+	nm.PendingTimeout = 0
+	nm.ProcessKeyMsg(digitKeyMsg('3'), NavigationContext{})
+	if cmd := nm.CountTimeoutCmd(); cmd != nil {
+		t.Errorf("expected no count timeout command")
+	}
+}
+
+func TestNavigationManager_KeySequenceBrokenAfterAmbiguousKey(t *testing.T) {
+	nm := newNavigationManager()
+	nm.KeyMap.Top = key.NewBinding(key.WithKeys("g g"))
+	nm.KeyMap.NextMark = key.NewBinding(key.WithKeys("g"))
+
+	nm.ProcessKeyMsg(tea.KeyPressMsg{Code: 'g', Text: "g"}, NavigationContext{})
+	if cmd := nm.SequenceTimeoutCmd(); cmd == nil {
+		t.Errorf("expected a sequence timeout command")
+	}
+	staleTimeout := sequenceTimeoutMsg{navigationManagerID: nm.id, tag: nm.pendingKeysTag}
+
+	// the ambiguous key fires, then the key that broke the sequence is deferred
+	res := nm.ProcessKeyMsg(downKeyMsg, NavigationContext{})
+	if res.Action != ActionNextMark {
+		t.Errorf("expected next mark action, got %+v", res)
+	}
+	deferred, ok := nm.TakeDeferredKeyMsg()
+	if !ok || deferred.String() != downKeyMsg.String() {
+		t.Errorf("expected deferred down key, got %v", deferred)
+	}
+	if _, ok = nm.TakeDeferredKeyMsg(); ok {
+		t.Errorf("expected deferred key to be taken only once")
+	}
+
+	// stale timeouts are ignored
+	if res = nm.ProcessSequenceTimeout(staleTimeout, NavigationContext{}); res.Action != ActionNone {
+		t.Errorf("expected stale timeout to be ignored, got %+v", res)
+	}
+	if cmd := nm.SequenceTimeoutCmd(); cmd != nil {
+		t.Errorf("expected no sequence timeout command")
+	}
+}
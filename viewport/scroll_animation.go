@@ -0,0 +1,153 @@
+package viewport
+
+import (
+	"math"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea/v2"
+)
+
+// EasingFn maps the fraction of a scroll animation's duration that has elapsed, from 0 to 1, to the fraction of its
+// distance that has been scrolled, from 0 to 1
+type EasingFn func(t float64) float64
+
+// EaseLinear scrolls at a constant speed
+func EaseLinear(t float64) float64 {
+	return t
+}
+
+// EaseOutCubic scrolls quickly at first, then slows down towards the target
+func EaseOutCubic(t float64) float64 {
+	return 1 - math.Pow(1-t, 3)
+}
+
+// EaseInOutCubic speeds up from the start, then slows down towards the target
+func EaseInOutCubic(t float64) float64 {
+	if t < 0.5 {
+		return 4 * t * t * t
+	}
+	return 1 - math.Pow(-2*t+2, 3)/2
+}
+
+const (
+	// defaultSmoothScrollDuration is how long a smooth scroll takes by default
+	defaultSmoothScrollDuration = 150 * time.Millisecond
+
+	// smoothScrollFrameInterval is the time between frames of a smooth scroll
+	smoothScrollFrameInterval = time.Second / 60
+)
+
+// scrollAnimation is the state of an in-progress smooth scroll
+type scrollAnimation struct {
+	// active is true while the animation is in progress
+	active bool
+
+	// tag increments for each animation so frames of previous animations are ignored
+	tag int
+
+	// start is when the animation started
+	start time.Time
+
+	// numLines is the total number of lines to scroll, negative for up
+	numLines int
+
+	// numLinesScrolled is the number of lines scrolled so far, negative for up
+	numLinesScrolled int
+
+	// targetTopItemIdx and targetTopItemLineOffset are the scroll position at the end of the animation
+	targetTopItemIdx        int
+	targetTopItemLineOffset int
+}
+
+// scrollFrameMsg is sent for each frame of a smooth scroll
+type scrollFrameMsg struct {
+	navigationManagerID int64
+	tag                 int
+	time                time.Time
+}
+
+// animateScroll runs scrollFn, which changes the scroll position and selection. If smooth scrolling is enabled, the
+// selection changes immediately but the scroll position is animated from where it was to where scrollFn left it.
+// Returns the command for the first frame, if any
+func (m *Model[T]) animateScroll(scrollFn func()) tea.Cmd {
+	m.finishScrollAnimation()
+	if !m.config.SmoothScroll || m.config.SmoothScrollDuration <= 0 {
+		scrollFn()
+		return nil
+	}
+
+	startTopItemIdx, startTopItemLineOffset := m.display.TopItemIdx, m.display.TopItemLineOffset
+	scrollFn()
+	targetTopItemIdx, targetTopItemLineOffset := m.display.TopItemIdx, m.display.TopItemLineOffset
+	numLines := m.numLinesBetween(startTopItemIdx, startTopItemLineOffset, targetTopItemIdx, targetTopItemLineOffset)
+	if numLines == 0 {
+		return nil
+	}
+
+	m.display.TopItemIdx, m.display.TopItemLineOffset = startTopItemIdx, startTopItemLineOffset
+	m.display.animation = scrollAnimation{
+		active:                  true,
+		tag:                     m.display.animation.tag + 1,
+		start:                   m.navigation.now(),
+		numLines:                numLines,
+		targetTopItemIdx:        targetTopItemIdx,
+		targetTopItemLineOffset: targetTopItemLineOffset,
+	}
+	return m.scrollFrameCmd()
+}
+
+// processScrollFrame advances the smooth scroll animation to the time of the frame, returning the command for the
+// next frame if the animation isn't finished
+func (m *Model[T]) processScrollFrame(msg scrollFrameMsg) tea.Cmd {
+	if !m.display.animation.active || msg.navigationManagerID != m.navigation.id || msg.tag != m.display.animation.tag {
+		return nil
+	}
+
+	progress := float64(msg.time.Sub(m.display.animation.start)) / float64(m.config.SmoothScrollDuration)
+	if progress >= 1 {
+		m.finishScrollAnimation()
+		return nil
+	}
+
+	easing := m.config.SmoothScrollEasing
+	if easing == nil {
+		easing = EaseLinear
+	}
+	eased := max(0, min(1, easing(max(0, progress))))
+	numLinesScrolled := int(math.Round(eased * float64(m.display.animation.numLines)))
+	m.scrollByNLines(numLinesScrolled - m.display.animation.numLinesScrolled)
+	m.display.animation.numLinesScrolled = numLinesScrolled
+	return m.scrollFrameCmd()
+}
+
+// finishScrollAnimation jumps to the end of the smooth scroll animation, if any. Anything moving the scroll position or
+// selection calls it first, so frames of the interrupted animation don't scroll away from where it moved
+func (m *Model[T]) finishScrollAnimation() {
+	if !m.display.animation.active {
+		return
+	}
+	m.display.animation.active = false
+	m.safelySetTopItemIdxAndOffset(m.display.animation.targetTopItemIdx, m.display.animation.targetTopItemLineOffset)
+}
+
+func (m *Model[T]) scrollFrameCmd() tea.Cmd {
+	id, tag := m.navigation.id, m.display.animation.tag
+	return tea.Tick(smoothScrollFrameInterval, func(t time.Time) tea.Msg {
+		return scrollFrameMsg{navigationManagerID: id, tag: tag, time: t}
+	})
+}
+
+// numLinesBetween returns the number of lines from one scroll position to another, negative if the second is above
+func (m *Model[T]) numLinesBetween(fromItemIdx, fromLineOffset, toItemIdx, toLineOffset int) int {
+	if !m.config.WrapText {
+		return toItemIdx - fromItemIdx
+	}
+	if fromItemIdx > toItemIdx || (fromItemIdx == toItemIdx && fromLineOffset > toLineOffset) {
+		return -m.numLinesBetween(toItemIdx, toLineOffset, fromItemIdx, fromLineOffset)
+	}
+	numLines := toLineOffset - fromLineOffset
+	for itemIdx := fromItemIdx; itemIdx < toItemIdx; itemIdx++ {
+		numLines += m.numLinesForItem(itemIdx)
+	}
+	return numLines
+}
@@ -0,0 +1,109 @@
+package viewport
+
+import tea "github.com/charmbracelet/bubbletea/v2"
+
+// ScrollLinkMsg is sent when a linked viewport's scroll position or selection changes through Update. Pass it to the
+// Update of the other viewports in the same group so they follow. Viewports apply it without sending their own, so
+// linked viewports never trigger each other in a loop
+type ScrollLinkMsg struct {
+	// Group is the scroll link group of the viewport that changed
+	Group string
+
+	// sourceID identifies the viewport that changed so it ignores its own messages
+	sourceID int64
+
+	// position is the scroll position and selection of the viewport that changed
+	position scrollPosition
+
+	// topKey and selectedKey are the link keys of the top and selected items, if the viewport that changed has a
+	// link key function
+	topKey, selectedKey string
+	hasKeys             bool
+}
+
+// scrollPosition is the part of a viewport's state that linked viewports follow
+type scrollPosition struct {
+	topItemIdx        int
+	topItemLineOffset int
+
+	// selectedItemIdx is -1 if selection is disabled
+	selectedItemIdx int
+
+	xOffset int
+}
+
+func (m *Model[T]) scrollPosition() scrollPosition {
+	selectedItemIdx := -1
+	if m.navigation.SelectionEnabled {
+		selectedItemIdx = m.content.GetSelectedIdx()
+	}
+	return scrollPosition{
+		topItemIdx:        m.display.TopItemIdx,
+		topItemLineOffset: m.display.TopItemLineOffset,
+		selectedItemIdx:   selectedItemIdx,
+		xOffset:           m.display.XOffset,
+	}
+}
+
+// scrollLinkCmdIfMoved returns a command sending a ScrollLinkMsg if the viewport is linked and its scroll position or
+// selection changed from prev
+func (m *Model[T]) scrollLinkCmdIfMoved(prev scrollPosition) tea.Cmd {
+	if m.scrollPosition() == prev {
+		return nil
+	}
+	return m.ScrollLinkCmd()
+}
+
+// ScrollLinkCmd returns a command sending a ScrollLinkMsg with the viewport's current scroll position and selection,
+// e.g. after changing them outside of Update. Returns nil if the viewport isn't linked
+func (m *Model[T]) ScrollLinkCmd() tea.Cmd {
+	if m.config.ScrollLinkGroup == "" {
+		return nil
+	}
+	msg := ScrollLinkMsg{
+		Group:    m.config.ScrollLinkGroup,
+		sourceID: m.navigation.id,
+		position: m.scrollPosition(),
+	}
+	if m.content.LinkKeyFn != nil && !m.content.IsEmpty() {
+		msg.hasKeys = true
+		topItemIdx := clampValZeroToMax(msg.position.topItemIdx, m.content.NumItems()-1)
+		msg.topKey = m.content.LinkKeyFn(m.content.Items[topItemIdx])
+		if msg.position.selectedItemIdx >= 0 {
+			msg.selectedKey = m.content.LinkKeyFn(m.content.Items[msg.position.selectedItemIdx])
+		}
+	}
+	return func() tea.Msg {
+		return msg
+	}
+}
+
+// applyScrollLink follows the scroll position and selection of another viewport in the same group. If both viewports
+// have link key functions, items are aligned by key, and parts of the position whose key isn't found are left as is.
+// Otherwise items are aligned by index
+func (m *Model[T]) applyScrollLink(msg ScrollLinkMsg) {
+	if msg.Group == "" || msg.Group != m.config.ScrollLinkGroup || msg.sourceID == m.navigation.id {
+		return
+	}
+	m.finishScrollAnimation()
+
+	topItemIdx, selectedItemIdx := msg.position.topItemIdx, msg.position.selectedItemIdx
+	if msg.hasKeys && m.content.LinkKeyFn != nil {
+		topItemIdx = m.content.IndexOfLinkKey(msg.topKey)
+		if selectedItemIdx >= 0 {
+			selectedItemIdx = m.content.IndexOfLinkKey(msg.selectedKey)
+		}
+	}
+
+	if m.navigation.SelectionEnabled && selectedItemIdx >= 0 {
+		m.content.SetSelectedIdx(selectedItemIdx)
+	}
+	if topItemIdx >= 0 {
+		m.safelySetTopItemIdxAndOffset(topItemIdx, msg.position.topItemLineOffset)
+	} else if m.navigation.SelectionEnabled && selectedItemIdx >= 0 {
+		m.scrollSoSelectionInView()
+	}
+	if !m.config.WrapText {
+		m.safelySetXOffset(msg.position.xOffset)
+	}
+}
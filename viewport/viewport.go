@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"regexp"
 	"strings"
+	"time"
 
 	tea "github.com/charmbracelet/bubbletea/v2"
 	"github.com/charmbracelet/lipgloss/v2"
@@ -35,17 +36,36 @@ import (
 
 var surroundingAnsiRegex = regexp.MustCompile(`(\x1b\[[0-9;]*m.*?\x1b\[0?m)`)
 
+var ansiResetRegex = regexp.MustCompile(`\x1b\[0?m`)
+
 // Styles contains styling configuration for the viewport
 type Styles struct {
 	FooterStyle              lipgloss.Style
 	HighlightStyle           lipgloss.Style
 	HighlightStyleIfSelected lipgloss.Style
 	SelectedItemStyle        lipgloss.Style
+	MarkedItemStyle          lipgloss.Style
+	FoldSummaryStyle         lipgloss.Style
 }
 
+// ItemAlignment is the vertical position in the viewport to scroll an item to
+type ItemAlignment int
+
+const (
+	// AlignTop scrolls an item to the top of the viewport
+	AlignTop ItemAlignment = iota
+	// AlignCenter scrolls an item to the vertical center of the viewport
+	AlignCenter
+	// AlignBottom scrolls an item to the bottom of the viewport
+	AlignBottom
+)
+
 // CompareFn is a function type for comparing two items of type T.
 type CompareFn[T any] func(a, b T) bool
 
+// ItemStyleFunc returns the style of every line of an item, given the item, its index, and whether it's selected
+type ItemStyleFunc[T any] func(item T, idx int, selected bool) lipgloss.Style
+
 // Model represents a viewport component
 type Model[T Renderable] struct {
 	// content manages the content and selection state
@@ -76,88 +96,42 @@ func (m *Model[T]) Update(msg tea.Msg) (Model[T], tea.Cmd) {
 		cmd  tea.Cmd
 		cmds []tea.Cmd
 	)
+	prevPosition := m.scrollPosition()
 
 	switch msg := msg.(type) {
-	case tea.KeyMsg:
-		navCtx := NavigationContext{
-			WrapText:        m.config.WrapText,
-			Dimensions:      m.display.Bounds,
-			NumContentLines: m.getNumContentLines(),
-			NumVisibleItems: m.getNumVisibleItems(),
-		}
-		navResult := m.navigation.ProcessKeyMsg(msg, navCtx)
-
-		switch navResult.Action {
-		case ActionUp:
-			if m.navigation.SelectionEnabled {
-				m.selectedItemIdxUp(navResult.SelectionAmount)
-			} else {
-				m.scrollUp(navResult.ScrollAmount)
-			}
-
-		case ActionDown:
-			if m.navigation.SelectionEnabled {
-				m.selectedItemIdxDown(navResult.SelectionAmount)
-			} else {
-				m.scrollDown(navResult.ScrollAmount)
-			}
+	case ScrollLinkMsg:
+		// following a linked viewport never sends a ScrollLinkMsg of its own
+		m.applyScrollLink(msg)
+		return *m, nil
 
-		case ActionLeft:
-			if !m.config.WrapText {
-				m.viewLeft(navResult.ScrollAmount)
-			}
-
-		case ActionRight:
-			if !m.config.WrapText {
-				m.viewRight(navResult.ScrollAmount)
-			}
+	case SearchProgressMsg:
+		return *m, m.processSearchProgress(msg)
 
-		case ActionHalfPageUp:
-			m.scrollUp(navResult.ScrollAmount)
-			if m.navigation.SelectionEnabled {
-				m.selectedItemIdxUp(navResult.SelectionAmount)
-			}
-
-		case ActionHalfPageDown:
-			m.scrollDown(navResult.ScrollAmount)
-			if m.navigation.SelectionEnabled {
-				m.selectedItemIdxDown(navResult.SelectionAmount)
-			}
-
-		case ActionPageUp:
-			m.scrollUp(navResult.ScrollAmount)
-			if m.navigation.SelectionEnabled {
-				m.selectedItemIdxUp(navResult.SelectionAmount)
-			}
-
-		case ActionPageDown:
-			m.scrollDown(navResult.ScrollAmount)
-			if m.navigation.SelectionEnabled {
-				m.selectedItemIdxDown(navResult.SelectionAmount)
-			}
+	case tea.KeyMsg:
+		if !m.navigation.Focused {
+			return *m, nil
+		}
+		// new input cancels any in-progress smooth scroll
+		m.finishScrollAnimation()
+		navResult := m.navigation.ProcessKeyMsg(msg, m.navigationContext())
+		cmds = append(cmds, m.applyNavigationResult(navResult))
+		for deferredMsg, ok := m.navigation.TakeDeferredKeyMsg(); ok; deferredMsg, ok = m.navigation.TakeDeferredKeyMsg() {
+			cmds = append(cmds, m.applyNavigationResult(m.navigation.ProcessKeyMsg(deferredMsg, m.navigationContext())))
+		}
+		cmds = append(cmds, m.navigation.CountTimeoutCmd())
+		cmd = m.navigation.SequenceTimeoutCmd()
 
-		case ActionTop:
-			if m.navigation.SelectionEnabled {
-				m.SetSelectedItemIdx(0)
-			} else {
-				m.display.TopItemIdx = 0
-				m.display.TopItemLineOffset = 0
-			}
+	case sequenceTimeoutMsg:
+		cmd = m.applyNavigationResult(m.navigation.ProcessSequenceTimeout(msg, m.navigationContext()))
 
-		case ActionBottom:
-			if m.navigation.SelectionEnabled {
-				m.selectedItemIdxDown(m.content.NumItems())
-			} else {
-				maxItemIdx, maxTopLineOffset := m.maxItemIdxAndMaxTopLineOffset()
-				m.safelySetTopItemIdxAndOffset(maxItemIdx, maxTopLineOffset)
-			}
+	case countTimeoutMsg:
+		m.navigation.ProcessCountTimeout(msg)
 
-		default:
-			// no-op on keypress that doesn't produce a selection action
-		}
+	case scrollFrameMsg:
+		cmd = m.processScrollFrame(msg)
 	}
 
-	cmds = append(cmds, cmd)
+	cmds = append(cmds, cmd, m.scrollLinkCmdIfMoved(prevPosition))
 	return *m, tea.Batch(cmds...)
 }
 
@@ -172,9 +146,13 @@ func (m *Model[T]) View() string {
 	estimatedSize := (len(visibleHeaderLines) + len(visibleContentLines.lines) + 10) * (m.display.Bounds.Width + 1)
 	builder.Grow(estimatedSize)
 
+	headerXOffset := 0
+	if m.config.PanHeader && !m.config.WrapText {
+		headerXOffset = m.display.XOffset
+	}
 	for i := range visibleHeaderLines {
 		lineBuffer := linebuffer.New(visibleHeaderLines[i])
-		line, _ := lineBuffer.Take(0, m.display.Bounds.Width, m.config.ContinuationIndicator, linebuffer.HighlightData{}, lipgloss.NewStyle())
+		line := m.takeLine(lineBuffer, headerXOffset, linebuffer.HighlightData{}, lipgloss.NewStyle())
 		builder.WriteString(line)
 		builder.WriteByte('\n')
 	}
@@ -185,11 +163,9 @@ func (m *Model[T]) View() string {
 		if m.config.WrapText {
 			truncated = visibleContentLines.lines[i].Content()
 		} else {
-			lineBuffer := visibleContentLines.lines[i]
-			truncated, _ = lineBuffer.Take(
+			truncated = m.takeLine(
+				visibleContentLines.lines[i],
 				m.display.XOffset,
-				m.display.Bounds.Width,
-				m.config.ContinuationIndicator,
 				m.content.ToHighlight,
 				m.highlightStyle(visibleContentLines.itemIndexes[i]),
 			)
@@ -198,6 +174,8 @@ func (m *Model[T]) View() string {
 		isSelection := m.navigation.SelectionEnabled && visibleContentLines.itemIndexes[i] == m.content.GetSelectedIdx()
 		if isSelection {
 			truncated = m.styleSelection(truncated)
+		} else if m.content.IsMarked(visibleContentLines.itemIndexes[i]) {
+			truncated = styleSections(truncated, m.styles().MarkedItemStyle)
 		}
 
 		if !m.config.WrapText && m.display.XOffset > 0 && lipgloss.Width(truncated) == 0 && visibleContentLines.lines[i].Width() > 0 {
@@ -214,6 +192,19 @@ func (m *Model[T]) View() string {
 			truncated = m.styleSelection(" ")
 		}
 
+		if isSelection && m.config.FullWidthSelection {
+			if padding := m.display.Bounds.Width - lipgloss.Width(truncated); padding > 0 {
+				truncated += m.styleSelection(strings.Repeat(" ", padding))
+			}
+		}
+
+		if m.content.ItemStyleFn != nil {
+			itemIdx := visibleContentLines.itemIndexes[i]
+			itemStyle := m.content.ItemStyleFn(m.content.Items[itemIdx], itemIdx, isSelection)
+			truncated += strings.Repeat(" ", max(0, m.display.Bounds.Width-lipgloss.Width(truncated)))
+			truncated = styleUnderneath(truncated, itemStyle)
+		}
+
 		truncatedVisibleContentLines[i] = truncated
 	}
 
@@ -250,8 +241,33 @@ func (m *Model[T]) SetStyles(styles Styles) {
 	m.display.Styles = styles
 }
 
+// SetBlurredStyles sets the styling configuration used while the viewport is blurred, e.g. with a dimmed selection.
+// Until set, blurred viewports render with the same styles as focused ones
+func (m *Model[T]) SetBlurredStyles(styles Styles) {
+	m.display.BlurredStyles = &styles
+}
+
+// Focus focuses the viewport so it handles key input and renders with its styles. Viewports start focused
+func (m *Model[T]) Focus() {
+	m.navigation.Focused = true
+}
+
+// Blur blurs the viewport so it ignores key input and renders with its blurred styles. Any partially typed count or
+// key sequence is discarded
+func (m *Model[T]) Blur() {
+	m.navigation.Focused = false
+	m.navigation.clearPending()
+}
+
+// Focused returns true if the viewport is focused
+func (m *Model[T]) Focused() bool {
+	return m.navigation.Focused
+}
+
 // SetContent sets the content, the selectable set of lines in the viewport
 func (m *Model[T]) SetContent(content []T) {
+	m.finishScrollAnimation()
+	m.CancelSearch()
 	var initialNumLinesAboveSelection int
 	var stayAtTop, stayAtBottom bool
 	var prevSelection T
@@ -270,7 +286,7 @@ func (m *Model[T]) SetContent(content []T) {
 		}
 	}
 
-	m.content.Items = content
+	m.content.SetItems(content)
 	// ensure scroll position is valid given new content
 	m.safelySetTopItemIdxAndOffset(m.display.TopItemIdx, m.display.TopItemLineOffset)
 
@@ -333,11 +349,104 @@ func (m *Model[T]) SetSelectionEnabled(selectionEnabled bool) {
 	}
 }
 
+// SetFullWidthSelection sets whether the selection style fills every line of the selected item to the viewport width,
+// rather than only styling its text
+func (m *Model[T]) SetFullWidthSelection(fullWidthSelection bool) {
+	m.config.FullWidthSelection = fullWidthSelection
+}
+
 // SetFooterEnabled sets whether the viewport shows the footer when it overflows
 func (m *Model[T]) SetFooterEnabled(footerEnabled bool) {
 	m.config.FooterEnabled = footerEnabled
 }
 
+// SetCountPrefixEnabled sets whether digits typed before a key are a count, e.g. `5j` to move down 5 times,
+// `42G` to go to the 42nd item, or `50%` to go halfway through the content
+func (m *Model[T]) SetCountPrefixEnabled(countPrefixEnabled bool) {
+	m.navigation.CountPrefixEnabled = countPrefixEnabled
+	if !countPrefixEnabled {
+		m.navigation.pendingCount = 0
+	}
+}
+
+// GetPendingCount returns the count typed so far for the next navigation key, e.g. 5 after typing `5` on the way
+// to `5j`, or 0 if there is none. Useful for showing in a footer
+func (m *Model[T]) GetPendingCount() int {
+	return m.navigation.PendingCount()
+}
+
+// GetPendingKeys returns the keys typed so far of an incomplete key sequence, e.g. ["g"] on the way to `g g`.
+// Useful for showing in a footer
+func (m *Model[T]) GetPendingKeys() []string {
+	return m.navigation.PendingKeys()
+}
+
+// SetSequenceTimeout sets how long to wait for the next key of a key sequence like `g g`. When the keys typed so far
+// are both a complete binding and the start of a longer sequence, the complete binding fires after this timeout
+func (m *Model[T]) SetSequenceTimeout(timeout time.Duration) {
+	m.navigation.SequenceTimeout = timeout
+}
+
+// SetPendingTimeout sets how long a partially typed count stays pending without further input. Zero means no timeout
+func (m *Model[T]) SetPendingTimeout(timeout time.Duration) {
+	m.navigation.PendingTimeout = timeout
+}
+
+// SetScrollOff sets the minimum number of lines to keep visible above and below the selection when navigating,
+// like vim's scrolloff. Lines are counted after wrapping
+func (m *Model[T]) SetScrollOff(scrollOff int) {
+	m.config.ScrollOff = max(0, scrollOff)
+}
+
+// SetKeepSelectionCentered sets whether the viewport scrolls to keep the selection vertically centered when navigating
+func (m *Model[T]) SetKeepSelectionCentered(keepSelectionCentered bool) {
+	m.config.KeepSelectionCentered = keepSelectionCentered
+}
+
+// SetSmoothScroll sets whether paging up and down is animated over several frames rather than jumping instantly.
+// The animation is driven by commands returned from Update
+func (m *Model[T]) SetSmoothScroll(smoothScroll bool) {
+	m.config.SmoothScroll = smoothScroll
+	if !smoothScroll {
+		m.finishScrollAnimation()
+	}
+}
+
+// SetSmoothScrollDuration sets how long a smooth scroll takes
+func (m *Model[T]) SetSmoothScrollDuration(duration time.Duration) {
+	m.config.SmoothScrollDuration = duration
+}
+
+// SetSmoothScrollEasing sets the easing function for smooth scrolling, e.g. EaseOutCubic
+func (m *Model[T]) SetSmoothScrollEasing(easing EasingFn) {
+	m.config.SmoothScrollEasing = easing
+}
+
+// SetScrollLinkGroup links the viewport to other viewports with the same group so they scroll together. When the
+// viewport's scroll position or selection changes in Update, it returns a command sending a ScrollLinkMsg for the
+// other viewports in the group to follow. An empty group unlinks the viewport
+func (m *Model[T]) SetScrollLinkGroup(group string) {
+	m.config.ScrollLinkGroup = group
+}
+
+// SetScrollLinkKeyFunc sets a function returning a key for an item, so linked viewports that also have one are aligned
+// by item key rather than by item index, e.g. for viewports showing the same items in different orders
+func (m *Model[T]) SetScrollLinkKeyFunc(keyFn func(T) string) {
+	m.content.LinkKeyFn = keyFn
+}
+
+// IsScrollAnimating returns true while a smooth scroll is in progress
+func (m *Model[T]) IsScrollAnimating() bool {
+	return m.display.animation.active
+}
+
+// SetItemStyleFunc sets a function styling whole lines of each item, e.g. to stripe alternate items or dim old ones.
+// The style goes underneath the item's own ansi styling, highlights, and the selected or marked item style, and lines
+// are padded to the viewport width so a background fills it. Nil removes it
+func (m *Model[T]) SetItemStyleFunc(styleFn ItemStyleFunc[T]) {
+	m.content.ItemStyleFn = styleFn
+}
+
 // SetSelectionComparator sets the comparator function for maintaining the current selection when content changes.
 // If compareFn is non-nil, the viewport will try to maintain the current selection when content changes.
 func (m *Model[T]) SetSelectionComparator(compareFn CompareFn[T]) {
@@ -351,6 +460,7 @@ func (m *Model[T]) GetSelectionEnabled() bool {
 
 // SetWrapText sets whether the viewport wraps text
 func (m *Model[T]) SetWrapText(wrapText bool) {
+	m.finishScrollAnimation()
 	var initialNumLinesAboveSelection int
 	if m.navigation.SelectionEnabled {
 		if inView := m.selectionInViewInfo(); inView.numLinesSelectionInView > 0 {
@@ -387,11 +497,13 @@ func (m *Model[T]) SetHeight(height int) {
 
 // SetSelectedItemIdx sets the selected context index. Automatically puts selection in view as necessary
 func (m *Model[T]) SetSelectedItemIdx(selectedItemIdx int) {
+	m.finishScrollAnimation()
 	if !m.navigation.SelectionEnabled || m.getNumContentLines() == 0 {
 		return
 	}
 	m.content.SetSelectedIdx(selectedItemIdx)
 	m.scrollSoSelectionInView()
+	m.scrollSoSelectionHasContext()
 }
 
 // GetSelectedItemIdx returns the currently selected item index
@@ -410,20 +522,52 @@ func (m *Model[T]) GetSelectedItem() *T {
 	return m.content.GetSelectedItem()
 }
 
+// SetHeaderPanEnabled sets whether the header pans horizontally with the content when wrapping is off, e.g. for
+// column titles that should stay aligned with their columns
+func (m *Model[T]) SetHeaderPanEnabled(panHeader bool) {
+	m.config.PanHeader = panHeader
+}
+
+// SetFrozenWidth sets the number of leading terminal cells of each line that stay in place when panning horizontally
+// with wrapping off, e.g. to keep a key column visible. The continuation indicator is shown at the freeze boundary
+func (m *Model[T]) SetFrozenWidth(frozenWidth int) {
+	m.config.FrozenWidth = max(0, frozenWidth)
+}
+
 // SetStringToHighlight sets a string to highlight in the viewport. Can only set string or regex, not both.
 func (m *Model[T]) SetStringToHighlight(h string) {
-	m.content.ToHighlight = linebuffer.HighlightData{
+	m.setToHighlight(linebuffer.HighlightData{
 		StringToHighlight: h,
 		IsRegex:           false,
-	}
+		CaseMode:          m.content.ToHighlight.CaseMode,
+		MatchMode:         m.content.ToHighlight.MatchMode,
+	})
 }
 
 // SetRegexToHighlight sets a regex to highlight in the viewport. Can only set string or regex, not both.
 func (m *Model[T]) SetRegexToHighlight(r *regexp.Regexp) {
-	m.content.ToHighlight = linebuffer.HighlightData{
+	m.setToHighlight(linebuffer.HighlightData{
 		RegexPatternToHighlight: r,
 		IsRegex:                 true,
-	}
+		CaseMode:                m.content.ToHighlight.CaseMode,
+		MatchMode:               m.content.ToHighlight.MatchMode,
+	})
+}
+
+// SetHighlightCaseMode sets how letter case is compared when matching the string or regex to highlight. Defaults to
+// case-sensitive
+func (m *Model[T]) SetHighlightCaseMode(caseMode linebuffer.CaseMode) {
+	toHighlight := m.content.ToHighlight
+	toHighlight.CaseMode = caseMode
+	m.setToHighlight(toHighlight)
+}
+
+// SetHighlightMatchMode sets how the string to highlight is matched, e.g. only as a whole word. Defaults to matching
+// it anywhere. Doesn't apply to a regex to highlight
+func (m *Model[T]) SetHighlightMatchMode(matchMode linebuffer.MatchMode) {
+	toHighlight := m.content.ToHighlight
+	toHighlight.MatchMode = matchMode
+	m.setToHighlight(toHighlight)
 }
 
 // SetHeader sets the header, an unselectable set of lines at the top of the viewport
@@ -431,6 +575,134 @@ func (m *Model[T]) SetHeader(header []string) {
 	m.content.Header = header
 }
 
+// SetMark sets a named mark on the selected item, or the top visible item if selection is disabled.
+// Marks follow their items when content changes if a selection comparator is set.
+func (m *Model[T]) SetMark(name rune) {
+	if m.content.IsEmpty() {
+		return
+	}
+	m.content.SetMark(name, m.currentItemIdx())
+}
+
+// ClearMark removes the mark with the given name
+func (m *Model[T]) ClearMark(name rune) {
+	m.content.ClearMark(name)
+}
+
+// ClearMarks removes all marks
+func (m *Model[T]) ClearMarks() {
+	m.content.ClearMarks()
+}
+
+// Marks returns the marked items by mark name, e.g. for the caller to persist them
+func (m *Model[T]) Marks() map[rune]T {
+	return m.content.GetMarks()
+}
+
+// SetMarks sets marks on the current content by mark name, e.g. to restore persisted marks.
+// Requires a selection comparator to find the items. Items not found in the content are not marked.
+func (m *Model[T]) SetMarks(marks map[rune]T) {
+	m.content.ClearMarks()
+	for name, item := range marks {
+		if idx := m.content.findItemIdx(item); idx >= 0 {
+			m.content.SetMark(name, idx)
+		}
+	}
+}
+
+// JumpToMark selects the item with the given mark, or scrolls it to the top if selection is disabled.
+// Returns false if there is no such mark.
+func (m *Model[T]) JumpToMark(name rune) bool {
+	m.finishScrollAnimation()
+	idx, ok := m.content.GetMarkIdx(name)
+	if !ok {
+		return false
+	}
+	m.jumpToItemIdx(idx)
+	return true
+}
+
+// NextMark jumps to the next marked item after the current one, wrapping around to the first
+func (m *Model[T]) NextMark() {
+	m.finishScrollAnimation()
+	markedIdxs := m.content.GetMarkedIdxs()
+	if len(markedIdxs) == 0 {
+		return
+	}
+	currentIdx := m.currentItemIdx()
+	for _, idx := range markedIdxs {
+		if idx > currentIdx {
+			m.jumpToItemIdx(idx)
+			return
+		}
+	}
+	m.jumpToItemIdx(markedIdxs[0])
+}
+
+// PrevMark jumps to the previous marked item before the current one, wrapping around to the last
+func (m *Model[T]) PrevMark() {
+	m.finishScrollAnimation()
+	markedIdxs := m.content.GetMarkedIdxs()
+	if len(markedIdxs) == 0 {
+		return
+	}
+	currentIdx := m.currentItemIdx()
+	for i := len(markedIdxs) - 1; i >= 0; i-- {
+		if markedIdxs[i] < currentIdx {
+			m.jumpToItemIdx(markedIdxs[i])
+			return
+		}
+	}
+	m.jumpToItemIdx(markedIdxs[len(markedIdxs)-1])
+}
+
+// NextMatch jumps to the next item after the current one containing the highlighted string or regex, wrapping around
+// to the first. Returns false if no item matches
+func (m *Model[T]) NextMatch() bool {
+	m.finishScrollAnimation()
+	return m.jumpToMatch(1)
+}
+
+// PrevMatch jumps to the previous item before the current one containing the highlighted string or regex, wrapping
+// around to the last. Returns false if no item matches
+func (m *Model[T]) PrevMatch() bool {
+	m.finishScrollAnimation()
+	return m.jumpToMatch(-1)
+}
+
+// FoldItem folds the item at itemIdx so that when wrapped, it shows as a single summary line with the number of hidden
+// lines. Folds stay on the same items when content changes if a selection comparator is set
+func (m *Model[T]) FoldItem(itemIdx int) {
+	m.setFolded(itemIdx, true)
+}
+
+// UnfoldItem unfolds the item at itemIdx
+func (m *Model[T]) UnfoldItem(itemIdx int) {
+	m.setFolded(itemIdx, false)
+}
+
+// ToggleFold folds the item at itemIdx if unfolded, or unfolds it if folded
+func (m *Model[T]) ToggleFold(itemIdx int) {
+	m.setFolded(itemIdx, !m.content.IsFolded(itemIdx))
+}
+
+// IsItemFolded returns true if the item at itemIdx is folded
+func (m *Model[T]) IsItemFolded(itemIdx int) bool {
+	return m.content.IsFolded(itemIdx)
+}
+
+// FoldAll folds every item, including items added by later content changes, until UnfoldAll
+func (m *Model[T]) FoldAll() {
+	m.content.SetAllFolded(true)
+	m.afterFoldChange()
+}
+
+// UnfoldAll unfolds every item
+func (m *Model[T]) UnfoldAll() {
+	m.content.SetAllFolded(false)
+	m.afterFoldChange()
+}
+
 // GetWidth returns the viewport width
 func (m *Model[T]) GetWidth() int {
 	return m.display.Bounds.Width
@@ -441,8 +713,35 @@ func (m *Model[T]) GetHeight() int {
 	return m.display.Bounds.Height
 }
 
+// GetTopItemIdx returns the index of the item at the top of the viewport and the number of its lines scrolled above
+// the top
+func (m *Model[T]) GetTopItemIdx() (int, int) {
+	return m.display.TopItemIdx, m.display.TopItemLineOffset
+}
+
+// SetTopItemIdx scrolls so the item at itemIdx is at the top of the viewport with lineOffset of its lines scrolled
+// above the top, as far as possible
+func (m *Model[T]) SetTopItemIdx(itemIdx, lineOffset int) {
+	m.finishScrollAnimation()
+	m.safelySetTopItemIdxAndOffset(itemIdx, lineOffset)
+}
+
+// GetXOffset returns the number of terminal cells panned horizontally
+func (m *Model[T]) GetXOffset() int {
+	return m.display.XOffset
+}
+
+// SetXOffset pans horizontally to xOffset terminal cells, as far as possible. Has no effect when wrapping is on
+func (m *Model[T]) SetXOffset(xOffset int) {
+	if m.config.WrapText {
+		return
+	}
+	m.safelySetXOffset(xOffset)
+}
+
 // ScrollSoItemIdxInView scrolls the viewport to ensure the specified item index is visible.
 func (m *Model[T]) ScrollSoItemIdxInView(itemIdx int) {
+	m.finishScrollAnimation()
 	if m.content.IsEmpty() {
 		m.safelySetTopItemIdxAndOffset(0, 0)
 		return
@@ -484,6 +783,232 @@ func (m *Model[T]) ScrollSoItemIdxInView(itemIdx int) {
 	}
 }
 
+// navigationContext returns the current context needed to process navigation keys
+func (m *Model[T]) navigationContext() NavigationContext {
+	return NavigationContext{
+		WrapText:        m.config.WrapText,
+		Dimensions:      m.display.Bounds,
+		NumContentLines: m.getNumContentLines(),
+		NumVisibleItems: m.getNumVisibleItems(),
+	}
+}
+
+// applyNavigationResult updates the viewport for the result of processing a navigation key, returning a command
+// for any resulting smooth scroll
+func (m *Model[T]) applyNavigationResult(navResult NavigationResult) tea.Cmd {
+	switch navResult.Action {
+	case ActionUp:
+		if m.navigation.SelectionEnabled {
+			m.selectedItemIdxUp(navResult.SelectionAmount)
+		} else {
+			m.scrollUp(navResult.ScrollAmount)
+		}
+
+	case ActionDown:
+		if m.navigation.SelectionEnabled {
+			m.selectedItemIdxDown(navResult.SelectionAmount)
+		} else {
+			m.scrollDown(navResult.ScrollAmount)
+		}
+
+	case ActionLeft:
+		if !m.config.WrapText {
+			m.viewLeft(navResult.ScrollAmount)
+		}
+
+	case ActionRight:
+		if !m.config.WrapText {
+			m.viewRight(navResult.ScrollAmount)
+		}
+
+	case ActionHalfPageUp:
+		return m.animateScroll(func() {
+			m.scrollUp(navResult.ScrollAmount)
+			if m.navigation.SelectionEnabled {
+				m.selectedItemIdxUp(navResult.SelectionAmount)
+			}
+		})
+
+	case ActionHalfPageDown:
+		return m.animateScroll(func() {
+			m.scrollDown(navResult.ScrollAmount)
+			if m.navigation.SelectionEnabled {
+				m.selectedItemIdxDown(navResult.SelectionAmount)
+			}
+		})
+
+	case ActionPageUp:
+		return m.animateScroll(func() {
+			m.scrollUp(navResult.ScrollAmount)
+			if m.navigation.SelectionEnabled {
+				m.selectedItemIdxUp(navResult.SelectionAmount)
+			}
+		})
+
+	case ActionPageDown:
+		return m.animateScroll(func() {
+			m.scrollDown(navResult.ScrollAmount)
+			if m.navigation.SelectionEnabled {
+				m.selectedItemIdxDown(navResult.SelectionAmount)
+			}
+		})
+
+	case ActionTop:
+		if m.navigation.SelectionEnabled {
+			m.SetSelectedItemIdx(0)
+		} else {
+			m.display.TopItemIdx = 0
+			m.display.TopItemLineOffset = 0
+		}
+
+	case ActionBottom:
+		if m.navigation.SelectionEnabled {
+			m.selectedItemIdxDown(m.content.NumItems())
+		} else {
+			maxItemIdx, maxTopLineOffset := m.maxItemIdxAndMaxTopLineOffset()
+			m.safelySetTopItemIdxAndOffset(maxItemIdx, maxTopLineOffset)
+		}
+
+	case ActionSetMark:
+		m.SetMark(navResult.Mark)
+
+	case ActionJumpToMark:
+		m.JumpToMark(navResult.Mark)
+
+	case ActionNextMark:
+		m.NextMark()
+
+	case ActionPrevMark:
+		m.PrevMark()
+
+	case ActionGoToItem:
+		if !m.content.IsEmpty() {
+			m.jumpToItemIdx(clampValZeroToMax(navResult.Count-1, m.content.NumItems()-1))
+		}
+
+	case ActionGoToPercent:
+		m.ScrollToPercent(navResult.Count)
+
+	case ActionCenterSelection:
+		if m.navigation.SelectionEnabled {
+			m.ScrollToItem(m.content.GetSelectedIdx(), AlignCenter)
+		}
+
+	case ActionTopAlignSelection:
+		if m.navigation.SelectionEnabled {
+			m.ScrollToItem(m.content.GetSelectedIdx(), AlignTop)
+		}
+
+	case ActionBottomAlignSelection:
+		if m.navigation.SelectionEnabled {
+			m.ScrollToItem(m.content.GetSelectedIdx(), AlignBottom)
+		}
+
+	case ActionToggleFold:
+		if !m.content.IsEmpty() {
+			m.ToggleFold(m.currentItemIdx())
+		}
+
+	default:
+		// no-op on keypress that doesn't produce a selection action
+	}
+	return nil
+}
+
+// currentItemIdx returns the selected item index, or the top visible item index if selection is disabled
+func (m *Model[T]) currentItemIdx() int {
+	if m.navigation.SelectionEnabled {
+		return m.content.GetSelectedIdx()
+	}
+	return clampValZeroToMax(m.display.TopItemIdx, m.content.NumItems()-1)
+}
+
+// setToHighlight sets what to highlight, discarding the search for the previous highlight only if it changed
+func (m *Model[T]) setToHighlight(toHighlight linebuffer.HighlightData) {
+	if sameHighlight(m.content.ToHighlight, toHighlight) {
+		return
+	}
+	m.CancelSearch()
+	m.content.ToHighlight = toHighlight
+}
+
+// jumpToItemIdx selects the item at itemIdx, or scrolls it to the top if selection is disabled
+func (m *Model[T]) jumpToItemIdx(itemIdx int) {
+	if m.navigation.SelectionEnabled {
+		m.SetSelectedItemIdx(itemIdx)
+		return
+	}
+	m.ScrollToItem(itemIdx, AlignTop)
+}
+
+// jumpToMatch jumps to the nearest item containing the highlight in the direction of step, checking the current item
+// last. Uses the matches of a finished background search if there is one rather than checking each item
+func (m *Model[T]) jumpToMatch(step int) bool {
+	numItems := m.content.NumItems()
+	if numItems == 0 {
+		return false
+	}
+	currentIdx := m.currentItemIdx()
+	if m.content.search.done {
+		idx := m.searchMatchIdx(currentIdx, step)
+		if idx < 0 {
+			return false
+		}
+		m.jumpToItemIdx(idx)
+		return true
+	}
+	for i := 1; i <= numItems; i++ {
+		idx := ((currentIdx+step*i)%numItems + numItems) % numItems
+		if m.content.ItemMatchesHighlight(idx) {
+			m.jumpToItemIdx(idx)
+			return true
+		}
+	}
+	return false
+}
+
+// ScrollToItem scrolls so the item at itemIdx is at the given alignment in the viewport, as far as the content allows.
+// If selection is enabled, the item is also selected.
+func (m *Model[T]) ScrollToItem(itemIdx int, alignment ItemAlignment) {
+	m.finishScrollAnimation()
+	if m.content.IsEmpty() {
+		m.safelySetTopItemIdxAndOffset(0, 0)
+		return
+	}
+	itemIdx = clampValZeroToMax(itemIdx, m.content.NumItems()-1)
+	if m.navigation.SelectionEnabled {
+		m.content.SetSelectedIdx(itemIdx)
+	}
+
+	numLinesInItem := 1
+	if m.config.WrapText {
+		numLinesInItem = m.numLinesForItem(itemIdx)
+	}
+	m.display.TopItemIdx = itemIdx
+	m.display.TopItemLineOffset = 0
+	switch alignment {
+	case AlignCenter:
+		m.scrollUp(max(0, (m.getNumContentLines()-numLinesInItem)/2))
+	case AlignBottom:
+		m.scrollUp(max(0, m.getNumContentLines()-numLinesInItem))
+	default:
+	}
+	m.safelySetTopItemIdxAndOffset(m.display.TopItemIdx, m.display.TopItemLineOffset)
+}
+
+// ScrollToPercent goes to the item p percent of the way through the content. The item is selected if selection is
+// enabled, otherwise it is scrolled to the top of the viewport
+func (m *Model[T]) ScrollToPercent(p int) {
+	m.finishScrollAnimation()
+	if m.content.IsEmpty() {
+		return
+	}
+	p = max(0, min(100, p))
+	numItems := m.content.NumItems()
+	itemIdx := (p*numItems+99)/100 - 1
+	m.jumpToItemIdx(clampValZeroToMax(itemIdx, numItems-1))
+}
+
 func (m *Model[T]) maxLineWidth() int {
 	maxLineWidth := 0
 
@@ -518,17 +1043,59 @@ func (m *Model[T]) numLinesForItem(itemIdx int) int {
 	if m.content.IsEmpty() || itemIdx < 0 || itemIdx >= m.content.NumItems() {
 		return 0
 	}
+	if m.content.IsFolded(itemIdx) {
+		return 1
+	}
 	items := m.content.Items
 	lb := items[itemIdx].Render()
 	return len(lb.WrappedLines(m.display.Bounds.Width, m.display.Bounds.Height, linebuffer.HighlightData{}, lipgloss.NewStyle()))
 }
 
+// wrappedItemLines returns the wrapped lines of the item at itemIdx, or a single summary line if the item is folded
+func (m *Model[T]) wrappedItemLines(itemIdx int) []string {
+	lb := m.content.Items[itemIdx].Render()
+	itemLines := lb.WrappedLines(m.display.Bounds.Width, m.display.Bounds.Height, m.content.ToHighlight, m.highlightStyle(itemIdx))
+	if len(itemLines) <= 1 || !m.content.IsFolded(itemIdx) {
+		return itemLines
+	}
+
+	// as much of the first line as fits before the summary of hidden lines
+	summary := fmt.Sprintf("[+%d lines]", len(itemLines)-1)
+	firstLine := linebuffer.New(itemLines[0])
+	first, firstWidth := firstLine.Take(0, max(0, m.display.Bounds.Width-lipgloss.Width(summary)-1), "", linebuffer.HighlightData{}, lipgloss.NewStyle())
+	if firstWidth > 0 {
+		first += " "
+		firstWidth++
+	}
+	summaryLine := linebuffer.New(summary)
+	summary, _ = summaryLine.Take(0, m.display.Bounds.Width-firstWidth, "", linebuffer.HighlightData{}, lipgloss.NewStyle())
+	return []string{first + m.styles().FoldSummaryStyle.Render(summary)}
+}
+
+func (m *Model[T]) setFolded(itemIdx int, folded bool) {
+	m.content.SetFolded(itemIdx, folded)
+	m.afterFoldChange()
+}
+
+// afterFoldChange keeps the scroll position valid and the selection in view after items are folded or unfolded
+func (m *Model[T]) afterFoldChange() {
+	m.finishScrollAnimation()
+	if m.content.IsFolded(m.display.TopItemIdx) {
+		m.display.TopItemLineOffset = 0
+	}
+	if m.navigation.SelectionEnabled {
+		m.scrollSoSelectionInView()
+	}
+	m.safelySetTopItemIdxAndOffset(m.display.TopItemIdx, m.display.TopItemLineOffset)
+}
+
 func (m *Model[T]) safelySetXOffset(n int) {
 	maxXOffset := m.maxLineWidth() - m.display.Bounds.Width
 	m.display.XOffset = max(0, min(maxXOffset, n))
 }
 
 func (m *Model[T]) setWidthHeight(width, height int) {
+	m.finishScrollAnimation()
 	m.display.SetBounds(width, height)
 	if m.navigation.SelectionEnabled {
 		m.scrollSoSelectionInView()
@@ -554,6 +1121,41 @@ func (m *Model[T]) scrollSoSelectionInView() {
 	m.ScrollSoItemIdxInView(m.content.GetSelectedIdx())
 }
 
+// scrollSoSelectionHasContext scrolls so that ScrollOff lines are visible above and below the selection, or so that
+// the selection is vertically centered if KeepSelectionCentered, as far as the content allows
+func (m *Model[T]) scrollSoSelectionHasContext() {
+	if m.config.ScrollOff <= 0 && !m.config.KeepSelectionCentered {
+		return
+	}
+	inView := m.selectionInViewInfo()
+	if inView.numLinesSelectionInView == 0 {
+		return
+	}
+
+	numLinesInSelection := 1
+	if m.config.WrapText {
+		numLinesInSelection = m.numLinesForItem(m.content.GetSelectedIdx())
+	}
+	numLinesAround := m.getNumContentLines() - numLinesInSelection
+	if numLinesAround <= 0 {
+		// selection takes up the whole screen
+		return
+	}
+
+	numLinesAbove := inView.numLinesAboveSelection
+	numLinesBelow := numLinesAround - numLinesAbove
+	if m.config.KeepSelectionCentered {
+		m.scrollByNLines(numLinesAbove - numLinesAround/2)
+		return
+	}
+	margin := min(m.config.ScrollOff, numLinesAround/2)
+	if numLinesAbove < margin {
+		m.scrollUp(margin - numLinesAbove)
+	} else if numLinesBelow < margin {
+		m.scrollDown(margin - numLinesBelow)
+	}
+}
+
 func (m *Model[T]) selectedItemIdxDown(n int) {
 	m.SetSelectedItemIdx(m.content.GetSelectedIdx() + n)
 }
@@ -726,8 +1328,7 @@ func (m *Model[T]) getVisibleContentLines() visibleContentLinesResult {
 	}
 
 	if m.config.WrapText {
-		lb := currItem.Render()
-		itemLines := lb.WrappedLines(m.display.Bounds.Width, m.display.Bounds.Height, m.content.ToHighlight, m.highlightStyle(currItemIdx))
+		itemLines := m.wrappedItemLines(currItemIdx)
 		offsetLines := safeSliceFromIdx(itemLines, m.display.TopItemLineOffset)
 		done = addLines(toLineBuffers(offsetLines), currItemIdx)
 
@@ -736,9 +1337,7 @@ func (m *Model[T]) getVisibleContentLines() visibleContentLinesResult {
 			if currItemIdx >= m.content.NumItems() {
 				done = true
 			} else {
-				currItem = items[currItemIdx]
-				lb = currItem.Render()
-				itemLines = lb.WrappedLines(m.display.Bounds.Width, m.display.Bounds.Height, m.content.ToHighlight, m.highlightStyle(currItemIdx))
+				itemLines = m.wrappedItemLines(currItemIdx)
 				done = addLines(toLineBuffers(itemLines), currItemIdx)
 			}
 		}
@@ -781,7 +1380,12 @@ func (m *Model[T]) getVisibleContentLines() visibleContentLinesResult {
 }
 
 func (m *Model[T]) highlightStyle(itemIdx int) lipgloss.Style {
-	return m.display.GetHighlightStyle(m.navigation.SelectionEnabled && itemIdx == m.content.GetSelectedIdx())
+	return m.display.GetHighlightStyle(m.navigation.SelectionEnabled && itemIdx == m.content.GetSelectedIdx(), m.navigation.Focused)
+}
+
+// styles returns the styling configuration for the current focus state
+func (m *Model[T]) styles() Styles {
+	return m.display.GetStyles(m.navigation.Focused)
 }
 
 func (m *Model[T]) getTruncatedFooterLine(visibleContentLines visibleContentLinesResult) string {
@@ -808,7 +1412,39 @@ func (m *Model[T]) getTruncatedFooterLine(visibleContentLines visibleContentLine
 
 	footerBuffer := linebuffer.New(footerString)
 	f, _ := footerBuffer.Take(0, m.display.Bounds.Width, m.config.ContinuationIndicator, linebuffer.HighlightData{}, lipgloss.NewStyle())
-	return m.display.Styles.FooterStyle.Render(f)
+	return m.styles().FooterStyle.Render(f)
+}
+
+// takeLine returns the part of an unwrapped line that is visible when panned right by xOffset. The first FrozenWidth
+// cells stay in place, with the rest of the line panning after them
+func (m *Model[T]) takeLine(
+	lineBuffer linebuffer.LineBufferer,
+	xOffset int,
+	toHighlight linebuffer.HighlightData,
+	highlightStyle lipgloss.Style,
+) string {
+	width := m.display.Bounds.Width
+	frozenWidth := min(m.config.FrozenWidth, width)
+	if frozenWidth <= 0 || xOffset == 0 {
+		line, _ := lineBuffer.Take(xOffset, width, m.config.ContinuationIndicator, toHighlight, highlightStyle)
+		return line
+	}
+	if lineBuffer.Width() <= frozenWidth {
+		// line is entirely frozen
+		line, _ := lineBuffer.Take(0, width, m.config.ContinuationIndicator, toHighlight, highlightStyle)
+		return line
+	}
+
+	frozen, frozenTaken := lineBuffer.Take(0, frozenWidth, "", toHighlight, highlightStyle)
+	// pad in case a wide rune didn't fit before the freeze boundary
+	frozen += strings.Repeat(" ", frozenWidth-frozenTaken)
+
+	panned, _ := lineBuffer.Take(frozenWidth+xOffset, width-frozenWidth, m.config.ContinuationIndicator, toHighlight, highlightStyle)
+	if panned == "" {
+		// if panned right past where line ends, show continuation indicator at the freeze boundary
+		panned, _ = linebuffer.New(m.config.ContinuationIndicator).Take(0, width-frozenWidth, "", linebuffer.HighlightData{}, lipgloss.NewStyle())
+	}
+	return frozen + panned
 }
 
 func (m *Model[T]) getLineContinuationIndicator() string {
@@ -909,17 +1545,39 @@ func (m *Model[T]) getNumVisibleItems() int {
 }
 
 func (m *Model[T]) styleSelection(selection string) string {
-	split := surroundingAnsiRegex.Split(selection, -1)
-	matches := surroundingAnsiRegex.FindAllString(selection, -1)
+	return styleSections(selection, m.styles().SelectedItemStyle)
+}
+
+// styleUnderneath applies style to all of s as if it were styled before any of the ansi codes in s, so they take
+// precedence over it
+func styleUnderneath(s string, style lipgloss.Style) string {
+	// only the ansi codes style starts text with are needed, not its layout
+	rendered := style.Inline(true).UnsetWidth().UnsetHeight().UnsetMaxWidth().UnsetMaxHeight().Render("x")
+	opening := rendered[:max(0, strings.Index(rendered, "x"))]
+	if opening == "" || s == "" {
+		return s
+	}
+	// restart the style after each reset
+	styled := opening + ansiResetRegex.ReplaceAllLiteralString(s, "\x1b[m"+opening)
+	if trimmed, ok := strings.CutSuffix(styled, "\x1b[m"+opening); ok {
+		return trimmed + "\x1b[m"
+	}
+	return styled + "\x1b[m"
+}
+
+// styleSections applies style to the sections of s that are not already styled by ansi codes
+func styleSections(s string, style lipgloss.Style) string {
+	split := surroundingAnsiRegex.Split(s, -1)
+	matches := surroundingAnsiRegex.FindAllString(s, -1)
 	var builder strings.Builder
 
-	// pre-allocate the builder's capacity based on the selection string length
+	// pre-allocate the builder's capacity based on the string length
 	// optional but can improve performance for longer strings
-	builder.Grow(len(selection))
+	builder.Grow(len(s))
 
 	for i, section := range split {
 		if section != "" {
-			builder.WriteString(m.display.Styles.SelectedItemStyle.Render(section))
+			builder.WriteString(style.Render(section))
 		}
 		if i < len(split)-1 && i < len(matches) {
 			builder.WriteString(matches[i])
@@ -928,6 +1586,19 @@ func (m *Model[T]) styleSelection(selection string) string {
 	return builder.String()
 }
 
+// sameHighlight returns true if a and b highlight the same text, comparing regexes by their patterns
+func sameHighlight(a, b linebuffer.HighlightData) bool {
+	if a.StringToHighlight != b.StringToHighlight || a.IsRegex != b.IsRegex || a.CaseMode != b.CaseMode ||
+		a.MatchMode != b.MatchMode {
+		return false
+	}
+	ra, rb := a.RegexPatternToHighlight, b.RegexPatternToHighlight
+	if ra == nil || rb == nil {
+		return ra == rb
+	}
+	return ra.String() == rb.String()
+}
+
 func toLineBuffers(lines []string) []linebuffer.LineBufferer {
 	res := make([]linebuffer.LineBufferer, len(lines))
 	for i, line := range lines {
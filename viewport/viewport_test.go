@@ -1,7 +1,9 @@
 package viewport
 
 import (
+	"fmt"
 	"regexp"
+	"strconv"
 	"strings"
 	"testing"
 	"time"
@@ -9,7 +11,8 @@ import (
 	"github.com/charmbracelet/bubbles/v2/key"
 	tea "github.com/charmbracelet/bubbletea/v2"
 	"github.com/charmbracelet/lipgloss/v2"
-	"github.com/robinovitch61/bubbleo/viewport/internal"
+	"github.com/robinovitch61/bubbleo/internal/testutil"
+	"github.com/robinovitch61/bubbleo/viewport/linebuffer"
 )
 
 var (
@@ -21,6 +24,14 @@ var (
 	fullPgUpKeyMsg   = tea.KeyPressMsg{Code: 'b', Text: "b"}
 	goToTopKeyMsg    = tea.KeyPressMsg{Code: 'g', Text: "g"}
 	goToBottomKeyMsg = tea.KeyPressMsg{Code: 'g', Text: "g", Mod: tea.ModShift}
+	setMarkKeyMsg    = tea.KeyPressMsg{Code: 'm', Text: "m"}
+	jumpToMarkKeyMsg = tea.KeyPressMsg{Code: '\'', Text: "'"}
+	nextMarkKeyMsg   = tea.KeyPressMsg{Code: ']', Text: "]"}
+	prevMarkKeyMsg   = tea.KeyPressMsg{Code: '[', Text: "["}
+	percentKeyMsg    = tea.KeyPressMsg{Code: '%', Text: "%"}
+	aKeyMsg          = tea.KeyPressMsg{Code: 'a', Text: "a"}
+	xKeyMsg          = tea.KeyPressMsg{Code: 'x', Text: "x"}
+	zKeyMsg          = tea.KeyPressMsg{Code: 'z', Text: "z"}
 	red              = lipgloss.Color("#ff0000")
 	blue             = lipgloss.Color("#0000ff")
 	green            = lipgloss.Color("#00ff00")
@@ -69,6 +80,42 @@ func newViewport(width, height int) Model[RenderableString] {
 			key.WithKeys("shift+g"),
 			key.WithHelp("G", "bottom"),
 		),
+		Percent: key.NewBinding(
+			key.WithKeys("%"),
+			key.WithHelp("%", "go to percent"),
+		),
+		SetMark: key.NewBinding(
+			key.WithKeys("m"),
+			key.WithHelp("m", "set mark"),
+		),
+		JumpToMark: key.NewBinding(
+			key.WithKeys("'"),
+			key.WithHelp("'", "jump to mark"),
+		),
+		NextMark: key.NewBinding(
+			key.WithKeys("]"),
+			key.WithHelp("]", "next mark"),
+		),
+		PrevMark: key.NewBinding(
+			key.WithKeys("["),
+			key.WithHelp("[", "previous mark"),
+		),
+		CenterSelection: key.NewBinding(
+			key.WithKeys("z z"),
+			key.WithHelp("zz", "center selection"),
+		),
+		TopAlignSelection: key.NewBinding(
+			key.WithKeys("z t"),
+			key.WithHelp("zt", "selection to top"),
+		),
+		BottomAlignSelection: key.NewBinding(
+			key.WithKeys("z b"),
+			key.WithHelp("zb", "selection to bottom"),
+		),
+		ToggleFold: key.NewBinding(
+			key.WithKeys("z a"),
+			key.WithHelp("za", "toggle fold"),
+		),
 	}
 	styles := Styles{
 		FooterStyle:              lipgloss.NewStyle(),
@@ -85,11 +132,11 @@ func newViewport(width, height int) Model[RenderableString] {
 func TestViewport_SelectionOff_WrapOff_Empty(t *testing.T) {
 	w, h := 15, 5
 	vp := newViewport(w, h)
-	expectedView := pad(vp.GetWidth(), vp.GetHeight(), []string{})
-	internal.CmpStr(t, expectedView, vp.View())
+	expectedView := testutil.Pad(vp.GetWidth(), vp.GetHeight(), []string{})
+	testutil.CmpStr(t, expectedView, vp.View())
 	vp.SetHeader([]string{"header"})
-	expectedView = pad(vp.GetWidth(), vp.GetHeight(), []string{"header"})
-	internal.CmpStr(t, expectedView, vp.View())
+	expectedView = testutil.Pad(vp.GetWidth(), vp.GetHeight(), []string{"header"})
+	testutil.CmpStr(t, expectedView, vp.View())
 }
 
 func TestViewport_SelectionOff_WrapOff_SmolDimensions(t *testing.T) {
@@ -97,23 +144,23 @@ func TestViewport_SelectionOff_WrapOff_SmolDimensions(t *testing.T) {
 	vp := newViewport(w, h)
 	vp.SetHeader([]string{"header"})
 	setContent(&vp, []string{"hi"})
-	expectedView := pad(vp.GetWidth(), vp.GetHeight(), []string{""})
-	internal.CmpStr(t, expectedView, vp.View())
+	expectedView := testutil.Pad(vp.GetWidth(), vp.GetHeight(), []string{""})
+	testutil.CmpStr(t, expectedView, vp.View())
 
 	vp.SetWidth(1)
 	vp.SetHeight(1)
-	expectedView = pad(vp.GetWidth(), vp.GetHeight(), []string{"."})
-	internal.CmpStr(t, expectedView, vp.View())
+	expectedView = testutil.Pad(vp.GetWidth(), vp.GetHeight(), []string{"."})
+	testutil.CmpStr(t, expectedView, vp.View())
 
 	vp.SetWidth(2)
 	vp.SetHeight(2)
-	expectedView = pad(vp.GetWidth(), vp.GetHeight(), []string{"..", ""})
-	internal.CmpStr(t, expectedView, vp.View())
+	expectedView = testutil.Pad(vp.GetWidth(), vp.GetHeight(), []string{"..", ""})
+	testutil.CmpStr(t, expectedView, vp.View())
 
 	vp.SetWidth(3)
 	vp.SetHeight(3)
-	expectedView = pad(vp.GetWidth(), vp.GetHeight(), []string{"...", "hi", "..."})
-	internal.CmpStr(t, expectedView, vp.View())
+	expectedView = testutil.Pad(vp.GetWidth(), vp.GetHeight(), []string{"...", "hi", "..."})
+	testutil.CmpStr(t, expectedView, vp.View())
 }
 
 func TestViewport_SelectionOff_WrapOff_Basic(t *testing.T) {
@@ -126,7 +173,7 @@ func TestViewport_SelectionOff_WrapOff_Basic(t *testing.T) {
 		lipgloss.NewStyle().Foreground(red).Render("a really really long line"),
 		lipgloss.NewStyle().Foreground(red).Render("a") + " really really long line",
 	})
-	expectedView := pad(vp.GetWidth(), vp.GetHeight(), []string{
+	expectedView := testutil.Pad(vp.GetWidth(), vp.GetHeight(), []string{
 		"header",
 		"first line",
 		"\x1b[38;2;255;0;0msecond\x1b[m line",
@@ -134,7 +181,7 @@ func TestViewport_SelectionOff_WrapOff_Basic(t *testing.T) {
 		"\x1b[38;2;255;0;0ma\x1b[m really rea...",
 		"100% (4/4)",
 	})
-	internal.CmpStr(t, expectedView, vp.View())
+	testutil.CmpStr(t, expectedView, vp.View())
 }
 
 func TestViewport_SelectionOff_WrapOff_GetConfigs(t *testing.T) {
@@ -169,17 +216,17 @@ func TestViewport_SelectionOff_WrapOff_ShowFooter(t *testing.T) {
 		lipgloss.NewStyle().Foreground(red).Render("a really really long line"),
 		lipgloss.NewStyle().Foreground(red).Render("a") + " really really long line",
 	})
-	expectedView := pad(vp.GetWidth(), vp.GetHeight(), []string{
+	expectedView := testutil.Pad(vp.GetWidth(), vp.GetHeight(), []string{
 		"header",
 		"first line",
 		"\x1b[38;2;255;0;0msecond\x1b[m line",
 		"\x1b[38;2;255;0;0ma really rea...\x1b[m",
 		"75% (3/4)",
 	})
-	internal.CmpStr(t, expectedView, vp.View())
+	testutil.CmpStr(t, expectedView, vp.View())
 
 	vp.SetHeight(6)
-	expectedView = pad(vp.GetWidth(), vp.GetHeight(), []string{
+	expectedView = testutil.Pad(vp.GetWidth(), vp.GetHeight(), []string{
 		"header",
 		"first line",
 		"\x1b[38;2;255;0;0msecond\x1b[m line",
@@ -187,17 +234,17 @@ func TestViewport_SelectionOff_WrapOff_ShowFooter(t *testing.T) {
 		"\x1b[38;2;255;0;0ma\x1b[m really rea...",
 		"100% (4/4)",
 	})
-	internal.CmpStr(t, expectedView, vp.View())
+	testutil.CmpStr(t, expectedView, vp.View())
 
 	vp.SetHeight(7)
-	expectedView = pad(vp.GetWidth(), vp.GetHeight(), []string{
+	expectedView = testutil.Pad(vp.GetWidth(), vp.GetHeight(), []string{
 		"header",
 		"first line",
 		"\x1b[38;2;255;0;0msecond\x1b[m line",
 		"\x1b[38;2;255;0;0ma really rea...\x1b[m",
 		"\x1b[38;2;255;0;0ma\x1b[m really rea...",
 	})
-	internal.CmpStr(t, expectedView, vp.View())
+	testutil.CmpStr(t, expectedView, vp.View())
 }
 
 func TestViewport_SelectionOff_WrapOff_FooterStyle(t *testing.T) {
@@ -216,14 +263,14 @@ func TestViewport_SelectionOff_WrapOff_FooterStyle(t *testing.T) {
 		"3",
 		"4",
 	})
-	expectedView := pad(vp.GetWidth(), vp.GetHeight(), []string{
+	expectedView := testutil.Pad(vp.GetWidth(), vp.GetHeight(), []string{
 		"header",
 		"1",
 		"2",
 		"3",
 		"\x1b[38;2;255;0;0m75% (3/4)\x1b[m",
 	})
-	internal.CmpStr(t, expectedView, vp.View())
+	testutil.CmpStr(t, expectedView, vp.View())
 }
 
 func TestViewport_SelectionOff_WrapOff_FooterDisabled(t *testing.T) {
@@ -236,24 +283,24 @@ func TestViewport_SelectionOff_WrapOff_FooterDisabled(t *testing.T) {
 		"third line",
 		"fourth line",
 	})
-	expectedView := pad(vp.GetWidth(), vp.GetHeight(), []string{
+	expectedView := testutil.Pad(vp.GetWidth(), vp.GetHeight(), []string{
 		"header",
 		"first line",
 		"second line",
 		"third line",
 		"75% (3/4)",
 	})
-	internal.CmpStr(t, expectedView, vp.View())
+	testutil.CmpStr(t, expectedView, vp.View())
 
 	vp.SetFooterEnabled(false)
-	expectedView = pad(vp.GetWidth(), vp.GetHeight(), []string{
+	expectedView = testutil.Pad(vp.GetWidth(), vp.GetHeight(), []string{
 		"header",
 		"first line",
 		"second line",
 		"third line",
 		"fourth line",
 	})
-	internal.CmpStr(t, expectedView, vp.View())
+	testutil.CmpStr(t, expectedView, vp.View())
 }
 
 func TestViewport_SelectionOff_WrapOff_SpaceAround(t *testing.T) {
@@ -265,14 +312,14 @@ func TestViewport_SelectionOff_WrapOff_SpaceAround(t *testing.T) {
 		"          first line          ",
 		"               first line               ",
 	})
-	expectedView := pad(vp.GetWidth(), vp.GetHeight(), []string{
+	expectedView := testutil.Pad(vp.GetWidth(), vp.GetHeight(), []string{
 		"header",
 		"    first li...",
 		"          fi...",
 		"            ...",
 		"100% (3/3)",
 	})
-	internal.CmpStr(t, expectedView, vp.View())
+	testutil.CmpStr(t, expectedView, vp.View())
 }
 
 func TestViewport_SelectionOff_WrapOff_MultiHeader(t *testing.T) {
@@ -283,55 +330,55 @@ func TestViewport_SelectionOff_WrapOff_MultiHeader(t *testing.T) {
 		"line1",
 		"line2",
 	})
-	expectedView := pad(vp.GetWidth(), vp.GetHeight(), []string{
+	expectedView := testutil.Pad(vp.GetWidth(), vp.GetHeight(), []string{
 		"header1",
 		"header2",
 	})
-	internal.CmpStr(t, expectedView, vp.View())
+	testutil.CmpStr(t, expectedView, vp.View())
 
 	vp.SetHeight(3)
-	expectedView = pad(vp.GetWidth(), vp.GetHeight(), []string{
+	expectedView = testutil.Pad(vp.GetWidth(), vp.GetHeight(), []string{
 		"header1",
 		"header2",
 	})
-	internal.CmpStr(t, expectedView, vp.View())
+	testutil.CmpStr(t, expectedView, vp.View())
 
 	vp.SetHeight(4)
-	expectedView = pad(vp.GetWidth(), vp.GetHeight(), []string{
+	expectedView = testutil.Pad(vp.GetWidth(), vp.GetHeight(), []string{
 		"header1",
 		"header2",
 		"line1",
 		"50% (1/2)",
 	})
-	internal.CmpStr(t, expectedView, vp.View())
+	testutil.CmpStr(t, expectedView, vp.View())
 
 	vp, _ = vp.Update(downKeyMsg)
-	expectedView = pad(vp.GetWidth(), vp.GetHeight(), []string{
+	expectedView = testutil.Pad(vp.GetWidth(), vp.GetHeight(), []string{
 		"header1",
 		"header2",
 		"line2",
 		"100% (2/2)",
 	})
-	internal.CmpStr(t, expectedView, vp.View())
+	testutil.CmpStr(t, expectedView, vp.View())
 
 	vp.SetHeight(5)
-	expectedView = pad(vp.GetWidth(), vp.GetHeight(), []string{
+	expectedView = testutil.Pad(vp.GetWidth(), vp.GetHeight(), []string{
 		"header1",
 		"header2",
 		"line1",
 		"line2",
 		"100% (2/2)",
 	})
-	internal.CmpStr(t, expectedView, vp.View())
+	testutil.CmpStr(t, expectedView, vp.View())
 
 	vp.SetHeight(6)
-	expectedView = pad(vp.GetWidth(), vp.GetHeight(), []string{
+	expectedView = testutil.Pad(vp.GetWidth(), vp.GetHeight(), []string{
 		"header1",
 		"header2",
 		"line1",
 		"line2",
 	})
-	internal.CmpStr(t, expectedView, vp.View())
+	testutil.CmpStr(t, expectedView, vp.View())
 }
 
 func TestViewport_SelectionOff_WrapOff_OverflowLine(t *testing.T) {
@@ -342,12 +389,12 @@ func TestViewport_SelectionOff_WrapOff_OverflowLine(t *testing.T) {
 		"123456789012345",
 		"1234567890123456",
 	})
-	expectedView := pad(vp.GetWidth(), vp.GetHeight(), []string{
+	expectedView := testutil.Pad(vp.GetWidth(), vp.GetHeight(), []string{
 		"long header ...",
 		"123456789012345",
 		"123456789012...",
 	})
-	internal.CmpStr(t, expectedView, vp.View())
+	testutil.CmpStr(t, expectedView, vp.View())
 }
 
 func TestViewport_SelectionOff_WrapOff_OverflowHeight(t *testing.T) {
@@ -362,7 +409,7 @@ func TestViewport_SelectionOff_WrapOff_OverflowHeight(t *testing.T) {
 		"1234567890123456",
 		"1234567890123456",
 	})
-	expectedView := pad(vp.GetWidth(), vp.GetHeight(), []string{
+	expectedView := testutil.Pad(vp.GetWidth(), vp.GetHeight(), []string{
 		"header",
 		"123456789012345",
 		"123456789012...",
@@ -370,7 +417,7 @@ func TestViewport_SelectionOff_WrapOff_OverflowHeight(t *testing.T) {
 		"123456789012...",
 		"66% (4/6)",
 	})
-	internal.CmpStr(t, expectedView, vp.View())
+	testutil.CmpStr(t, expectedView, vp.View())
 }
 
 func TestViewport_SelectionOff_WrapOff_Scrolling(t *testing.T) {
@@ -389,12 +436,12 @@ func TestViewport_SelectionOff_WrapOff_Scrolling(t *testing.T) {
 	}
 	validate := func(expectedView string) {
 		// set content multiple times to confirm no side effects of doing it
-		internal.CmpStr(t, expectedView, vp.View())
+		testutil.CmpStr(t, expectedView, vp.View())
 		doSetContent()
-		internal.CmpStr(t, expectedView, vp.View())
+		testutil.CmpStr(t, expectedView, vp.View())
 	}
 	doSetContent()
-	expectedView := pad(vp.GetWidth(), vp.GetHeight(), []string{
+	expectedView := testutil.Pad(vp.GetWidth(), vp.GetHeight(), []string{
 		"header",
 		"first",
 		"second",
@@ -410,7 +457,7 @@ func TestViewport_SelectionOff_WrapOff_Scrolling(t *testing.T) {
 
 	// scrolling down by one
 	vp, _ = vp.Update(downKeyMsg)
-	expectedView = pad(vp.GetWidth(), vp.GetHeight(), []string{
+	expectedView = testutil.Pad(vp.GetWidth(), vp.GetHeight(), []string{
 		"header",
 		"second",
 		"third",
@@ -422,7 +469,7 @@ func TestViewport_SelectionOff_WrapOff_Scrolling(t *testing.T) {
 
 	// scrolling down by one again
 	vp, _ = vp.Update(downKeyMsg)
-	expectedView = pad(vp.GetWidth(), vp.GetHeight(), []string{
+	expectedView = testutil.Pad(vp.GetWidth(), vp.GetHeight(), []string{
 		"header",
 		"third",
 		"fourth",
@@ -449,33 +496,33 @@ func TestViewport_SelectionOff_WrapOff_ScrollToItem(t *testing.T) {
 		"fifth",
 		"sixth",
 	})
-	expectedView := pad(vp.GetWidth(), vp.GetHeight(), []string{
+	expectedView := testutil.Pad(vp.GetWidth(), vp.GetHeight(), []string{
 		"header",
 		"first",
 		"second",
 		"33% (2/6)",
 	})
-	internal.CmpStr(t, expectedView, vp.View())
+	testutil.CmpStr(t, expectedView, vp.View())
 
 	// scroll so last item in view
 	vp.ScrollSoItemIdxInView(5)
-	expectedView = pad(vp.GetWidth(), vp.GetHeight(), []string{
+	expectedView = testutil.Pad(vp.GetWidth(), vp.GetHeight(), []string{
 		"header",
 		"fifth",
 		"sixth",
 		"100% (6/6)",
 	})
-	internal.CmpStr(t, expectedView, vp.View())
+	testutil.CmpStr(t, expectedView, vp.View())
 
 	// scroll so second item in view
 	vp.ScrollSoItemIdxInView(1)
-	expectedView = pad(vp.GetWidth(), vp.GetHeight(), []string{
+	expectedView = testutil.Pad(vp.GetWidth(), vp.GetHeight(), []string{
 		"header",
 		"second",
 		"third",
 		"50% (3/6)",
 	})
-	internal.CmpStr(t, expectedView, vp.View())
+	testutil.CmpStr(t, expectedView, vp.View())
 }
 
 func TestViewport_SelectionOff_WrapOff_BulkScrolling(t *testing.T) {
@@ -490,93 +537,93 @@ func TestViewport_SelectionOff_WrapOff_BulkScrolling(t *testing.T) {
 		"fifth",
 		"sixth",
 	})
-	expectedView := pad(vp.GetWidth(), vp.GetHeight(), []string{
+	expectedView := testutil.Pad(vp.GetWidth(), vp.GetHeight(), []string{
 		"header",
 		"first",
 		"second",
 		"33% (2/6)",
 	})
-	internal.CmpStr(t, expectedView, vp.View())
+	testutil.CmpStr(t, expectedView, vp.View())
 
 	// full page down
 	vp, _ = vp.Update(fullPgDownKeyMsg)
-	expectedView = pad(vp.GetWidth(), vp.GetHeight(), []string{
+	expectedView = testutil.Pad(vp.GetWidth(), vp.GetHeight(), []string{
 		"header",
 		"third",
 		"fourth",
 		"66% (4/6)",
 	})
-	internal.CmpStr(t, expectedView, vp.View())
+	testutil.CmpStr(t, expectedView, vp.View())
 
 	// half page down
 	vp, _ = vp.Update(halfPgDownKeyMsg)
-	expectedView = pad(vp.GetWidth(), vp.GetHeight(), []string{
+	expectedView = testutil.Pad(vp.GetWidth(), vp.GetHeight(), []string{
 		"header",
 		"fourth",
 		"fifth",
 		"83% (5/6)",
 	})
-	internal.CmpStr(t, expectedView, vp.View())
+	testutil.CmpStr(t, expectedView, vp.View())
 
 	// full page down
 	vp, _ = vp.Update(fullPgDownKeyMsg)
-	expectedView = pad(vp.GetWidth(), vp.GetHeight(), []string{
+	expectedView = testutil.Pad(vp.GetWidth(), vp.GetHeight(), []string{
 		"header",
 		"fifth",
 		"sixth",
 		"100% (6/6)",
 	})
-	internal.CmpStr(t, expectedView, vp.View())
+	testutil.CmpStr(t, expectedView, vp.View())
 
 	// full page up
 	vp, _ = vp.Update(fullPgUpKeyMsg)
-	expectedView = pad(vp.GetWidth(), vp.GetHeight(), []string{
+	expectedView = testutil.Pad(vp.GetWidth(), vp.GetHeight(), []string{
 		"header",
 		"third",
 		"fourth",
 		"66% (4/6)",
 	})
-	internal.CmpStr(t, expectedView, vp.View())
+	testutil.CmpStr(t, expectedView, vp.View())
 
 	// half page up
 	vp, _ = vp.Update(halfPgUpKeyMsg)
-	expectedView = pad(vp.GetWidth(), vp.GetHeight(), []string{
+	expectedView = testutil.Pad(vp.GetWidth(), vp.GetHeight(), []string{
 		"header",
 		"second",
 		"third",
 		"50% (3/6)",
 	})
-	internal.CmpStr(t, expectedView, vp.View())
+	testutil.CmpStr(t, expectedView, vp.View())
 
 	// full page up
 	vp, _ = vp.Update(fullPgUpKeyMsg)
-	expectedView = pad(vp.GetWidth(), vp.GetHeight(), []string{
+	expectedView = testutil.Pad(vp.GetWidth(), vp.GetHeight(), []string{
 		"header",
 		"first",
 		"second",
 		"33% (2/6)",
 	})
-	internal.CmpStr(t, expectedView, vp.View())
+	testutil.CmpStr(t, expectedView, vp.View())
 
 	// go to bottom
 	vp, _ = vp.Update(goToBottomKeyMsg)
-	expectedView = pad(vp.GetWidth(), vp.GetHeight(), []string{
+	expectedView = testutil.Pad(vp.GetWidth(), vp.GetHeight(), []string{
 		"header",
 		"fifth",
 		"sixth",
 		"100% (6/6)",
 	})
-	internal.CmpStr(t, expectedView, vp.View())
+	testutil.CmpStr(t, expectedView, vp.View())
 
 	// go to top
 	vp, _ = vp.Update(goToTopKeyMsg)
-	expectedView = pad(vp.GetWidth(), vp.GetHeight(), []string{
+	expectedView = testutil.Pad(vp.GetWidth(), vp.GetHeight(), []string{
 		"header",
 		"first",
 		"second",
 		"33% (2/6)",
 	})
-	internal.CmpStr(t, expectedView, vp.View())
+	testutil.CmpStr(t, expectedView, vp.View())
 }
 
 func TestViewport_SelectionOff_WrapOff_Panning(t *testing.T) {
@@ -595,12 +642,12 @@ func TestViewport_SelectionOff_WrapOff_Panning(t *testing.T) {
 	}
 	validate := func(expectedView string) {
 		// set content multiple times to confirm no side effects of doing it
-		internal.CmpStr(t, expectedView, vp.View())
+		testutil.CmpStr(t, expectedView, vp.View())
 		doSetContent()
-		internal.CmpStr(t, expectedView, vp.View())
+		testutil.CmpStr(t, expectedView, vp.View())
 	}
 	doSetContent()
-	expectedView := pad(vp.GetWidth(), vp.GetHeight(), []string{
+	expectedView := testutil.Pad(vp.GetWidth(), vp.GetHeight(), []string{
 		"header ...",
 		"first l...",
 		"second ...",
@@ -612,7 +659,7 @@ func TestViewport_SelectionOff_WrapOff_Panning(t *testing.T) {
 
 	// pan right
 	vp.safelySetXOffset(5)
-	expectedView = pad(vp.GetWidth(), vp.GetHeight(), []string{
+	expectedView = testutil.Pad(vp.GetWidth(), vp.GetHeight(), []string{
 		"header ...",
 		"...ne t...",
 		"...ine ...",
@@ -624,7 +671,7 @@ func TestViewport_SelectionOff_WrapOff_Panning(t *testing.T) {
 
 	// scroll down
 	vp, _ = vp.Update(downKeyMsg)
-	expectedView = pad(vp.GetWidth(), vp.GetHeight(), []string{
+	expectedView = testutil.Pad(vp.GetWidth(), vp.GetHeight(), []string{
 		"header ...",
 		"...ine ...",
 		"...ne t...",
@@ -636,7 +683,7 @@ func TestViewport_SelectionOff_WrapOff_Panning(t *testing.T) {
 
 	// pan all the way right
 	vp.safelySetXOffset(41)
-	expectedView = pad(vp.GetWidth(), vp.GetHeight(), []string{
+	expectedView = testutil.Pad(vp.GetWidth(), vp.GetHeight(), []string{
 		"header ...",
 		"...e first",
 		"...",
@@ -648,7 +695,7 @@ func TestViewport_SelectionOff_WrapOff_Panning(t *testing.T) {
 
 	// scroll down
 	vp, _ = vp.Update(downKeyMsg)
-	expectedView = pad(vp.GetWidth(), vp.GetHeight(), []string{
+	expectedView = testutil.Pad(vp.GetWidth(), vp.GetHeight(), []string{
 		"header ...",
 		"...ly long",
 		"...",
@@ -662,11 +709,11 @@ func TestViewport_SelectionOff_WrapOff_Panning(t *testing.T) {
 	setContent(&vp, []string{
 		"the first one",
 	})
-	expectedView = pad(vp.GetWidth(), vp.GetHeight(), []string{
+	expectedView = testutil.Pad(vp.GetWidth(), vp.GetHeight(), []string{
 		"header ...",
 		"...rst one",
 	})
-	internal.CmpStr(t, expectedView, vp.View())
+	testutil.CmpStr(t, expectedView, vp.View())
 }
 
 func TestViewport_SelectionOff_WrapOff_ChangeHeight(t *testing.T) {
@@ -681,16 +728,16 @@ func TestViewport_SelectionOff_WrapOff_ChangeHeight(t *testing.T) {
 		"fifth",
 		"sixth",
 	})
-	expectedView := pad(vp.GetWidth(), vp.GetHeight(), []string{
+	expectedView := testutil.Pad(vp.GetWidth(), vp.GetHeight(), []string{
 		"header",
 		"first",
 		"16% (1/6)",
 	})
-	internal.CmpStr(t, expectedView, vp.View())
+	testutil.CmpStr(t, expectedView, vp.View())
 
 	// increase height
 	vp.SetHeight(6)
-	expectedView = pad(vp.GetWidth(), vp.GetHeight(), []string{
+	expectedView = testutil.Pad(vp.GetWidth(), vp.GetHeight(), []string{
 		"header",
 		"first",
 		"second",
@@ -698,12 +745,12 @@ func TestViewport_SelectionOff_WrapOff_ChangeHeight(t *testing.T) {
 		"fourth",
 		"66% (4/6)",
 	})
-	internal.CmpStr(t, expectedView, vp.View())
+	testutil.CmpStr(t, expectedView, vp.View())
 
 	// scroll to bottom
 	vp, _ = vp.Update(downKeyMsg)
 	vp, _ = vp.Update(downKeyMsg)
-	expectedView = pad(vp.GetWidth(), vp.GetHeight(), []string{
+	expectedView = testutil.Pad(vp.GetWidth(), vp.GetHeight(), []string{
 		"header",
 		"third",
 		"fourth",
@@ -711,20 +758,20 @@ func TestViewport_SelectionOff_WrapOff_ChangeHeight(t *testing.T) {
 		"sixth",
 		"100% (6/6)",
 	})
-	internal.CmpStr(t, expectedView, vp.View())
+	testutil.CmpStr(t, expectedView, vp.View())
 
 	// reduce height
 	vp.SetHeight(3)
-	expectedView = pad(vp.GetWidth(), vp.GetHeight(), []string{
+	expectedView = testutil.Pad(vp.GetWidth(), vp.GetHeight(), []string{
 		"header",
 		"third",
 		"50% (3/6)",
 	})
-	internal.CmpStr(t, expectedView, vp.View())
+	testutil.CmpStr(t, expectedView, vp.View())
 
 	// increase height
 	vp.SetHeight(8)
-	expectedView = pad(vp.GetWidth(), vp.GetHeight(), []string{
+	expectedView = testutil.Pad(vp.GetWidth(), vp.GetHeight(), []string{
 		"header",
 		"first",
 		"second",
@@ -734,7 +781,7 @@ func TestViewport_SelectionOff_WrapOff_ChangeHeight(t *testing.T) {
 		"sixth",
 		"100% (6/6)",
 	})
-	internal.CmpStr(t, expectedView, vp.View())
+	testutil.CmpStr(t, expectedView, vp.View())
 }
 
 func TestViewport_SelectionOff_WrapOff_ChangeContent(t *testing.T) {
@@ -749,46 +796,46 @@ func TestViewport_SelectionOff_WrapOff_ChangeContent(t *testing.T) {
 		"fifth",
 		"sixth",
 	})
-	expectedView := pad(vp.GetWidth(), vp.GetHeight(), []string{
+	expectedView := testutil.Pad(vp.GetWidth(), vp.GetHeight(), []string{
 		"header",
 		"first",
 		"second",
 		"third",
 		"50% (3/6)",
 	})
-	internal.CmpStr(t, expectedView, vp.View())
+	testutil.CmpStr(t, expectedView, vp.View())
 
 	// scroll down
 	vp, _ = vp.Update(downKeyMsg)
 	vp, _ = vp.Update(downKeyMsg)
 	vp, _ = vp.Update(downKeyMsg)
-	expectedView = pad(vp.GetWidth(), vp.GetHeight(), []string{
+	expectedView = testutil.Pad(vp.GetWidth(), vp.GetHeight(), []string{
 		"header",
 		"fourth",
 		"fifth",
 		"sixth",
 		"100% (6/6)",
 	})
-	internal.CmpStr(t, expectedView, vp.View())
+	testutil.CmpStr(t, expectedView, vp.View())
 
 	// remove content
 	setContent(&vp, []string{})
-	expectedView = pad(vp.GetWidth(), vp.GetHeight(), []string{
+	expectedView = testutil.Pad(vp.GetWidth(), vp.GetHeight(), []string{
 		"header",
 	})
-	internal.CmpStr(t, expectedView, vp.View())
+	testutil.CmpStr(t, expectedView, vp.View())
 
 	// re-add content
 	setContent(&vp, []string{
 		"first",
 		"second",
 	})
-	expectedView = pad(vp.GetWidth(), vp.GetHeight(), []string{
+	expectedView = testutil.Pad(vp.GetWidth(), vp.GetHeight(), []string{
 		"header",
 		"first",
 		"second",
 	})
-	internal.CmpStr(t, expectedView, vp.View())
+	testutil.CmpStr(t, expectedView, vp.View())
 }
 
 func TestViewport_SelectionOff_WrapOff_StringToHighlight(t *testing.T) {
@@ -808,14 +855,100 @@ func TestViewport_SelectionOff_WrapOff_StringToHighlight(t *testing.T) {
 		"second",
 		"third",
 	})
-	expectedView := pad(vp.GetWidth(), vp.GetHeight(), []string{
+	expectedView := testutil.Pad(vp.GetWidth(), vp.GetHeight(), []string{
 		"header",
 		"first",
 		"\x1b[38;2;255;0;0msecond\x1b[m",
 		"\x1b[38;2;255;0;0msecond\x1b[m",
 		"75% (3/4)",
 	})
-	internal.CmpStr(t, expectedView, vp.View())
+	testutil.CmpStr(t, expectedView, vp.View())
+}
+
+func TestViewport_SelectionOff_WrapOff_HighlightCaseMode(t *testing.T) {
+	w, h := 10, 5
+	vp := newViewport(w, h)
+	vp.SetHeader([]string{"header"})
+	vp.SetHighlightCaseMode(linebuffer.SmartCase)
+	vp.SetStringToHighlight("second")
+	vp.SetStyles(Styles{
+		FooterStyle:              lipgloss.NewStyle(),
+		HighlightStyle:           lipgloss.NewStyle().Foreground(red),
+		HighlightStyleIfSelected: lipgloss.NewStyle(),
+		SelectedItemStyle:        selectionStyle,
+	})
+	setContent(&vp, []string{
+		"first",
+		"Second",
+		"SECOND",
+		"third",
+	})
+	expectedView := testutil.Pad(vp.GetWidth(), vp.GetHeight(), []string{
+		"header",
+		"first",
+		"\x1b[38;2;255;0;0mSecond\x1b[m",
+		"\x1b[38;2;255;0;0mSECOND\x1b[m",
+		"75% (3/4)",
+	})
+	testutil.CmpStr(t, expectedView, vp.View())
+
+	// the case mode is kept when the string to highlight changes, and an uppercase letter makes it case-sensitive
+	vp.SetStringToHighlight("Second")
+	expectedView = testutil.Pad(vp.GetWidth(), vp.GetHeight(), []string{
+		"header",
+		"first",
+		"\x1b[38;2;255;0;0mSecond\x1b[m",
+		"SECOND",
+		"75% (3/4)",
+	})
+	testutil.CmpStr(t, expectedView, vp.View())
+
+	vp.SetHighlightCaseMode(linebuffer.CaseInsensitive)
+	vp.SetRegexToHighlight(regexp.MustCompile("^s"))
+	expectedView = testutil.Pad(vp.GetWidth(), vp.GetHeight(), []string{
+		"header",
+		"first",
+		"\x1b[38;2;255;0;0mS\x1b[mecond",
+		"\x1b[38;2;255;0;0mS\x1b[mECOND",
+		"75% (3/4)",
+	})
+	testutil.CmpStr(t, expectedView, vp.View())
+}
+
+func TestViewport_SelectionOff_WrapOff_HighlightMatchMode(t *testing.T) {
+	w, h := 15, 4
+	vp := newViewport(w, h)
+	vp.SetHighlightMatchMode(linebuffer.MatchWholeWord)
+	vp.SetStringToHighlight("id")
+	vp.SetStyles(Styles{
+		FooterStyle:              lipgloss.NewStyle(),
+		HighlightStyle:           lipgloss.NewStyle().Foreground(red),
+		HighlightStyleIfSelected: lipgloss.NewStyle(),
+		SelectedItemStyle:        selectionStyle,
+	})
+	setContent(&vp, []string{
+		"id: 1",
+		"width: 2",
+		"hidden id",
+	})
+	expectedView := testutil.Pad(vp.GetWidth(), vp.GetHeight(), []string{
+		"\x1b[38;2;255;0;0mid\x1b[m: 1",
+		"width: 2",
+		"hidden \x1b[38;2;255;0;0mid\x1b[m",
+		"100% (3/3)",
+	})
+	testutil.CmpStr(t, expectedView, vp.View())
+
+	// the match mode is kept when the string to highlight changes
+	vp.SetHighlightMatchMode(linebuffer.MatchGlob)
+	vp.SetStringToHighlight("w*:")
+	expectedView = testutil.Pad(vp.GetWidth(), vp.GetHeight(), []string{
+		"id: 1",
+		"\x1b[38;2;255;0;0mwidth:\x1b[m 2",
+		"hidden id",
+		"100% (3/3)",
+	})
+	testutil.CmpStr(t, expectedView, vp.View())
 }
 
 func TestViewport_SelectionOff_WrapOff_RegexToHighlight(t *testing.T) {
@@ -835,14 +968,14 @@ func TestViewport_SelectionOff_WrapOff_RegexToHighlight(t *testing.T) {
 		"second",
 		"third",
 	})
-	expectedView := pad(vp.GetWidth(), vp.GetHeight(), []string{
+	expectedView := testutil.Pad(vp.GetWidth(), vp.GetHeight(), []string{
 		"header",
 		"first",
 		"\x1b[38;2;255;0;0msecon\x1b[md",
 		"\x1b[38;2;255;0;0msecon\x1b[md",
 		"75% (3/4)",
 	})
-	internal.CmpStr(t, expectedView, vp.View())
+	testutil.CmpStr(t, expectedView, vp.View())
 }
 
 func TestViewport_SelectionOff_WrapOff_StringToHighlightManyMatches(t *testing.T) {
@@ -860,13 +993,13 @@ func TestViewport_SelectionOff_WrapOff_StringToHighlightManyMatches(t *testing.T
 			HighlightStyleIfSelected: lipgloss.NewStyle().Foreground(red),
 			SelectedItemStyle:        selectionStyle,
 		})
-		expectedView := pad(vp.GetWidth(), vp.GetHeight(), []string{
+		expectedView := testutil.Pad(vp.GetWidth(), vp.GetHeight(), []string{
 			"header",
 			strings.Repeat("\x1b[38;2;0;255;0mr\x1b[m", 7) + strings.Repeat(".", 3),
 		})
-		internal.CmpStr(t, expectedView, vp.View())
+		testutil.CmpStr(t, expectedView, vp.View())
 	}
-	internal.RunWithTimeout(t, runTest, 20*time.Millisecond)
+	testutil.RunWithTimeout(t, runTest, 20*time.Millisecond)
 }
 
 func TestViewport_SelectionOff_WrapOff_RegexToHighlightManyMatches(t *testing.T) {
@@ -884,13 +1017,13 @@ func TestViewport_SelectionOff_WrapOff_RegexToHighlightManyMatches(t *testing.T)
 			HighlightStyleIfSelected: lipgloss.NewStyle().Foreground(red),
 			SelectedItemStyle:        selectionStyle,
 		})
-		expectedView := pad(vp.GetWidth(), vp.GetHeight(), []string{
+		expectedView := testutil.Pad(vp.GetWidth(), vp.GetHeight(), []string{
 			"header",
 			strings.Repeat("\x1b[38;2;0;255;0mr\x1b[m", 7) + strings.Repeat(".", 3),
 		})
-		internal.CmpStr(t, expectedView, vp.View())
+		testutil.CmpStr(t, expectedView, vp.View())
 	}
-	internal.RunWithTimeout(t, runTest, 20*time.Millisecond)
+	testutil.RunWithTimeout(t, runTest, 20*time.Millisecond)
 }
 
 func TestViewport_SelectionOff_WrapOff_StringToHighlightAnsi(t *testing.T) {
@@ -907,19 +1040,19 @@ func TestViewport_SelectionOff_WrapOff_StringToHighlightAnsi(t *testing.T) {
 		HighlightStyleIfSelected: lipgloss.NewStyle(),
 		SelectedItemStyle:        selectionStyle,
 	})
-	expectedView := pad(vp.GetWidth(), vp.GetHeight(), []string{
+	expectedView := testutil.Pad(vp.GetWidth(), vp.GetHeight(), []string{
 		"header",
 		"lin\x1b[38;2;0;0;255me\x1b[m \x1b[38;2;255;0;0mr\x1b[m\x1b[38;2;0;0;255me\x1b[m\x1b[38;2;255;0;0md\x1b[m \x1b[38;2;0;0;255me\x1b[m again",
 	})
-	internal.CmpStr(t, expectedView, vp.View())
+	testutil.CmpStr(t, expectedView, vp.View())
 
 	// should not highlight the ansi escape codes themselves
 	vp.SetStringToHighlight("38")
-	expectedView = pad(vp.GetWidth(), vp.GetHeight(), []string{
+	expectedView = testutil.Pad(vp.GetWidth(), vp.GetHeight(), []string{
 		"header",
 		"line \x1b[38;2;255;0;0mred\x1b[m e again",
 	})
-	internal.CmpStr(t, expectedView, vp.View())
+	testutil.CmpStr(t, expectedView, vp.View())
 }
 
 func TestViewport_SelectionOff_WrapOff_RegexToHighlightAnsi(t *testing.T) {
@@ -936,19 +1069,19 @@ func TestViewport_SelectionOff_WrapOff_RegexToHighlightAnsi(t *testing.T) {
 		HighlightStyleIfSelected: lipgloss.NewStyle(),
 		SelectedItemStyle:        selectionStyle,
 	})
-	expectedView := pad(vp.GetWidth(), vp.GetHeight(), []string{
+	expectedView := testutil.Pad(vp.GetWidth(), vp.GetHeight(), []string{
 		"header",
 		"line \x1b[38;2;0;0;255mre\x1b[m\x1b[38;2;255;0;0md\x1b[m e again",
 	})
-	internal.CmpStr(t, expectedView, vp.View())
+	testutil.CmpStr(t, expectedView, vp.View())
 
 	// should not highlight the ansi escape codes themselves
 	vp.SetRegexToHighlight(regexp.MustCompile("38"))
-	expectedView = pad(vp.GetWidth(), vp.GetHeight(), []string{
+	expectedView = testutil.Pad(vp.GetWidth(), vp.GetHeight(), []string{
 		"header",
 		"line \x1b[38;2;255;0;0mred\x1b[m e again",
 	})
-	internal.CmpStr(t, expectedView, vp.View())
+	testutil.CmpStr(t, expectedView, vp.View())
 }
 
 func TestViewport_SelectionOff_WrapOff_StringToHighlightAnsiUnicode(t *testing.T) {
@@ -967,12 +1100,12 @@ func TestViewport_SelectionOff_WrapOff_StringToHighlightAnsiUnicode(t *testing.T
 		HighlightStyleIfSelected: lipgloss.NewStyle(),
 		SelectedItemStyle:        selectionStyle,
 	})
-	expectedView := pad(vp.GetWidth(), vp.GetHeight(), []string{
+	expectedView := testutil.Pad(vp.GetWidth(), vp.GetHeight(), []string{
 		"A💖中é",
 		"A💖\x1b[38;2;0;0;255m中é\x1b[m",
 		"A💖\x1b[38;2;0;0;255m中é\x1b[m...",
 	})
-	internal.CmpStr(t, expectedView, vp.View())
+	testutil.CmpStr(t, expectedView, vp.View())
 }
 
 func TestViewport_SelectionOff_WrapOff_SetSelectionEnabled_SetsTopVisibleItem(t *testing.T) {
@@ -989,13 +1122,13 @@ func TestViewport_SelectionOff_WrapOff_SetSelectionEnabled_SetsTopVisibleItem(t
 	vp, _ = vp.Update(downKeyMsg)
 	vp, _ = vp.Update(downKeyMsg)
 	vp.SetSelectionEnabled(true)
-	expectedView := pad(vp.GetWidth(), vp.GetHeight(), []string{
+	expectedView := testutil.Pad(vp.GetWidth(), vp.GetHeight(), []string{
 		"\x1b[38;2;0;0;255mthird\x1b[m",
 		"fourth",
 		"fifth",
 		"50% (3/6)",
 	})
-	internal.CmpStr(t, expectedView, vp.View())
+	testutil.CmpStr(t, expectedView, vp.View())
 }
 
 // # SELECTION ENABLED, WRAP OFF
@@ -1004,11 +1137,11 @@ func TestViewport_SelectionOn_WrapOff_Empty(t *testing.T) {
 	w, h := 15, 5
 	vp := newViewport(w, h)
 	vp.SetSelectionEnabled(true)
-	expectedView := pad(vp.GetWidth(), vp.GetHeight(), []string{})
-	internal.CmpStr(t, expectedView, vp.View())
+	expectedView := testutil.Pad(vp.GetWidth(), vp.GetHeight(), []string{})
+	testutil.CmpStr(t, expectedView, vp.View())
 	vp.SetHeader([]string{"header"})
-	expectedView = pad(vp.GetWidth(), vp.GetHeight(), []string{"header"})
-	internal.CmpStr(t, expectedView, vp.View())
+	expectedView = testutil.Pad(vp.GetWidth(), vp.GetHeight(), []string{"header"})
+	testutil.CmpStr(t, expectedView, vp.View())
 }
 
 func TestViewport_SelectionOn_WrapOff_SmolDimensions(t *testing.T) {
@@ -1017,27 +1150,27 @@ func TestViewport_SelectionOn_WrapOff_SmolDimensions(t *testing.T) {
 	vp.SetSelectionEnabled(true)
 	vp.SetHeader([]string{"header"})
 	setContent(&vp, []string{"hi"})
-	expectedView := pad(vp.GetWidth(), vp.GetHeight(), []string{""})
-	internal.CmpStr(t, expectedView, vp.View())
+	expectedView := testutil.Pad(vp.GetWidth(), vp.GetHeight(), []string{""})
+	testutil.CmpStr(t, expectedView, vp.View())
 
 	vp.SetWidth(1)
 	vp.SetHeight(1)
-	expectedView = pad(vp.GetWidth(), vp.GetHeight(), []string{"."})
-	internal.CmpStr(t, expectedView, vp.View())
+	expectedView = testutil.Pad(vp.GetWidth(), vp.GetHeight(), []string{"."})
+	testutil.CmpStr(t, expectedView, vp.View())
 
 	vp.SetWidth(2)
 	vp.SetHeight(2)
-	expectedView = pad(vp.GetWidth(), vp.GetHeight(), []string{"..", ""})
-	internal.CmpStr(t, expectedView, vp.View())
+	expectedView = testutil.Pad(vp.GetWidth(), vp.GetHeight(), []string{"..", ""})
+	testutil.CmpStr(t, expectedView, vp.View())
 
 	vp.SetWidth(3)
 	vp.SetHeight(3)
-	expectedView = pad(vp.GetWidth(), vp.GetHeight(), []string{
+	expectedView = testutil.Pad(vp.GetWidth(), vp.GetHeight(), []string{
 		"...",
 		"\x1b[38;2;0;0;255mhi\x1b[m",
 		"...",
 	})
-	internal.CmpStr(t, expectedView, vp.View())
+	testutil.CmpStr(t, expectedView, vp.View())
 }
 
 func TestViewport_SelectionOn_WrapOff_Basic(t *testing.T) {
@@ -1051,7 +1184,7 @@ func TestViewport_SelectionOn_WrapOff_Basic(t *testing.T) {
 		lipgloss.NewStyle().Foreground(red).Render("a really really long line"),
 		lipgloss.NewStyle().Foreground(red).Render("a") + " really really long line",
 	})
-	expectedView := pad(vp.GetWidth(), vp.GetHeight(), []string{
+	expectedView := testutil.Pad(vp.GetWidth(), vp.GetHeight(), []string{
 		"header",
 		"\x1b[38;2;0;0;255mfirst line\x1b[m",
 		"\x1b[38;2;255;0;0msecond\x1b[m line",
@@ -1059,7 +1192,7 @@ func TestViewport_SelectionOn_WrapOff_Basic(t *testing.T) {
 		"\x1b[38;2;255;0;0ma\x1b[m really rea...",
 		"25% (1/4)",
 	})
-	internal.CmpStr(t, expectedView, vp.View())
+	testutil.CmpStr(t, expectedView, vp.View())
 }
 
 func TestViewport_SelectionOn_WrapOff_GetConfigs(t *testing.T) {
@@ -1100,17 +1233,17 @@ func TestViewport_SelectionOn_WrapOff_ShowFooter(t *testing.T) {
 		lipgloss.NewStyle().Foreground(red).Render("a really really long line"),
 		lipgloss.NewStyle().Foreground(red).Render("a") + " really really long line",
 	})
-	expectedView := pad(vp.GetWidth(), vp.GetHeight(), []string{
+	expectedView := testutil.Pad(vp.GetWidth(), vp.GetHeight(), []string{
 		"header",
 		"\x1b[38;2;0;0;255mfirst line\x1b[m",
 		"\x1b[38;2;255;0;0msecond\x1b[m line",
 		"\x1b[38;2;255;0;0ma really rea...\x1b[m",
 		"25% (1/4)",
 	})
-	internal.CmpStr(t, expectedView, vp.View())
+	testutil.CmpStr(t, expectedView, vp.View())
 
 	vp.SetHeight(6)
-	expectedView = pad(vp.GetWidth(), vp.GetHeight(), []string{
+	expectedView = testutil.Pad(vp.GetWidth(), vp.GetHeight(), []string{
 		"header",
 		"\x1b[38;2;0;0;255mfirst line\x1b[m",
 		"\x1b[38;2;255;0;0msecond\x1b[m line",
@@ -1118,17 +1251,17 @@ func TestViewport_SelectionOn_WrapOff_ShowFooter(t *testing.T) {
 		"\x1b[38;2;255;0;0ma\x1b[m really rea...",
 		"25% (1/4)",
 	})
-	internal.CmpStr(t, expectedView, vp.View())
+	testutil.CmpStr(t, expectedView, vp.View())
 
 	vp.SetHeight(7)
-	expectedView = pad(vp.GetWidth(), vp.GetHeight(), []string{
+	expectedView = testutil.Pad(vp.GetWidth(), vp.GetHeight(), []string{
 		"header",
 		"\x1b[38;2;0;0;255mfirst line\x1b[m",
 		"\x1b[38;2;255;0;0msecond\x1b[m line",
 		"\x1b[38;2;255;0;0ma really rea...\x1b[m",
 		"\x1b[38;2;255;0;0ma\x1b[m really rea...",
 	})
-	internal.CmpStr(t, expectedView, vp.View())
+	testutil.CmpStr(t, expectedView, vp.View())
 }
 
 func TestViewport_SelectionOn_WrapOff_FooterStyle(t *testing.T) {
@@ -1148,14 +1281,14 @@ func TestViewport_SelectionOn_WrapOff_FooterStyle(t *testing.T) {
 		"3",
 		"4",
 	})
-	expectedView := pad(vp.GetWidth(), vp.GetHeight(), []string{
+	expectedView := testutil.Pad(vp.GetWidth(), vp.GetHeight(), []string{
 		"header",
 		"\x1b[38;2;0;0;255m1\x1b[m",
 		"2",
 		"3",
 		"\x1b[38;2;255;0;0m25% (1/4)\x1b[m",
 	})
-	internal.CmpStr(t, expectedView, vp.View())
+	testutil.CmpStr(t, expectedView, vp.View())
 }
 
 func TestViewport_SelectionOn_WrapOff_FooterDisabled(t *testing.T) {
@@ -1169,24 +1302,24 @@ func TestViewport_SelectionOn_WrapOff_FooterDisabled(t *testing.T) {
 		"third line",
 		"fourth line",
 	})
-	expectedView := pad(vp.GetWidth(), vp.GetHeight(), []string{
+	expectedView := testutil.Pad(vp.GetWidth(), vp.GetHeight(), []string{
 		"header",
 		"\x1b[38;2;0;0;255mfirst line\x1b[m",
 		"second line",
 		"third line",
 		"25% (1/4)",
 	})
-	internal.CmpStr(t, expectedView, vp.View())
+	testutil.CmpStr(t, expectedView, vp.View())
 
 	vp.SetFooterEnabled(false)
-	expectedView = pad(vp.GetWidth(), vp.GetHeight(), []string{
+	expectedView = testutil.Pad(vp.GetWidth(), vp.GetHeight(), []string{
 		"header",
 		"\x1b[38;2;0;0;255mfirst line\x1b[m",
 		"second line",
 		"third line",
 		"fourth line",
 	})
-	internal.CmpStr(t, expectedView, vp.View())
+	testutil.CmpStr(t, expectedView, vp.View())
 }
 
 func TestViewport_SelectionOn_WrapOff_SpaceAround(t *testing.T) {
@@ -1199,14 +1332,14 @@ func TestViewport_SelectionOn_WrapOff_SpaceAround(t *testing.T) {
 		"          first line          ",
 		"               first line               ",
 	})
-	expectedView := pad(vp.GetWidth(), vp.GetHeight(), []string{
+	expectedView := testutil.Pad(vp.GetWidth(), vp.GetHeight(), []string{
 		"header",
 		"\x1b[38;2;0;0;255m    first li...\x1b[m",
 		"          fi...",
 		"            ...",
 		"33% (1/3)",
 	})
-	internal.CmpStr(t, expectedView, vp.View())
+	testutil.CmpStr(t, expectedView, vp.View())
 }
 
 func TestViewport_SelectionOn_WrapOff_MultiHeader(t *testing.T) {
@@ -1218,55 +1351,55 @@ func TestViewport_SelectionOn_WrapOff_MultiHeader(t *testing.T) {
 		"line1",
 		"line2",
 	})
-	expectedView := pad(vp.GetWidth(), vp.GetHeight(), []string{
+	expectedView := testutil.Pad(vp.GetWidth(), vp.GetHeight(), []string{
 		"header1",
 		"header2",
 	})
-	internal.CmpStr(t, expectedView, vp.View())
+	testutil.CmpStr(t, expectedView, vp.View())
 
 	vp.SetHeight(3)
-	expectedView = pad(vp.GetWidth(), vp.GetHeight(), []string{
+	expectedView = testutil.Pad(vp.GetWidth(), vp.GetHeight(), []string{
 		"header1",
 		"header2",
 	})
-	internal.CmpStr(t, expectedView, vp.View())
+	testutil.CmpStr(t, expectedView, vp.View())
 
 	vp.SetHeight(4)
-	expectedView = pad(vp.GetWidth(), vp.GetHeight(), []string{
+	expectedView = testutil.Pad(vp.GetWidth(), vp.GetHeight(), []string{
 		"header1",
 		"header2",
 		"\x1b[38;2;0;0;255mline1\x1b[m",
 		"50% (1/2)",
 	})
-	internal.CmpStr(t, expectedView, vp.View())
+	testutil.CmpStr(t, expectedView, vp.View())
 
 	vp, _ = vp.Update(downKeyMsg)
-	expectedView = pad(vp.GetWidth(), vp.GetHeight(), []string{
+	expectedView = testutil.Pad(vp.GetWidth(), vp.GetHeight(), []string{
 		"header1",
 		"header2",
 		"\x1b[38;2;0;0;255mline2\x1b[m",
 		"100% (2/2)",
 	})
-	internal.CmpStr(t, expectedView, vp.View())
+	testutil.CmpStr(t, expectedView, vp.View())
 
 	vp.SetHeight(5)
-	expectedView = pad(vp.GetWidth(), vp.GetHeight(), []string{
+	expectedView = testutil.Pad(vp.GetWidth(), vp.GetHeight(), []string{
 		"header1",
 		"header2",
 		"line1",
 		"\x1b[38;2;0;0;255mline2\x1b[m",
 		"100% (2/2)",
 	})
-	internal.CmpStr(t, expectedView, vp.View())
+	testutil.CmpStr(t, expectedView, vp.View())
 
 	vp.SetHeight(6)
-	expectedView = pad(vp.GetWidth(), vp.GetHeight(), []string{
+	expectedView = testutil.Pad(vp.GetWidth(), vp.GetHeight(), []string{
 		"header1",
 		"header2",
 		"line1",
 		"\x1b[38;2;0;0;255mline2\x1b[m",
 	})
-	internal.CmpStr(t, expectedView, vp.View())
+	testutil.CmpStr(t, expectedView, vp.View())
 }
 
 func TestViewport_SelectionOn_WrapOff_OverflowLine(t *testing.T) {
@@ -1278,12 +1411,12 @@ func TestViewport_SelectionOn_WrapOff_OverflowLine(t *testing.T) {
 		"123456789012345",
 		"1234567890123456",
 	})
-	expectedView := pad(vp.GetWidth(), vp.GetHeight(), []string{
+	expectedView := testutil.Pad(vp.GetWidth(), vp.GetHeight(), []string{
 		"long header ...",
 		"\x1b[38;2;0;0;255m123456789012345\x1b[m",
 		"123456789012...",
 	})
-	internal.CmpStr(t, expectedView, vp.View())
+	testutil.CmpStr(t, expectedView, vp.View())
 }
 
 func TestViewport_SelectionOn_WrapOff_OverflowHeight(t *testing.T) {
@@ -1299,7 +1432,7 @@ func TestViewport_SelectionOn_WrapOff_OverflowHeight(t *testing.T) {
 		"1234567890123456",
 		"1234567890123456",
 	})
-	expectedView := pad(vp.GetWidth(), vp.GetHeight(), []string{
+	expectedView := testutil.Pad(vp.GetWidth(), vp.GetHeight(), []string{
 		"header",
 		"\x1b[38;2;0;0;255m123456789012345\x1b[m",
 		"123456789012...",
@@ -1307,7 +1440,7 @@ func TestViewport_SelectionOn_WrapOff_OverflowHeight(t *testing.T) {
 		"123456789012...",
 		"16% (1/6)",
 	})
-	internal.CmpStr(t, expectedView, vp.View())
+	testutil.CmpStr(t, expectedView, vp.View())
 }
 
 func TestViewport_SelectionOn_WrapOff_Scrolling(t *testing.T) {
@@ -1327,12 +1460,12 @@ func TestViewport_SelectionOn_WrapOff_Scrolling(t *testing.T) {
 	}
 	validate := func(expectedView string) {
 		// set content multiple times to confirm no side effects of doing it
-		internal.CmpStr(t, expectedView, vp.View())
+		testutil.CmpStr(t, expectedView, vp.View())
 		doSetContent()
-		internal.CmpStr(t, expectedView, vp.View())
+		testutil.CmpStr(t, expectedView, vp.View())
 	}
 	doSetContent()
-	expectedView := pad(vp.GetWidth(), vp.GetHeight(), []string{
+	expectedView := testutil.Pad(vp.GetWidth(), vp.GetHeight(), []string{
 		"header",
 		"\x1b[38;2;0;0;255mfirst\x1b[m",
 		"second",
@@ -1348,7 +1481,7 @@ func TestViewport_SelectionOn_WrapOff_Scrolling(t *testing.T) {
 
 	// scrolling down by one
 	vp, _ = vp.Update(downKeyMsg)
-	expectedView = pad(vp.GetWidth(), vp.GetHeight(), []string{
+	expectedView = testutil.Pad(vp.GetWidth(), vp.GetHeight(), []string{
 		"header",
 		"first",
 		"\x1b[38;2;0;0;255msecond\x1b[m",
@@ -1363,7 +1496,7 @@ func TestViewport_SelectionOn_WrapOff_Scrolling(t *testing.T) {
 	vp, _ = vp.Update(downKeyMsg)
 	vp, _ = vp.Update(downKeyMsg)
 	vp, _ = vp.Update(downKeyMsg)
-	expectedView = pad(vp.GetWidth(), vp.GetHeight(), []string{
+	expectedView = testutil.Pad(vp.GetWidth(), vp.GetHeight(), []string{
 		"header",
 		"third",
 		"fourth",
@@ -1391,43 +1524,43 @@ func TestViewport_SelectionOn_WrapOff_ScrollToItem(t *testing.T) {
 		"fifth",
 		"sixth",
 	})
-	expectedView := pad(vp.GetWidth(), vp.GetHeight(), []string{
+	expectedView := testutil.Pad(vp.GetWidth(), vp.GetHeight(), []string{
 		"header",
 		"\x1b[38;2;0;0;255mfirst\x1b[m",
 		"second",
 		"16% (1/6)",
 	})
-	internal.CmpStr(t, expectedView, vp.View())
+	testutil.CmpStr(t, expectedView, vp.View())
 
 	// attempting to scroll so selection out of view is no-op
 	vp.ScrollSoItemIdxInView(5)
-	expectedView = pad(vp.GetWidth(), vp.GetHeight(), []string{
+	expectedView = testutil.Pad(vp.GetWidth(), vp.GetHeight(), []string{
 		"header",
 		"\x1b[38;2;0;0;255mfirst\x1b[m",
 		"second",
 		"16% (1/6)",
 	})
-	internal.CmpStr(t, expectedView, vp.View())
+	testutil.CmpStr(t, expectedView, vp.View())
 
 	// move selection down
 	vp, _ = vp.Update(downKeyMsg)
-	expectedView = pad(vp.GetWidth(), vp.GetHeight(), []string{
+	expectedView = testutil.Pad(vp.GetWidth(), vp.GetHeight(), []string{
 		"header",
 		"first",
 		"\x1b[38;2;0;0;255msecond\x1b[m",
 		"33% (2/6)",
 	})
-	internal.CmpStr(t, expectedView, vp.View())
+	testutil.CmpStr(t, expectedView, vp.View())
 
 	// scroll so third item in view
 	vp.ScrollSoItemIdxInView(2)
-	expectedView = pad(vp.GetWidth(), vp.GetHeight(), []string{
+	expectedView = testutil.Pad(vp.GetWidth(), vp.GetHeight(), []string{
 		"header",
 		"\x1b[38;2;0;0;255msecond\x1b[m",
 		"third",
 		"33% (2/6)",
 	})
-	internal.CmpStr(t, expectedView, vp.View())
+	testutil.CmpStr(t, expectedView, vp.View())
 }
 
 func TestViewport_SelectionOn_WrapOff_BulkScrolling(t *testing.T) {
@@ -1443,103 +1576,103 @@ func TestViewport_SelectionOn_WrapOff_BulkScrolling(t *testing.T) {
 		"fifth",
 		"sixth",
 	})
-	expectedView := pad(vp.GetWidth(), vp.GetHeight(), []string{
+	expectedView := testutil.Pad(vp.GetWidth(), vp.GetHeight(), []string{
 		"header",
 		"\x1b[38;2;0;0;255mfirst\x1b[m",
 		"second",
 		"16% (1/6)",
 	})
-	internal.CmpStr(t, expectedView, vp.View())
+	testutil.CmpStr(t, expectedView, vp.View())
 
 	// full page down
 	vp, _ = vp.Update(fullPgDownKeyMsg)
-	expectedView = pad(vp.GetWidth(), vp.GetHeight(), []string{
+	expectedView = testutil.Pad(vp.GetWidth(), vp.GetHeight(), []string{
 		"header",
 		"\x1b[38;2;0;0;255mthird\x1b[m",
 		"fourth",
 		"50% (3/6)",
 	})
-	internal.CmpStr(t, expectedView, vp.View())
+	testutil.CmpStr(t, expectedView, vp.View())
 
 	// half page down
 	vp, _ = vp.Update(halfPgDownKeyMsg)
-	expectedView = pad(vp.GetWidth(), vp.GetHeight(), []string{
+	expectedView = testutil.Pad(vp.GetWidth(), vp.GetHeight(), []string{
 		"header",
 		"\x1b[38;2;0;0;255mfourth\x1b[m",
 		"fifth",
 		"66% (4/6)",
 	})
-	internal.CmpStr(t, expectedView, vp.View())
+	testutil.CmpStr(t, expectedView, vp.View())
 
 	// full page down
 	vp, _ = vp.Update(fullPgDownKeyMsg)
-	expectedView = pad(vp.GetWidth(), vp.GetHeight(), []string{
+	expectedView = testutil.Pad(vp.GetWidth(), vp.GetHeight(), []string{
 		"header",
 		"fifth",
 		"\x1b[38;2;0;0;255msixth\x1b[m",
 		"100% (6/6)",
 	})
-	internal.CmpStr(t, expectedView, vp.View())
+	testutil.CmpStr(t, expectedView, vp.View())
 
 	// full page up
 	vp, _ = vp.Update(fullPgUpKeyMsg)
-	expectedView = pad(vp.GetWidth(), vp.GetHeight(), []string{
+	expectedView = testutil.Pad(vp.GetWidth(), vp.GetHeight(), []string{
 		"header",
 		"third",
 		"\x1b[38;2;0;0;255mfourth\x1b[m",
 		"66% (4/6)",
 	})
-	internal.CmpStr(t, expectedView, vp.View())
+	testutil.CmpStr(t, expectedView, vp.View())
 
 	// half page up
 	vp, _ = vp.Update(halfPgUpKeyMsg)
-	expectedView = pad(vp.GetWidth(), vp.GetHeight(), []string{
+	expectedView = testutil.Pad(vp.GetWidth(), vp.GetHeight(), []string{
 		"header",
 		"second",
 		"\x1b[38;2;0;0;255mthird\x1b[m",
 		"50% (3/6)",
 	})
-	internal.CmpStr(t, expectedView, vp.View())
+	testutil.CmpStr(t, expectedView, vp.View())
 
 	// half page up
 	vp, _ = vp.Update(halfPgUpKeyMsg)
-	expectedView = pad(vp.GetWidth(), vp.GetHeight(), []string{
+	expectedView = testutil.Pad(vp.GetWidth(), vp.GetHeight(), []string{
 		"header",
 		"first",
 		"\x1b[38;2;0;0;255msecond\x1b[m",
 		"33% (2/6)",
 	})
-	internal.CmpStr(t, expectedView, vp.View())
+	testutil.CmpStr(t, expectedView, vp.View())
 
 	// full page up
 	vp, _ = vp.Update(fullPgUpKeyMsg)
-	expectedView = pad(vp.GetWidth(), vp.GetHeight(), []string{
+	expectedView = testutil.Pad(vp.GetWidth(), vp.GetHeight(), []string{
 		"header",
 		"\x1b[38;2;0;0;255mfirst\x1b[m",
 		"second",
 		"16% (1/6)",
 	})
-	internal.CmpStr(t, expectedView, vp.View())
+	testutil.CmpStr(t, expectedView, vp.View())
 
 	// go to bottom
 	vp, _ = vp.Update(goToBottomKeyMsg)
-	expectedView = pad(vp.GetWidth(), vp.GetHeight(), []string{
+	expectedView = testutil.Pad(vp.GetWidth(), vp.GetHeight(), []string{
 		"header",
 		"fifth",
 		"\x1b[38;2;0;0;255msixth\x1b[m",
 		"100% (6/6)",
 	})
-	internal.CmpStr(t, expectedView, vp.View())
+	testutil.CmpStr(t, expectedView, vp.View())
 
 	// go to top
 	vp, _ = vp.Update(goToTopKeyMsg)
-	expectedView = pad(vp.GetWidth(), vp.GetHeight(), []string{
+	expectedView = testutil.Pad(vp.GetWidth(), vp.GetHeight(), []string{
 		"header",
 		"\x1b[38;2;0;0;255mfirst\x1b[m",
 		"second",
 		"16% (1/6)",
 	})
-	internal.CmpStr(t, expectedView, vp.View())
+	testutil.CmpStr(t, expectedView, vp.View())
 }
 
 func TestViewport_SelectionOn_WrapOff_Panning(t *testing.T) {
@@ -1559,12 +1692,12 @@ func TestViewport_SelectionOn_WrapOff_Panning(t *testing.T) {
 	}
 	validate := func(expectedView string) {
 		// set content multiple times to confirm no side effects of doing it
-		internal.CmpStr(t, expectedView, vp.View())
+		testutil.CmpStr(t, expectedView, vp.View())
 		doSetContent()
-		internal.CmpStr(t, expectedView, vp.View())
+		testutil.CmpStr(t, expectedView, vp.View())
 	}
 	doSetContent()
-	expectedView := pad(vp.GetWidth(), vp.GetHeight(), []string{
+	expectedView := testutil.Pad(vp.GetWidth(), vp.GetHeight(), []string{
 		"header ...",
 		"\x1b[38;2;0;0;255mfirst l...\x1b[m",
 		"second ...",
@@ -1576,7 +1709,7 @@ func TestViewport_SelectionOn_WrapOff_Panning(t *testing.T) {
 
 	// pan right
 	vp.safelySetXOffset(5)
-	expectedView = pad(vp.GetWidth(), vp.GetHeight(), []string{
+	expectedView = testutil.Pad(vp.GetWidth(), vp.GetHeight(), []string{
 		"header ...",
 		"\x1b[38;2;0;0;255m...ne t...\x1b[m",
 		"...ine ...",
@@ -1588,7 +1721,7 @@ func TestViewport_SelectionOn_WrapOff_Panning(t *testing.T) {
 
 	// scroll down
 	vp, _ = vp.Update(downKeyMsg)
-	expectedView = pad(vp.GetWidth(), vp.GetHeight(), []string{
+	expectedView = testutil.Pad(vp.GetWidth(), vp.GetHeight(), []string{
 		"header ...",
 		"...ne t...",
 		"\x1b[38;2;0;0;255m...ine ...\x1b[m",
@@ -1600,7 +1733,7 @@ func TestViewport_SelectionOn_WrapOff_Panning(t *testing.T) {
 
 	// pan all the way right
 	vp.safelySetXOffset(41)
-	expectedView = pad(vp.GetWidth(), vp.GetHeight(), []string{
+	expectedView = testutil.Pad(vp.GetWidth(), vp.GetHeight(), []string{
 		"header ...",
 		"...",
 		"\x1b[38;2;0;0;255m...e first\x1b[m",
@@ -1612,7 +1745,7 @@ func TestViewport_SelectionOn_WrapOff_Panning(t *testing.T) {
 
 	// scroll down
 	vp, _ = vp.Update(downKeyMsg)
-	expectedView = pad(vp.GetWidth(), vp.GetHeight(), []string{
+	expectedView = testutil.Pad(vp.GetWidth(), vp.GetHeight(), []string{
 		"header ...",
 		"...",
 		"...e first",
@@ -1624,7 +1757,7 @@ func TestViewport_SelectionOn_WrapOff_Panning(t *testing.T) {
 
 	// scroll down
 	vp, _ = vp.Update(downKeyMsg)
-	expectedView = pad(vp.GetWidth(), vp.GetHeight(), []string{
+	expectedView = testutil.Pad(vp.GetWidth(), vp.GetHeight(), []string{
 		"header ...",
 		"...",
 		"...e first",
@@ -1636,7 +1769,7 @@ func TestViewport_SelectionOn_WrapOff_Panning(t *testing.T) {
 
 	// scroll down
 	vp, _ = vp.Update(downKeyMsg)
-	expectedView = pad(vp.GetWidth(), vp.GetHeight(), []string{
+	expectedView = testutil.Pad(vp.GetWidth(), vp.GetHeight(), []string{
 		"header ...",
 		"...e first",
 		"...",
@@ -1648,7 +1781,7 @@ func TestViewport_SelectionOn_WrapOff_Panning(t *testing.T) {
 
 	// scroll down
 	vp, _ = vp.Update(downKeyMsg)
-	expectedView = pad(vp.GetWidth(), vp.GetHeight(), []string{
+	expectedView = testutil.Pad(vp.GetWidth(), vp.GetHeight(), []string{
 		"header ...",
 		"...ly long",
 		"...",
@@ -1660,7 +1793,7 @@ func TestViewport_SelectionOn_WrapOff_Panning(t *testing.T) {
 
 	// scroll up
 	vp, _ = vp.Update(upKeyMsg)
-	expectedView = pad(vp.GetWidth(), vp.GetHeight(), []string{
+	expectedView = testutil.Pad(vp.GetWidth(), vp.GetHeight(), []string{
 		"header ...",
 		"...ly long",
 		"...",
@@ -1672,7 +1805,7 @@ func TestViewport_SelectionOn_WrapOff_Panning(t *testing.T) {
 
 	// scroll up
 	vp, _ = vp.Update(upKeyMsg)
-	expectedView = pad(vp.GetWidth(), vp.GetHeight(), []string{
+	expectedView = testutil.Pad(vp.GetWidth(), vp.GetHeight(), []string{
 		"header ...",
 		"...ly long",
 		"\x1b[38;2;0;0;255m...\x1b[m",
@@ -1684,7 +1817,7 @@ func TestViewport_SelectionOn_WrapOff_Panning(t *testing.T) {
 
 	// scroll up
 	vp, _ = vp.Update(upKeyMsg)
-	expectedView = pad(vp.GetWidth(), vp.GetHeight(), []string{
+	expectedView = testutil.Pad(vp.GetWidth(), vp.GetHeight(), []string{
 		"header ...",
 		"\x1b[38;2;0;0;255m...ly long\x1b[m",
 		"...",
@@ -1696,7 +1829,7 @@ func TestViewport_SelectionOn_WrapOff_Panning(t *testing.T) {
 
 	// scroll up
 	vp, _ = vp.Update(upKeyMsg)
-	expectedView = pad(vp.GetWidth(), vp.GetHeight(), []string{
+	expectedView = testutil.Pad(vp.GetWidth(), vp.GetHeight(), []string{
 		"header ...",
 		"\x1b[38;2;0;0;255m...n mu...\x1b[m",
 		"...ly long",
@@ -1708,7 +1841,7 @@ func TestViewport_SelectionOn_WrapOff_Panning(t *testing.T) {
 
 	// scroll up
 	vp, _ = vp.Update(upKeyMsg)
-	expectedView = pad(vp.GetWidth(), vp.GetHeight(), []string{
+	expectedView = testutil.Pad(vp.GetWidth(), vp.GetHeight(), []string{
 		"header ...",
 		"\x1b[38;2;0;0;255m...ly long\x1b[m",
 		"...n mu...",
@@ -1722,11 +1855,11 @@ func TestViewport_SelectionOn_WrapOff_Panning(t *testing.T) {
 	setContent(&vp, []string{
 		"the first one",
 	})
-	expectedView = pad(vp.GetWidth(), vp.GetHeight(), []string{
+	expectedView = testutil.Pad(vp.GetWidth(), vp.GetHeight(), []string{
 		"header ...",
 		"\x1b[38;2;0;0;255m...rst one\x1b[m",
 	})
-	internal.CmpStr(t, expectedView, vp.View())
+	testutil.CmpStr(t, expectedView, vp.View())
 }
 
 func TestViewport_SelectionOn_WrapOff_MaintainSelection(t *testing.T) {
@@ -1743,25 +1876,25 @@ func TestViewport_SelectionOn_WrapOff_MaintainSelection(t *testing.T) {
 		"tenth",
 		"eleventh",
 	})
-	expectedView := pad(vp.GetWidth(), vp.GetHeight(), []string{
+	expectedView := testutil.Pad(vp.GetWidth(), vp.GetHeight(), []string{
 		"header",
 		"\x1b[38;2;0;0;255msixth\x1b[m",
 		"seventh",
 		"eighth",
 		"16% (1/6)",
 	})
-	internal.CmpStr(t, expectedView, vp.View())
+	testutil.CmpStr(t, expectedView, vp.View())
 
 	// selection down
 	vp, _ = vp.Update(downKeyMsg)
-	expectedView = pad(vp.GetWidth(), vp.GetHeight(), []string{
+	expectedView = testutil.Pad(vp.GetWidth(), vp.GetHeight(), []string{
 		"header",
 		"sixth",
 		"\x1b[38;2;0;0;255mseventh\x1b[m",
 		"eighth",
 		"33% (2/6)",
 	})
-	internal.CmpStr(t, expectedView, vp.View())
+	testutil.CmpStr(t, expectedView, vp.View())
 
 	// add content above
 	setContent(&vp, []string{
@@ -1777,14 +1910,14 @@ func TestViewport_SelectionOn_WrapOff_MaintainSelection(t *testing.T) {
 		"tenth",
 		"eleventh",
 	})
-	expectedView = pad(vp.GetWidth(), vp.GetHeight(), []string{
+	expectedView = testutil.Pad(vp.GetWidth(), vp.GetHeight(), []string{
 		"header",
 		"sixth",
 		"\x1b[38;2;0;0;255mseventh\x1b[m",
 		"eighth",
 		"63% (7/11)",
 	})
-	internal.CmpStr(t, expectedView, vp.View())
+	testutil.CmpStr(t, expectedView, vp.View())
 
 	// add content below
 	setContent(&vp, []string{
@@ -1805,14 +1938,14 @@ func TestViewport_SelectionOn_WrapOff_MaintainSelection(t *testing.T) {
 		"fifteenth",
 		"sixteenth",
 	})
-	expectedView = pad(vp.GetWidth(), vp.GetHeight(), []string{
+	expectedView = testutil.Pad(vp.GetWidth(), vp.GetHeight(), []string{
 		"header",
 		"sixth",
 		"\x1b[38;2;0;0;255mseventh\x1b[m",
 		"eighth",
 		"43% (7/16)",
 	})
-	internal.CmpStr(t, expectedView, vp.View())
+	testutil.CmpStr(t, expectedView, vp.View())
 }
 
 func TestViewport_SelectionOn_WrapOff_StickyTop(t *testing.T) {
@@ -1826,34 +1959,34 @@ func TestViewport_SelectionOn_WrapOff_StickyTop(t *testing.T) {
 	setContent(&vp, []string{
 		"first",
 	})
-	expectedView := pad(vp.GetWidth(), vp.GetHeight(), []string{
+	expectedView := testutil.Pad(vp.GetWidth(), vp.GetHeight(), []string{
 		"header",
 		"\x1b[38;2;0;0;255mfirst\x1b[m",
 	})
-	internal.CmpStr(t, expectedView, vp.View())
+	testutil.CmpStr(t, expectedView, vp.View())
 
 	// add content
 	setContent(&vp, []string{
 		"second",
 		"first",
 	})
-	expectedView = pad(vp.GetWidth(), vp.GetHeight(), []string{
+	expectedView = testutil.Pad(vp.GetWidth(), vp.GetHeight(), []string{
 		"header",
 		"\x1b[38;2;0;0;255msecond\x1b[m",
 		"first",
 		"50% (1/2)",
 	})
-	internal.CmpStr(t, expectedView, vp.View())
+	testutil.CmpStr(t, expectedView, vp.View())
 
 	// de-activate by moving selection down
 	vp, _ = vp.Update(downKeyMsg)
-	expectedView = pad(vp.GetWidth(), vp.GetHeight(), []string{
+	expectedView = testutil.Pad(vp.GetWidth(), vp.GetHeight(), []string{
 		"header",
 		"second",
 		"\x1b[38;2;0;0;255mfirst\x1b[m",
 		"100% (2/2)",
 	})
-	internal.CmpStr(t, expectedView, vp.View())
+	testutil.CmpStr(t, expectedView, vp.View())
 
 	// add content
 	setContent(&vp, []string{
@@ -1861,13 +1994,13 @@ func TestViewport_SelectionOn_WrapOff_StickyTop(t *testing.T) {
 		"first",
 		"third",
 	})
-	expectedView = pad(vp.GetWidth(), vp.GetHeight(), []string{
+	expectedView = testutil.Pad(vp.GetWidth(), vp.GetHeight(), []string{
 		"header",
 		"second",
 		"\x1b[38;2;0;0;255mfirst\x1b[m",
 		"66% (2/3)",
 	})
-	internal.CmpStr(t, expectedView, vp.View())
+	testutil.CmpStr(t, expectedView, vp.View())
 }
 
 func TestViewport_SelectionOn_WrapOff_StickyBottom(t *testing.T) {
@@ -1881,34 +2014,34 @@ func TestViewport_SelectionOn_WrapOff_StickyBottom(t *testing.T) {
 	setContent(&vp, []string{
 		"first",
 	})
-	expectedView := pad(vp.GetWidth(), vp.GetHeight(), []string{
+	expectedView := testutil.Pad(vp.GetWidth(), vp.GetHeight(), []string{
 		"header",
 		"\x1b[38;2;0;0;255mfirst\x1b[m",
 	})
-	internal.CmpStr(t, expectedView, vp.View())
+	testutil.CmpStr(t, expectedView, vp.View())
 
 	// add content
 	setContent(&vp, []string{
 		"second",
 		"first",
 	})
-	expectedView = pad(vp.GetWidth(), vp.GetHeight(), []string{
+	expectedView = testutil.Pad(vp.GetWidth(), vp.GetHeight(), []string{
 		"header",
 		"second",
 		"\x1b[38;2;0;0;255mfirst\x1b[m",
 		"100% (2/2)",
 	})
-	internal.CmpStr(t, expectedView, vp.View())
+	testutil.CmpStr(t, expectedView, vp.View())
 
 	// de-activate by moving selection up
 	vp, _ = vp.Update(upKeyMsg)
-	expectedView = pad(vp.GetWidth(), vp.GetHeight(), []string{
+	expectedView = testutil.Pad(vp.GetWidth(), vp.GetHeight(), []string{
 		"header",
 		"\x1b[38;2;0;0;255msecond\x1b[m",
 		"first",
 		"50% (1/2)",
 	})
-	internal.CmpStr(t, expectedView, vp.View())
+	testutil.CmpStr(t, expectedView, vp.View())
 
 	// add content
 	setContent(&vp, []string{
@@ -1916,13 +2049,13 @@ func TestViewport_SelectionOn_WrapOff_StickyBottom(t *testing.T) {
 		"first",
 		"third",
 	})
-	expectedView = pad(vp.GetWidth(), vp.GetHeight(), []string{
+	expectedView = testutil.Pad(vp.GetWidth(), vp.GetHeight(), []string{
 		"header",
 		"\x1b[38;2;0;0;255msecond\x1b[m",
 		"first",
 		"33% (1/3)",
 	})
-	internal.CmpStr(t, expectedView, vp.View())
+	testutil.CmpStr(t, expectedView, vp.View())
 }
 
 func TestViewport_SelectionOn_WrapOff_StickyBottomOverflowHeight(t *testing.T) {
@@ -1936,23 +2069,23 @@ func TestViewport_SelectionOn_WrapOff_StickyBottomOverflowHeight(t *testing.T) {
 
 	// test covers case where first set content to empty, then overflow height
 	setContent(&vp, []string{})
-	expectedView := pad(vp.GetWidth(), vp.GetHeight(), []string{
+	expectedView := testutil.Pad(vp.GetWidth(), vp.GetHeight(), []string{
 		"header",
 	})
-	internal.CmpStr(t, expectedView, vp.View())
+	testutil.CmpStr(t, expectedView, vp.View())
 
 	setContent(&vp, []string{
 		"second",
 		"first",
 		"third",
 	})
-	expectedView = pad(vp.GetWidth(), vp.GetHeight(), []string{
+	expectedView = testutil.Pad(vp.GetWidth(), vp.GetHeight(), []string{
 		"header",
 		"first",
 		"\x1b[38;2;0;0;255mthird\x1b[m",
 		"100% (3/3)",
 	})
-	internal.CmpStr(t, expectedView, vp.View())
+	testutil.CmpStr(t, expectedView, vp.View())
 }
 
 func TestViewport_SelectionOn_WrapOff_StickyTopBottom(t *testing.T) {
@@ -1967,34 +2100,34 @@ func TestViewport_SelectionOn_WrapOff_StickyTopBottom(t *testing.T) {
 	setContent(&vp, []string{
 		"first",
 	})
-	expectedView := pad(vp.GetWidth(), vp.GetHeight(), []string{
+	expectedView := testutil.Pad(vp.GetWidth(), vp.GetHeight(), []string{
 		"header",
 		"\x1b[38;2;0;0;255mfirst\x1b[m",
 	})
-	internal.CmpStr(t, expectedView, vp.View())
+	testutil.CmpStr(t, expectedView, vp.View())
 
 	// add content, top sticky wins out arbitrarily when both set
 	setContent(&vp, []string{
 		"second",
 		"first",
 	})
-	expectedView = pad(vp.GetWidth(), vp.GetHeight(), []string{
+	expectedView = testutil.Pad(vp.GetWidth(), vp.GetHeight(), []string{
 		"header",
 		"\x1b[38;2;0;0;255msecond\x1b[m",
 		"first",
 		"50% (1/2)",
 	})
-	internal.CmpStr(t, expectedView, vp.View())
+	testutil.CmpStr(t, expectedView, vp.View())
 
 	// selection to bottom
 	vp, _ = vp.Update(downKeyMsg)
-	expectedView = pad(vp.GetWidth(), vp.GetHeight(), []string{
+	expectedView = testutil.Pad(vp.GetWidth(), vp.GetHeight(), []string{
 		"header",
 		"second",
 		"\x1b[38;2;0;0;255mfirst\x1b[m",
 		"100% (2/2)",
 	})
-	internal.CmpStr(t, expectedView, vp.View())
+	testutil.CmpStr(t, expectedView, vp.View())
 
 	// add content
 	setContent(&vp, []string{
@@ -2002,23 +2135,23 @@ func TestViewport_SelectionOn_WrapOff_StickyTopBottom(t *testing.T) {
 		"first",
 		"third",
 	})
-	expectedView = pad(vp.GetWidth(), vp.GetHeight(), []string{
+	expectedView = testutil.Pad(vp.GetWidth(), vp.GetHeight(), []string{
 		"header",
 		"first",
 		"\x1b[38;2;0;0;255mthird\x1b[m",
 		"100% (3/3)",
 	})
-	internal.CmpStr(t, expectedView, vp.View())
+	testutil.CmpStr(t, expectedView, vp.View())
 
 	// de-activate by moving selection up
 	vp, _ = vp.Update(upKeyMsg)
-	expectedView = pad(vp.GetWidth(), vp.GetHeight(), []string{
+	expectedView = testutil.Pad(vp.GetWidth(), vp.GetHeight(), []string{
 		"header",
 		"\x1b[38;2;0;0;255mfirst\x1b[m",
 		"third",
 		"66% (2/3)",
 	})
-	internal.CmpStr(t, expectedView, vp.View())
+	testutil.CmpStr(t, expectedView, vp.View())
 
 	// add content
 	setContent(&vp, []string{
@@ -2027,13 +2160,13 @@ func TestViewport_SelectionOn_WrapOff_StickyTopBottom(t *testing.T) {
 		"third",
 		"fourth",
 	})
-	expectedView = pad(vp.GetWidth(), vp.GetHeight(), []string{
+	expectedView = testutil.Pad(vp.GetWidth(), vp.GetHeight(), []string{
 		"header",
 		"\x1b[38;2;0;0;255mfirst\x1b[m",
 		"third",
 		"50% (2/4)",
 	})
-	internal.CmpStr(t, expectedView, vp.View())
+	testutil.CmpStr(t, expectedView, vp.View())
 }
 
 func TestViewport_SelectionOn_WrapOff_RemoveLogsWhenSelectionBottom(t *testing.T) {
@@ -2049,33 +2182,33 @@ func TestViewport_SelectionOn_WrapOff_RemoveLogsWhenSelectionBottom(t *testing.T
 		"third",
 		"fourth",
 	})
-	expectedView := pad(vp.GetWidth(), vp.GetHeight(), []string{
+	expectedView := testutil.Pad(vp.GetWidth(), vp.GetHeight(), []string{
 		"header",
 		"\x1b[38;2;0;0;255msecond\x1b[m",
 		"25% (1/4)",
 	})
-	internal.CmpStr(t, expectedView, vp.View())
+	testutil.CmpStr(t, expectedView, vp.View())
 
 	// selection to bottom
 	vp.SetSelectedItemIdx(3)
-	expectedView = pad(vp.GetWidth(), vp.GetHeight(), []string{
+	expectedView = testutil.Pad(vp.GetWidth(), vp.GetHeight(), []string{
 		"header",
 		"\x1b[38;2;0;0;255mfourth\x1b[m",
 		"100% (4/4)",
 	})
-	internal.CmpStr(t, expectedView, vp.View())
+	testutil.CmpStr(t, expectedView, vp.View())
 
 	// remove content
 	setContent(&vp, []string{
 		"second",
 		"first",
 	})
-	expectedView = pad(vp.GetWidth(), vp.GetHeight(), []string{
+	expectedView = testutil.Pad(vp.GetWidth(), vp.GetHeight(), []string{
 		"header",
 		"\x1b[38;2;0;0;255mfirst\x1b[m",
 		"100% (2/2)",
 	})
-	internal.CmpStr(t, expectedView, vp.View())
+	testutil.CmpStr(t, expectedView, vp.View())
 }
 
 func TestViewport_SelectionOn_WrapOff_ChangeHeight(t *testing.T) {
@@ -2091,16 +2224,16 @@ func TestViewport_SelectionOn_WrapOff_ChangeHeight(t *testing.T) {
 		"fifth",
 		"sixth",
 	})
-	expectedView := pad(vp.GetWidth(), vp.GetHeight(), []string{
+	expectedView := testutil.Pad(vp.GetWidth(), vp.GetHeight(), []string{
 		"header",
 		"\x1b[38;2;0;0;255mfirst\x1b[m",
 		"16% (1/6)",
 	})
-	internal.CmpStr(t, expectedView, vp.View())
+	testutil.CmpStr(t, expectedView, vp.View())
 
 	// increase height
 	vp.SetHeight(8)
-	expectedView = pad(vp.GetWidth(), vp.GetHeight(), []string{
+	expectedView = testutil.Pad(vp.GetWidth(), vp.GetHeight(), []string{
 		"header",
 		"\x1b[38;2;0;0;255mfirst\x1b[m",
 		"second",
@@ -2110,12 +2243,12 @@ func TestViewport_SelectionOn_WrapOff_ChangeHeight(t *testing.T) {
 		"sixth",
 		"16% (1/6)",
 	})
-	internal.CmpStr(t, expectedView, vp.View())
+	testutil.CmpStr(t, expectedView, vp.View())
 
 	// move selection to third line
 	vp, _ = vp.Update(downKeyMsg)
 	vp, _ = vp.Update(downKeyMsg)
-	expectedView = pad(vp.GetWidth(), vp.GetHeight(), []string{
+	expectedView = testutil.Pad(vp.GetWidth(), vp.GetHeight(), []string{
 		"header",
 		"first",
 		"second",
@@ -2125,20 +2258,20 @@ func TestViewport_SelectionOn_WrapOff_ChangeHeight(t *testing.T) {
 		"sixth",
 		"50% (3/6)",
 	})
-	internal.CmpStr(t, expectedView, vp.View())
+	testutil.CmpStr(t, expectedView, vp.View())
 
 	// reduce height
 	vp.SetHeight(3)
-	expectedView = pad(vp.GetWidth(), vp.GetHeight(), []string{
+	expectedView = testutil.Pad(vp.GetWidth(), vp.GetHeight(), []string{
 		"header",
 		"\x1b[38;2;0;0;255mthird\x1b[m",
 		"50% (3/6)",
 	})
-	internal.CmpStr(t, expectedView, vp.View())
+	testutil.CmpStr(t, expectedView, vp.View())
 
 	// increase height
 	vp.SetHeight(8)
-	expectedView = pad(vp.GetWidth(), vp.GetHeight(), []string{
+	expectedView = testutil.Pad(vp.GetWidth(), vp.GetHeight(), []string{
 		"header",
 		"first",
 		"second",
@@ -2148,13 +2281,13 @@ func TestViewport_SelectionOn_WrapOff_ChangeHeight(t *testing.T) {
 		"sixth",
 		"50% (3/6)",
 	})
-	internal.CmpStr(t, expectedView, vp.View())
+	testutil.CmpStr(t, expectedView, vp.View())
 
 	// move selection to last line
 	vp, _ = vp.Update(downKeyMsg)
 	vp, _ = vp.Update(downKeyMsg)
 	vp, _ = vp.Update(downKeyMsg)
-	expectedView = pad(vp.GetWidth(), vp.GetHeight(), []string{
+	expectedView = testutil.Pad(vp.GetWidth(), vp.GetHeight(), []string{
 		"header",
 		"first",
 		"second",
@@ -2164,20 +2297,20 @@ func TestViewport_SelectionOn_WrapOff_ChangeHeight(t *testing.T) {
 		"\x1b[38;2;0;0;255msixth\x1b[m",
 		"100% (6/6)",
 	})
-	internal.CmpStr(t, expectedView, vp.View())
+	testutil.CmpStr(t, expectedView, vp.View())
 
 	// reduce height
 	vp.SetHeight(3)
-	expectedView = pad(vp.GetWidth(), vp.GetHeight(), []string{
+	expectedView = testutil.Pad(vp.GetWidth(), vp.GetHeight(), []string{
 		"header",
 		"\x1b[38;2;0;0;255msixth\x1b[m",
 		"100% (6/6)",
 	})
-	internal.CmpStr(t, expectedView, vp.View())
+	testutil.CmpStr(t, expectedView, vp.View())
 
 	// increase height
 	vp.SetHeight(8)
-	expectedView = pad(vp.GetWidth(), vp.GetHeight(), []string{
+	expectedView = testutil.Pad(vp.GetWidth(), vp.GetHeight(), []string{
 		"header",
 		"first",
 		"second",
@@ -2187,7 +2320,7 @@ func TestViewport_SelectionOn_WrapOff_ChangeHeight(t *testing.T) {
 		"\x1b[38;2;0;0;255msixth\x1b[m",
 		"100% (6/6)",
 	})
-	internal.CmpStr(t, expectedView, vp.View())
+	testutil.CmpStr(t, expectedView, vp.View())
 }
 
 func TestViewport_SelectionOn_WrapOff_ChangeContent(t *testing.T) {
@@ -2203,42 +2336,42 @@ func TestViewport_SelectionOn_WrapOff_ChangeContent(t *testing.T) {
 		"fifth",
 		"sixth",
 	})
-	expectedView := pad(vp.GetWidth(), vp.GetHeight(), []string{
+	expectedView := testutil.Pad(vp.GetWidth(), vp.GetHeight(), []string{
 		"header",
 		"\x1b[38;2;0;0;255mfirst\x1b[m",
 		"second",
 		"third",
 		"16% (1/6)",
 	})
-	internal.CmpStr(t, expectedView, vp.View())
+	testutil.CmpStr(t, expectedView, vp.View())
 
 	// move selection to bottom
 	vp.SetSelectedItemIdx(5)
-	expectedView = pad(vp.GetWidth(), vp.GetHeight(), []string{
+	expectedView = testutil.Pad(vp.GetWidth(), vp.GetHeight(), []string{
 		"header",
 		"fourth",
 		"fifth",
 		"\x1b[38;2;0;0;255msixth\x1b[m",
 		"100% (6/6)",
 	})
-	internal.CmpStr(t, expectedView, vp.View())
+	testutil.CmpStr(t, expectedView, vp.View())
 
 	// remove content
 	setContent(&vp, []string{
 		"second",
 	})
-	expectedView = pad(vp.GetWidth(), vp.GetHeight(), []string{
+	expectedView = testutil.Pad(vp.GetWidth(), vp.GetHeight(), []string{
 		"header",
 		"\x1b[38;2;0;0;255msecond\x1b[m",
 	})
-	internal.CmpStr(t, expectedView, vp.View())
+	testutil.CmpStr(t, expectedView, vp.View())
 
 	// remove all content
 	setContent(&vp, []string{})
-	expectedView = pad(vp.GetWidth(), vp.GetHeight(), []string{
+	expectedView = testutil.Pad(vp.GetWidth(), vp.GetHeight(), []string{
 		"header",
 	})
-	internal.CmpStr(t, expectedView, vp.View())
+	testutil.CmpStr(t, expectedView, vp.View())
 
 	// add content (maintain selection off)
 	setContent(&vp, []string{
@@ -2249,14 +2382,14 @@ func TestViewport_SelectionOn_WrapOff_ChangeContent(t *testing.T) {
 		"fifth",
 		"sixth",
 	})
-	expectedView = pad(vp.GetWidth(), vp.GetHeight(), []string{
+	expectedView = testutil.Pad(vp.GetWidth(), vp.GetHeight(), []string{
 		"header",
 		"\x1b[38;2;0;0;255mfirst\x1b[m",
 		"second",
 		"third",
 		"16% (1/6)",
 	})
-	internal.CmpStr(t, expectedView, vp.View())
+	testutil.CmpStr(t, expectedView, vp.View())
 }
 
 func TestViewport_SelectionOn_WrapOff_StringToHighlight(t *testing.T) {
@@ -2277,24 +2410,24 @@ func TestViewport_SelectionOn_WrapOff_StringToHighlight(t *testing.T) {
 		"the second line",
 		"the fourth line",
 	})
-	expectedView := pad(vp.GetWidth(), vp.GetHeight(), []string{
+	expectedView := testutil.Pad(vp.GetWidth(), vp.GetHeight(), []string{
 		"header",
 		"\x1b[38;2;0;0;255mthe first line\x1b[m",
 		"the \x1b[38;2;0;255;0msecond\x1b[m line",
 		"the \x1b[38;2;0;255;0msecond\x1b[m line",
 		"25% (1/4)",
 	})
-	internal.CmpStr(t, expectedView, vp.View())
+	testutil.CmpStr(t, expectedView, vp.View())
 
 	vp.SetStringToHighlight("first")
-	expectedView = pad(vp.GetWidth(), vp.GetHeight(), []string{
+	expectedView = testutil.Pad(vp.GetWidth(), vp.GetHeight(), []string{
 		"header",
 		"\x1b[38;2;0;0;255mthe \x1b[m\x1b[38;2;255;0;0mfirst\x1b[m\x1b[38;2;0;0;255m line\x1b[m",
 		"the second line",
 		"the second line",
 		"25% (1/4)",
 	})
-	internal.CmpStr(t, expectedView, vp.View())
+	testutil.CmpStr(t, expectedView, vp.View())
 
 	setContent(&vp, []string{
 		"first line",
@@ -2302,14 +2435,14 @@ func TestViewport_SelectionOn_WrapOff_StringToHighlight(t *testing.T) {
 		"second line",
 		"fourth line",
 	})
-	expectedView = pad(vp.GetWidth(), vp.GetHeight(), []string{
+	expectedView = testutil.Pad(vp.GetWidth(), vp.GetHeight(), []string{
 		"header",
 		"\x1b[38;2;255;0;0mfirst\x1b[m\x1b[38;2;0;0;255m line\x1b[m",
 		"second line",
 		"second line",
 		"25% (1/4)",
 	})
-	internal.CmpStr(t, expectedView, vp.View())
+	testutil.CmpStr(t, expectedView, vp.View())
 }
 
 func TestViewport_SelectionOn_WrapOff_RegexToHighlight(t *testing.T) {
@@ -2330,24 +2463,24 @@ func TestViewport_SelectionOn_WrapOff_RegexToHighlight(t *testing.T) {
 		"the second line",
 		"the fourth line",
 	})
-	expectedView := pad(vp.GetWidth(), vp.GetHeight(), []string{
+	expectedView := testutil.Pad(vp.GetWidth(), vp.GetHeight(), []string{
 		"header",
 		"\x1b[38;2;0;0;255mthe first line\x1b[m",
 		"the \x1b[38;2;0;255;0msecond\x1b[m line",
 		"the \x1b[38;2;0;255;0msecond\x1b[m line",
 		"25% (1/4)",
 	})
-	internal.CmpStr(t, expectedView, vp.View())
+	testutil.CmpStr(t, expectedView, vp.View())
 
 	vp.SetRegexToHighlight(regexp.MustCompile("fir.t"))
-	expectedView = pad(vp.GetWidth(), vp.GetHeight(), []string{
+	expectedView = testutil.Pad(vp.GetWidth(), vp.GetHeight(), []string{
 		"header",
 		"\x1b[38;2;0;0;255mthe \x1b[m\x1b[38;2;255;0;0mfirst\x1b[m\x1b[38;2;0;0;255m line\x1b[m",
 		"the second line",
 		"the second line",
 		"25% (1/4)",
 	})
-	internal.CmpStr(t, expectedView, vp.View())
+	testutil.CmpStr(t, expectedView, vp.View())
 
 	setContent(&vp, []string{
 		"first line",
@@ -2355,14 +2488,14 @@ func TestViewport_SelectionOn_WrapOff_RegexToHighlight(t *testing.T) {
 		"second line",
 		"fourth line",
 	})
-	expectedView = pad(vp.GetWidth(), vp.GetHeight(), []string{
+	expectedView = testutil.Pad(vp.GetWidth(), vp.GetHeight(), []string{
 		"header",
 		"\x1b[38;2;255;0;0mfirst\x1b[m\x1b[38;2;0;0;255m line\x1b[m",
 		"second line",
 		"second line",
 		"25% (1/4)",
 	})
-	internal.CmpStr(t, expectedView, vp.View())
+	testutil.CmpStr(t, expectedView, vp.View())
 }
 
 func TestViewport_SelectionOn_WrapOff_StringToHighlightManyMatches(t *testing.T) {
@@ -2381,13 +2514,13 @@ func TestViewport_SelectionOn_WrapOff_StringToHighlightManyMatches(t *testing.T)
 			HighlightStyleIfSelected: lipgloss.NewStyle().Foreground(red),
 			SelectedItemStyle:        selectionStyle,
 		})
-		expectedView := pad(vp.GetWidth(), vp.GetHeight(), []string{
+		expectedView := testutil.Pad(vp.GetWidth(), vp.GetHeight(), []string{
 			"header",
 			strings.Repeat("\x1b[38;2;255;0;0mr\x1b[m", 7) + "\x1b[38;2;0;0;255m" + strings.Repeat(".", 3) + "\x1b[m",
 		})
-		internal.CmpStr(t, expectedView, vp.View())
+		testutil.CmpStr(t, expectedView, vp.View())
 	}
-	internal.RunWithTimeout(t, runTest, 10*time.Millisecond)
+	testutil.RunWithTimeout(t, runTest, 10*time.Millisecond)
 }
 
 func TestViewport_SelectionOn_WrapOff_RegexToHighlightManyMatches(t *testing.T) {
@@ -2406,13 +2539,13 @@ func TestViewport_SelectionOn_WrapOff_RegexToHighlightManyMatches(t *testing.T)
 			HighlightStyleIfSelected: lipgloss.NewStyle().Foreground(red),
 			SelectedItemStyle:        selectionStyle,
 		})
-		expectedView := pad(vp.GetWidth(), vp.GetHeight(), []string{
+		expectedView := testutil.Pad(vp.GetWidth(), vp.GetHeight(), []string{
 			"header",
 			strings.Repeat("\x1b[38;2;255;0;0mr\x1b[m", 7) + "\x1b[38;2;0;0;255m" + strings.Repeat(".", 3) + "\x1b[m",
 		})
-		internal.CmpStr(t, expectedView, vp.View())
+		testutil.CmpStr(t, expectedView, vp.View())
 	}
-	internal.RunWithTimeout(t, runTest, 10*time.Millisecond)
+	testutil.RunWithTimeout(t, runTest, 10*time.Millisecond)
 }
 
 func TestViewport_SelectionOn_WrapOff_AnsiOnSelection(t *testing.T) {
@@ -2423,11 +2556,11 @@ func TestViewport_SelectionOn_WrapOff_AnsiOnSelection(t *testing.T) {
 	setContent(&vp, []string{
 		"line with \x1b[38;2;255;0;0mred\x1b[m text",
 	})
-	expectedView := pad(vp.GetWidth(), vp.GetHeight(), []string{
+	expectedView := testutil.Pad(vp.GetWidth(), vp.GetHeight(), []string{
 		"header",
 		"\x1b[38;2;0;0;255mline with \x1b[m\x1b[38;2;255;0;0mred\x1b[m\x1b[38;2;0;0;255m text\x1b[m",
 	})
-	internal.CmpStr(t, expectedView, vp.View())
+	testutil.CmpStr(t, expectedView, vp.View())
 }
 
 func TestViewport_SelectionOn_WrapOff_SelectionEmpty(t *testing.T) {
@@ -2438,11 +2571,11 @@ func TestViewport_SelectionOn_WrapOff_SelectionEmpty(t *testing.T) {
 	setContent(&vp, []string{
 		"",
 	})
-	expectedView := pad(vp.GetWidth(), vp.GetHeight(), []string{
+	expectedView := testutil.Pad(vp.GetWidth(), vp.GetHeight(), []string{
 		"header",
 		"\x1b[38;2;0;0;255m \x1b[m",
 	})
-	internal.CmpStr(t, expectedView, vp.View())
+	testutil.CmpStr(t, expectedView, vp.View())
 }
 
 func TestViewport_SelectionOn_WrapOff_ExtraSlash(t *testing.T) {
@@ -2453,11 +2586,11 @@ func TestViewport_SelectionOn_WrapOff_ExtraSlash(t *testing.T) {
 	setContent(&vp, []string{
 		"|2024|\x1b[38;2;0mfl..lq\x1b[m/\x1b[38;2;0mflask-3\x1b[m|",
 	})
-	expectedView := pad(vp.GetWidth(), vp.GetHeight(), []string{
+	expectedView := testutil.Pad(vp.GetWidth(), vp.GetHeight(), []string{
 		"header",
 		"\x1b[38;2;0;0;255m|2024|\x1b[m\x1b[38;2;0mfl..lq\x1b[m\x1b[38;2;0;0;255m/\x1b[m\x1b[38;2;0mflask-3\x1b[m\x1b[38;2;0;0;255m|\x1b[m",
 	})
-	internal.CmpStr(t, expectedView, vp.View())
+	testutil.CmpStr(t, expectedView, vp.View())
 }
 
 func TestViewport_SelectionOn_WrapOff_StringToHighlightAnsiUnicode(t *testing.T) {
@@ -2477,12 +2610,12 @@ func TestViewport_SelectionOn_WrapOff_StringToHighlightAnsiUnicode(t *testing.T)
 		HighlightStyleIfSelected: lipgloss.NewStyle().Foreground(red),
 		SelectedItemStyle:        selectionStyle,
 	})
-	expectedView := pad(vp.GetWidth(), vp.GetHeight(), []string{
+	expectedView := testutil.Pad(vp.GetWidth(), vp.GetHeight(), []string{
 		"A💖中é",
 		"\x1b[38;2;0;0;255mA💖\x1b[m\x1b[38;2;255;0;0m中é\x1b[m",
 		"A💖\x1b[38;2;0;255;0m中é\x1b[m...",
 	})
-	internal.CmpStr(t, expectedView, vp.View())
+	testutil.CmpStr(t, expectedView, vp.View())
 }
 
 // # SELECTION DISABLED, WRAP ON
@@ -2491,11 +2624,11 @@ func TestViewport_SelectionOff_WrapOn_Empty(t *testing.T) {
 	w, h := 15, 5
 	vp := newViewport(w, h)
 	vp.SetWrapText(true)
-	expectedView := pad(vp.GetWidth(), vp.GetHeight(), []string{})
-	internal.CmpStr(t, expectedView, vp.View())
+	expectedView := testutil.Pad(vp.GetWidth(), vp.GetHeight(), []string{})
+	testutil.CmpStr(t, expectedView, vp.View())
 	vp.SetHeader([]string{"header"})
-	expectedView = pad(vp.GetWidth(), vp.GetHeight(), []string{"header"})
-	internal.CmpStr(t, expectedView, vp.View())
+	expectedView = testutil.Pad(vp.GetWidth(), vp.GetHeight(), []string{"header"})
+	testutil.CmpStr(t, expectedView, vp.View())
 }
 
 func TestViewport_SelectionOff_WrapOn_SmolDimensions(t *testing.T) {
@@ -2504,28 +2637,28 @@ func TestViewport_SelectionOff_WrapOn_SmolDimensions(t *testing.T) {
 	vp.SetWrapText(true)
 	vp.SetHeader([]string{"header"})
 	setContent(&vp, []string{"hi"})
-	expectedView := pad(vp.GetWidth(), vp.GetHeight(), []string{""})
-	internal.CmpStr(t, expectedView, vp.View())
+	expectedView := testutil.Pad(vp.GetWidth(), vp.GetHeight(), []string{""})
+	testutil.CmpStr(t, expectedView, vp.View())
 
 	vp.SetWidth(1)
 	vp.SetHeight(1)
-	expectedView = pad(vp.GetWidth(), vp.GetHeight(), []string{"h"})
-	internal.CmpStr(t, expectedView, vp.View())
+	expectedView = testutil.Pad(vp.GetWidth(), vp.GetHeight(), []string{"h"})
+	testutil.CmpStr(t, expectedView, vp.View())
 
 	vp.SetWidth(2)
 	vp.SetHeight(2)
-	expectedView = pad(vp.GetWidth(), vp.GetHeight(), []string{"he", "ad"})
-	internal.CmpStr(t, expectedView, vp.View())
+	expectedView = testutil.Pad(vp.GetWidth(), vp.GetHeight(), []string{"he", "ad"})
+	testutil.CmpStr(t, expectedView, vp.View())
 
 	vp.SetWidth(3)
 	vp.SetHeight(3)
-	expectedView = pad(vp.GetWidth(), vp.GetHeight(), []string{"hea", "der", ""})
-	internal.CmpStr(t, expectedView, vp.View())
+	expectedView = testutil.Pad(vp.GetWidth(), vp.GetHeight(), []string{"hea", "der", ""})
+	testutil.CmpStr(t, expectedView, vp.View())
 
 	vp.SetWidth(4)
 	vp.SetHeight(4)
-	expectedView = pad(vp.GetWidth(), vp.GetHeight(), []string{"head", "er", "hi", "1..."})
-	internal.CmpStr(t, expectedView, vp.View())
+	expectedView = testutil.Pad(vp.GetWidth(), vp.GetHeight(), []string{"head", "er", "hi", "1..."})
+	testutil.CmpStr(t, expectedView, vp.View())
 }
 
 func TestViewport_SelectionOff_WrapOn_Basic(t *testing.T) {
@@ -2539,7 +2672,7 @@ func TestViewport_SelectionOff_WrapOn_Basic(t *testing.T) {
 		lipgloss.NewStyle().Foreground(red).Render("a really really long line"),
 		lipgloss.NewStyle().Foreground(red).Render("a") + " really really long line",
 	})
-	expectedView := pad(vp.GetWidth(), vp.GetHeight(), []string{
+	expectedView := testutil.Pad(vp.GetWidth(), vp.GetHeight(), []string{
 		"header",
 		"first line",
 		"\x1b[38;2;255;0;0msecond\x1b[m line",
@@ -2547,7 +2680,7 @@ func TestViewport_SelectionOff_WrapOn_Basic(t *testing.T) {
 		"\x1b[38;2;255;0;0m long line\x1b[m",
 		"75% (3/4)",
 	})
-	internal.CmpStr(t, expectedView, vp.View())
+	testutil.CmpStr(t, expectedView, vp.View())
 }
 
 func TestViewport_SelectionOff_WrapOn_GetConfigs(t *testing.T) {
@@ -2584,7 +2717,7 @@ func TestViewport_SelectionOff_WrapOn_ShowFooter(t *testing.T) {
 		lipgloss.NewStyle().Foreground(red).Render("a really really long line"),
 		lipgloss.NewStyle().Foreground(red).Render("a") + " really really long line",
 	})
-	expectedView := pad(vp.GetWidth(), vp.GetHeight(), []string{
+	expectedView := testutil.Pad(vp.GetWidth(), vp.GetHeight(), []string{
 		"header",
 		"first line",
 		"\x1b[38;2;255;0;0msecond\x1b[m line",
@@ -2593,10 +2726,10 @@ func TestViewport_SelectionOff_WrapOn_ShowFooter(t *testing.T) {
 		"\x1b[38;2;255;0;0ma\x1b[m really really",
 		"99% (4/4)",
 	})
-	internal.CmpStr(t, expectedView, vp.View())
+	testutil.CmpStr(t, expectedView, vp.View())
 
 	vp.SetHeight(8)
-	expectedView = pad(vp.GetWidth(), vp.GetHeight(), []string{
+	expectedView = testutil.Pad(vp.GetWidth(), vp.GetHeight(), []string{
 		"header",
 		"first line",
 		"\x1b[38;2;255;0;0msecond\x1b[m line",
@@ -2606,10 +2739,10 @@ func TestViewport_SelectionOff_WrapOn_ShowFooter(t *testing.T) {
 		" long line",
 		"100% (4/4)",
 	})
-	internal.CmpStr(t, expectedView, vp.View())
+	testutil.CmpStr(t, expectedView, vp.View())
 
 	vp.SetHeight(9)
-	expectedView = pad(vp.GetWidth(), vp.GetHeight(), []string{
+	expectedView = testutil.Pad(vp.GetWidth(), vp.GetHeight(), []string{
 		"header",
 		"first line",
 		"\x1b[38;2;255;0;0msecond\x1b[m line",
@@ -2618,7 +2751,7 @@ func TestViewport_SelectionOff_WrapOn_ShowFooter(t *testing.T) {
 		"\x1b[38;2;255;0;0ma\x1b[m really really",
 		" long line",
 	})
-	internal.CmpStr(t, expectedView, vp.View())
+	testutil.CmpStr(t, expectedView, vp.View())
 }
 
 func TestViewport_SelectionOff_WrapOn_FooterStyle(t *testing.T) {
@@ -2638,14 +2771,14 @@ func TestViewport_SelectionOff_WrapOn_FooterStyle(t *testing.T) {
 		"3",
 		"4",
 	})
-	expectedView := pad(vp.GetWidth(), vp.GetHeight(), []string{
+	expectedView := testutil.Pad(vp.GetWidth(), vp.GetHeight(), []string{
 		"header",
 		"1",
 		"2",
 		"3",
 		"\x1b[38;2;255;0;0m75% (3/4)\x1b[m",
 	})
-	internal.CmpStr(t, expectedView, vp.View())
+	testutil.CmpStr(t, expectedView, vp.View())
 }
 
 func TestViewport_SelectionOff_WrapOn_FooterDisabled(t *testing.T) {
@@ -2659,24 +2792,24 @@ func TestViewport_SelectionOff_WrapOn_FooterDisabled(t *testing.T) {
 		"third line",
 		"fourth line",
 	})
-	expectedView := pad(vp.GetWidth(), vp.GetHeight(), []string{
+	expectedView := testutil.Pad(vp.GetWidth(), vp.GetHeight(), []string{
 		"header",
 		"first line",
 		"second line",
 		"third line",
 		"75% (3/4)",
 	})
-	internal.CmpStr(t, expectedView, vp.View())
+	testutil.CmpStr(t, expectedView, vp.View())
 
 	vp.SetFooterEnabled(false)
-	expectedView = pad(vp.GetWidth(), vp.GetHeight(), []string{
+	expectedView = testutil.Pad(vp.GetWidth(), vp.GetHeight(), []string{
 		"header",
 		"first line",
 		"second line",
 		"third line",
 		"fourth line",
 	})
-	internal.CmpStr(t, expectedView, vp.View())
+	testutil.CmpStr(t, expectedView, vp.View())
 }
 
 func TestViewport_SelectionOff_WrapOn_SpaceAround(t *testing.T) {
@@ -2690,14 +2823,14 @@ func TestViewport_SelectionOff_WrapOn_SpaceAround(t *testing.T) {
 		"               first line               ",
 	})
 	// trailing space is not trimmed
-	expectedView := pad(vp.GetWidth(), vp.GetHeight(), []string{
+	expectedView := testutil.Pad(vp.GetWidth(), vp.GetHeight(), []string{
 		"header",
 		"    first line ",
 		"",
 		"          first",
 		"66% (2/3)",
 	})
-	internal.CmpStr(t, expectedView, vp.View())
+	testutil.CmpStr(t, expectedView, vp.View())
 }
 
 func TestViewport_SelectionOff_WrapOn_MultiHeader(t *testing.T) {
@@ -2709,55 +2842,55 @@ func TestViewport_SelectionOff_WrapOn_MultiHeader(t *testing.T) {
 		"line1",
 		"line2",
 	})
-	expectedView := pad(vp.GetWidth(), vp.GetHeight(), []string{
+	expectedView := testutil.Pad(vp.GetWidth(), vp.GetHeight(), []string{
 		"header1",
 		"header2",
 	})
-	internal.CmpStr(t, expectedView, vp.View())
+	testutil.CmpStr(t, expectedView, vp.View())
 
 	vp.SetHeight(3)
-	expectedView = pad(vp.GetWidth(), vp.GetHeight(), []string{
+	expectedView = testutil.Pad(vp.GetWidth(), vp.GetHeight(), []string{
 		"header1",
 		"header2",
 	})
-	internal.CmpStr(t, expectedView, vp.View())
+	testutil.CmpStr(t, expectedView, vp.View())
 
 	vp.SetHeight(4)
-	expectedView = pad(vp.GetWidth(), vp.GetHeight(), []string{
+	expectedView = testutil.Pad(vp.GetWidth(), vp.GetHeight(), []string{
 		"header1",
 		"header2",
 		"line1",
 		"50% (1/2)",
 	})
-	internal.CmpStr(t, expectedView, vp.View())
+	testutil.CmpStr(t, expectedView, vp.View())
 
 	vp, _ = vp.Update(downKeyMsg)
-	expectedView = pad(vp.GetWidth(), vp.GetHeight(), []string{
+	expectedView = testutil.Pad(vp.GetWidth(), vp.GetHeight(), []string{
 		"header1",
 		"header2",
 		"line2",
 		"100% (2/2)",
 	})
-	internal.CmpStr(t, expectedView, vp.View())
+	testutil.CmpStr(t, expectedView, vp.View())
 
 	vp.SetHeight(5)
-	expectedView = pad(vp.GetWidth(), vp.GetHeight(), []string{
+	expectedView = testutil.Pad(vp.GetWidth(), vp.GetHeight(), []string{
 		"header1",
 		"header2",
 		"line1",
 		"line2",
 		"100% (2/2)",
 	})
-	internal.CmpStr(t, expectedView, vp.View())
+	testutil.CmpStr(t, expectedView, vp.View())
 
 	vp.SetHeight(6)
-	expectedView = pad(vp.GetWidth(), vp.GetHeight(), []string{
+	expectedView = testutil.Pad(vp.GetWidth(), vp.GetHeight(), []string{
 		"header1",
 		"header2",
 		"line1",
 		"line2",
 	})
-	internal.CmpStr(t, expectedView, vp.View())
+	testutil.CmpStr(t, expectedView, vp.View())
 }
 
 func TestViewport_SelectionOff_WrapOn_OverflowLine(t *testing.T) {
@@ -2769,7 +2902,7 @@ func TestViewport_SelectionOff_WrapOn_OverflowLine(t *testing.T) {
 		"123456789012345",
 		"1234567890123456",
 	})
-	expectedView := pad(vp.GetWidth(), vp.GetHeight(), []string{
+	expectedView := testutil.Pad(vp.GetWidth(), vp.GetHeight(), []string{
 		"long header ove",
 		"rflows",
 		"123456789012345",
@@ -2777,7 +2910,7 @@ func TestViewport_SelectionOff_WrapOn_OverflowLine(t *testing.T) {
 		"6",
 		"100% (2/2)",
 	})
-	internal.CmpStr(t, expectedView, vp.View())
+	testutil.CmpStr(t, expectedView, vp.View())
 }
 
 func TestViewport_SelectionOff_WrapOn_OverflowHeight(t *testing.T) {
@@ -2793,7 +2926,7 @@ func TestViewport_SelectionOff_WrapOn_OverflowHeight(t *testing.T) {
 		"1234567890123456",
 		"1234567890123456",
 	})
-	expectedView := pad(vp.GetWidth(), vp.GetHeight(), []string{
+	expectedView := testutil.Pad(vp.GetWidth(), vp.GetHeight(), []string{
 		"header",
 		"123456789012345",
 		"123456789012345",
@@ -2801,7 +2934,7 @@ func TestViewport_SelectionOff_WrapOn_OverflowHeight(t *testing.T) {
 		"123456789012345",
 		"50% (3/6)",
 	})
-	internal.CmpStr(t, expectedView, vp.View())
+	testutil.CmpStr(t, expectedView, vp.View())
 }
 
 func TestViewport_SelectionOff_WrapOn_Scrolling(t *testing.T) {
@@ -2821,12 +2954,12 @@ func TestViewport_SelectionOff_WrapOn_Scrolling(t *testing.T) {
 	}
 	validate := func(expectedView string) {
 		// set content multiple times to confirm no side effects of doing it
-		internal.CmpStr(t, expectedView, vp.View())
+		testutil.CmpStr(t, expectedView, vp.View())
 		doSetContent()
-		internal.CmpStr(t, expectedView, vp.View())
+		testutil.CmpStr(t, expectedView, vp.View())
 	}
 	doSetContent()
-	expectedView := pad(vp.GetWidth(), vp.GetHeight(), []string{
+	expectedView := testutil.Pad(vp.GetWidth(), vp.GetHeight(), []string{
 		"header",
 		"first",
 		"second",
@@ -2842,7 +2975,7 @@ func TestViewport_SelectionOff_WrapOn_Scrolling(t *testing.T) {
 
 	// scrolling down by one
 	vp, _ = vp.Update(downKeyMsg)
-	expectedView = pad(vp.GetWidth(), vp.GetHeight(), []string{
+	expectedView = testutil.Pad(vp.GetWidth(), vp.GetHeight(), []string{
 		"header",
 		"second",
 		"third",
@@ -2854,7 +2987,7 @@ func TestViewport_SelectionOff_WrapOn_Scrolling(t *testing.T) {
 
 	// scrolling down by one again
 	vp, _ = vp.Update(downKeyMsg)
-	expectedView = pad(vp.GetWidth(), vp.GetHeight(), []string{
+	expectedView = testutil.Pad(vp.GetWidth(), vp.GetHeight(), []string{
 		"header",
 		"third",
 		"fourth",
@@ -2879,33 +3012,33 @@ func TestViewport_SelectionOff_WrapOn_ScrollToItem(t *testing.T) {
 		"the second line",
 		"the third line",
 	})
-	expectedView := pad(vp.GetWidth(), vp.GetHeight(), []string{
+	expectedView := testutil.Pad(vp.GetWidth(), vp.GetHeight(), []string{
 		"header",
 		"the first",
 		"line",
 		"33% (1/3)",
 	})
-	internal.CmpStr(t, expectedView, vp.View())
+	testutil.CmpStr(t, expectedView, vp.View())
 
 	// scroll so last item in view
 	vp.ScrollSoItemIdxInView(2)
-	expectedView = pad(vp.GetWidth(), vp.GetHeight(), []string{
+	expectedView = testutil.Pad(vp.GetWidth(), vp.GetHeight(), []string{
 		"header",
 		"the third",
 		"line",
 		"100% (3/3)",
 	})
-	internal.CmpStr(t, expectedView, vp.View())
+	testutil.CmpStr(t, expectedView, vp.View())
 
 	// scroll so second item in view
 	vp.ScrollSoItemIdxInView(1)
-	expectedView = pad(vp.GetWidth(), vp.GetHeight(), []string{
+	expectedView = testutil.Pad(vp.GetWidth(), vp.GetHeight(), []string{
 		"header",
 		"the second",
 		" line",
 		"66% (2/3)",
 	})
-	internal.CmpStr(t, expectedView, vp.View())
+	testutil.CmpStr(t, expectedView, vp.View())
 }
 
 func TestViewport_SelectionOff_WrapOn_BulkScrolling(t *testing.T) {
@@ -2918,93 +3051,93 @@ func TestViewport_SelectionOff_WrapOn_BulkScrolling(t *testing.T) {
 		"the second line",
 		"the third line",
 	})
-	expectedView := pad(vp.GetWidth(), vp.GetHeight(), []string{
+	expectedView := testutil.Pad(vp.GetWidth(), vp.GetHeight(), []string{
 		"header",
 		"the first",
 		"line",
 		"33% (1/3)",
 	})
-	internal.CmpStr(t, expectedView, vp.View())
+	testutil.CmpStr(t, expectedView, vp.View())
 
 	// full page down
 	vp, _ = vp.Update(fullPgDownKeyMsg)
-	expectedView = pad(vp.GetWidth(), vp.GetHeight(), []string{
+	expectedView = testutil.Pad(vp.GetWidth(), vp.GetHeight(), []string{
 		"header",
 		"the second",
 		" line",
 		"66% (2/3)",
 	})
-	internal.CmpStr(t, expectedView, vp.View())
+	testutil.CmpStr(t, expectedView, vp.View())
 
 	// half page down
 	vp, _ = vp.Update(halfPgDownKeyMsg)
-	expectedView = pad(vp.GetWidth(), vp.GetHeight(), []string{
+	expectedView = testutil.Pad(vp.GetWidth(), vp.GetHeight(), []string{
 		"header",
 		" line",
 		"the third ",
 		"99% (3/3)",
 	})
-	internal.CmpStr(t, expectedView, vp.View())
+	testutil.CmpStr(t, expectedView, vp.View())
 
 	// full page down
 	vp, _ = vp.Update(fullPgDownKeyMsg)
-	expectedView = pad(vp.GetWidth(), vp.GetHeight(), []string{
+	expectedView = testutil.Pad(vp.GetWidth(), vp.GetHeight(), []string{
 		"header",
 		"the third ",
 		"line",
 		"100% (3/3)",
 	})
-	internal.CmpStr(t, expectedView, vp.View())
+	testutil.CmpStr(t, expectedView, vp.View())
 
 	// full page up
 	vp, _ = vp.Update(fullPgUpKeyMsg)
-	expectedView = pad(vp.GetWidth(), vp.GetHeight(), []string{
+	expectedView = testutil.Pad(vp.GetWidth(), vp.GetHeight(), []string{
 		"header",
 		"the second",
 		" line",
 		"66% (2/3)",
 	})
-	internal.CmpStr(t, expectedView, vp.View())
+	testutil.CmpStr(t, expectedView, vp.View())
 
 	// half page up
 	vp, _ = vp.Update(halfPgUpKeyMsg)
-	expectedView = pad(vp.GetWidth(), vp.GetHeight(), []string{
+	expectedView = testutil.Pad(vp.GetWidth(), vp.GetHeight(), []string{
 		"header",
 		"line",
 		"the second",
 		"66% (2/3)",
 	})
-	internal.CmpStr(t, expectedView, vp.View())
+	testutil.CmpStr(t, expectedView, vp.View())
 
 	// full page up
 	vp, _ = vp.Update(fullPgUpKeyMsg)
-	expectedView = pad(vp.GetWidth(), vp.GetHeight(), []string{
+	expectedView = testutil.Pad(vp.GetWidth(), vp.GetHeight(), []string{
 		"header",
 		"the first",
 		"line",
 		"33% (1/3)",
 	})
-	internal.CmpStr(t, expectedView, vp.View())
+	testutil.CmpStr(t, expectedView, vp.View())
 
 	// go to bottom
 	vp, _ = vp.Update(goToBottomKeyMsg)
-	expectedView = pad(vp.GetWidth(), vp.GetHeight(), []string{
+	expectedView = testutil.Pad(vp.GetWidth(), vp.GetHeight(), []string{
 		"header",
 		"the third ",
 		"line",
 		"100% (3/3)",
 	})
-	internal.CmpStr(t, expectedView, vp.View())
+	testutil.CmpStr(t, expectedView, vp.View())
 
 	// go to top
 	vp, _ = vp.Update(goToTopKeyMsg)
-	expectedView = pad(vp.GetWidth(), vp.GetHeight(), []string{
+	expectedView = testutil.Pad(vp.GetWidth(), vp.GetHeight(), []string{
 		"header",
 		"the first",
 		"line",
 		"33% (1/3)",
 	})
-	internal.CmpStr(t, expectedView, vp.View())
+	testutil.CmpStr(t, expectedView, vp.View())
 }
 
 func TestViewport_SelectionOff_WrapOn_Panning(t *testing.T) {
@@ -3024,12 +3157,12 @@ func TestViewport_SelectionOff_WrapOn_Panning(t *testing.T) {
 	}
 	validate := func(expectedView string) {
 		// set content multiple times to confirm no side effects of doing it
-		internal.CmpStr(t, expectedView, vp.View())
+		testutil.CmpStr(t, expectedView, vp.View())
 		doSetContent()
-		internal.CmpStr(t, expectedView, vp.View())
+		testutil.CmpStr(t, expectedView, vp.View())
 	}
 	doSetContent()
-	expectedView := pad(vp.GetWidth(), vp.GetHeight(), []string{
+	expectedView := testutil.Pad(vp.GetWidth(), vp.GetHeight(), []string{
 		"header lon",
 		"g",
 		"first line",
@@ -3046,7 +3179,7 @@ func TestViewport_SelectionOff_WrapOn_Panning(t *testing.T) {
 
 	// scroll down
 	vp, _ = vp.Update(downKeyMsg)
-	expectedView = pad(vp.GetWidth(), vp.GetHeight(), []string{
+	expectedView = testutil.Pad(vp.GetWidth(), vp.GetHeight(), []string{
 		"header lon",
 		"g",
 		" that is f",
@@ -3063,7 +3196,7 @@ func TestViewport_SelectionOff_WrapOn_Panning(t *testing.T) {
 
 	// scroll down
 	vp, _ = vp.Update(downKeyMsg)
-	expectedView = pad(vp.GetWidth(), vp.GetHeight(), []string{
+	expectedView = testutil.Pad(vp.GetWidth(), vp.GetHeight(), []string{
 		"header lon",
 		"g",
 		"airly long",
@@ -3076,7 +3209,7 @@ func TestViewport_SelectionOff_WrapOn_Panning(t *testing.T) {
 
 	// scroll down
 	vp, _ = vp.Update(downKeyMsg)
-	expectedView = pad(vp.GetWidth(), vp.GetHeight(), []string{
+	expectedView = testutil.Pad(vp.GetWidth(), vp.GetHeight(), []string{
 		"header lon",
 		"g",
 		"second lin",
@@ -3098,46 +3231,46 @@ func TestViewport_SelectionOff_WrapOn_ChangeHeight(t *testing.T) {
 		"the second line",
 		"the third line",
 	})
-	expectedView := pad(vp.GetWidth(), vp.GetHeight(), []string{
+	expectedView := testutil.Pad(vp.GetWidth(), vp.GetHeight(), []string{
 		"header",
 		"the first",
 		"line",
 		"33% (1/3)",
 	})
-	internal.CmpStr(t, expectedView, vp.View())
+	testutil.CmpStr(t, expectedView, vp.View())
 
 	// scroll down to bottom
 	vp, _ = vp.Update(fullPgDownKeyMsg)
 	vp, _ = vp.Update(fullPgDownKeyMsg)
-	expectedView = pad(vp.GetWidth(), vp.GetHeight(), []string{
+	expectedView = testutil.Pad(vp.GetWidth(), vp.GetHeight(), []string{
 		"header",
 		"the third",
 		"line",
 		"100% (3/3)",
 	})
-	internal.CmpStr(t, expectedView, vp.View())
+	testutil.CmpStr(t, expectedView, vp.View())
 
 	// reduce height
 	vp.SetHeight(3)
-	expectedView = pad(vp.GetWidth(), vp.GetHeight(), []string{
+	expectedView = testutil.Pad(vp.GetWidth(), vp.GetHeight(), []string{
 		"header",
 		"the third",
 		"99% (3/3)",
 	})
-	internal.CmpStr(t, expectedView, vp.View())
+	testutil.CmpStr(t, expectedView, vp.View())
 
 	// scroll down
 	vp, _ = vp.Update(downKeyMsg)
-	expectedView = pad(vp.GetWidth(), vp.GetHeight(), []string{
+	expectedView = testutil.Pad(vp.GetWidth(), vp.GetHeight(), []string{
 		"header",
 		"line",
 		"100% (3/3)",
 	})
-	internal.CmpStr(t, expectedView, vp.View())
+	testutil.CmpStr(t, expectedView, vp.View())
 
 	// increase height
 	vp.SetHeight(8)
-	expectedView = pad(vp.GetWidth(), vp.GetHeight(), []string{
+	expectedView = testutil.Pad(vp.GetWidth(), vp.GetHeight(), []string{
 		"header",
 		"the first",
 		"line",
@@ -3147,7 +3280,7 @@ func TestViewport_SelectionOff_WrapOn_ChangeHeight(t *testing.T) {
 		"line",
 		"100% (3/3)",
 	})
-	internal.CmpStr(t, expectedView, vp.View())
+	testutil.CmpStr(t, expectedView, vp.View())
 }
 
 func TestViewport_SelectionOff_WrapOn_ChangeContent(t *testing.T) {
@@ -3160,37 +3293,37 @@ func TestViewport_SelectionOff_WrapOn_ChangeContent(t *testing.T) {
 		"the second line",
 		"the third line",
 	})
-	expectedView := pad(vp.GetWidth(), vp.GetHeight(), []string{
+	expectedView := testutil.Pad(vp.GetWidth(), vp.GetHeight(), []string{
 		"header",
 		"the first",
 		"line",
 		"33% (1/3)",
 	})
-	internal.CmpStr(t, expectedView, vp.View())
+	testutil.CmpStr(t, expectedView, vp.View())
 
 	// scroll down to bottom
 	vp, _ = vp.Update(fullPgDownKeyMsg)
 	vp, _ = vp.Update(fullPgDownKeyMsg)
-	expectedView = pad(vp.GetWidth(), vp.GetHeight(), []string{
+	expectedView = testutil.Pad(vp.GetWidth(), vp.GetHeight(), []string{
 		"header",
 		"the third",
 		"line",
 		"100% (3/3)",
 	})
-	internal.CmpStr(t, expectedView, vp.View())
+	testutil.CmpStr(t, expectedView, vp.View())
 
 	// remove content
 	setContent(&vp, []string{
 		"the first line",
 		"the second line",
 	})
-	expectedView = pad(vp.GetWidth(), vp.GetHeight(), []string{
+	expectedView = testutil.Pad(vp.GetWidth(), vp.GetHeight(), []string{
 		"header",
 		"the second",
 		" line",
 		"100% (2/2)",
 	})
-	internal.CmpStr(t, expectedView, vp.View())
+	testutil.CmpStr(t, expectedView, vp.View())
 
 	// add content
 	setContent(&vp, []string{
@@ -3199,20 +3332,20 @@ func TestViewport_SelectionOff_WrapOn_ChangeContent(t *testing.T) {
 		"the third line",
 		"the fourth line",
 	})
-	expectedView = pad(vp.GetWidth(), vp.GetHeight(), []string{
+	expectedView = testutil.Pad(vp.GetWidth(), vp.GetHeight(), []string{
 		"header",
 		"the second",
 		" line",
 		"50% (2/4)",
 	})
-	internal.CmpStr(t, expectedView, vp.View())
+	testutil.CmpStr(t, expectedView, vp.View())
 
 	// remove all content
 	setContent(&vp, []string{})
-	expectedView = pad(vp.GetWidth(), vp.GetHeight(), []string{
+	expectedView = testutil.Pad(vp.GetWidth(), vp.GetHeight(), []string{
 		"header",
 	})
-	internal.CmpStr(t, expectedView, vp.View())
+	testutil.CmpStr(t, expectedView, vp.View())
 }
 
 func TestViewport_SelectionOff_WrapOn_StringToHighlight(t *testing.T) {
@@ -3233,27 +3366,27 @@ func TestViewport_SelectionOff_WrapOn_StringToHighlight(t *testing.T) {
 		"second",
 		"third",
 	})
-	expectedView := pad(vp.GetWidth(), vp.GetHeight(), []string{
+	expectedView := testutil.Pad(vp.GetWidth(), vp.GetHeight(), []string{
 		"header",
 		"first",
 		"\x1b[38;2;255;0;0msecond\x1b[m",
 		"\x1b[38;2;255;0;0msecond\x1b[m",
 		"75% (3/4)",
 	})
-	internal.CmpStr(t, expectedView, vp.View())
+	testutil.CmpStr(t, expectedView, vp.View())
 
 	setContent(&vp, []string{
 		"averylongwordthatwraps",
 	})
 	vp.SetStringToHighlight("wraps")
-	expectedView = pad(vp.GetWidth(), vp.GetHeight(), []string{
+	expectedView = testutil.Pad(vp.GetWidth(), vp.GetHeight(), []string{
 		"header",
 		"averylongw",
 		"ordthat\x1b[38;2;255;0;0mwra\x1b[m",
 		"\x1b[38;2;255;0;0mps\x1b[m",
 		"100% (1/1)",
 	})
-	internal.CmpStr(t, expectedView, vp.View())
+	testutil.CmpStr(t, expectedView, vp.View())
 }
 
 func TestViewport_SelectionOff_WrapOn_RegexToHighlight(t *testing.T) {
@@ -3274,14 +3407,14 @@ func TestViewport_SelectionOff_WrapOn_RegexToHighlight(t *testing.T) {
 		"second",
 		"third",
 	})
-	expectedView := pad(vp.GetWidth(), vp.GetHeight(), []string{
+	expectedView := testutil.Pad(vp.GetWidth(), vp.GetHeight(), []string{
 		"header",
 		"first",
 		"\x1b[38;2;255;0;0msecond\x1b[m",
 		"\x1b[38;2;255;0;0msecond\x1b[m",
 		"75% (3/4)",
 	})
-	internal.CmpStr(t, expectedView, vp.View())
+	testutil.CmpStr(t, expectedView, vp.View())
 }
 
 func TestViewport_SelectionOff_WrapOn_StringToHighlightManyMatches(t *testing.T) {
@@ -3300,16 +3433,16 @@ func TestViewport_SelectionOff_WrapOn_StringToHighlightManyMatches(t *testing.T)
 			HighlightStyleIfSelected: lipgloss.NewStyle().Foreground(red),
 			SelectedItemStyle:        selectionStyle,
 		})
-		expectedView := pad(vp.GetWidth(), vp.GetHeight(), []string{
+		expectedView := testutil.Pad(vp.GetWidth(), vp.GetHeight(), []string{
 			"header",
 			strings.Repeat("\x1b[38;2;0;255;0mr\x1b[m", 10),
 			strings.Repeat("\x1b[38;2;0;255;0mr\x1b[m", 10),
 			strings.Repeat("\x1b[38;2;0;255;0mr\x1b[m", 10),
 			"99% (1/1)",
 		})
-		internal.CmpStr(t, expectedView, vp.View())
+		testutil.CmpStr(t, expectedView, vp.View())
 	}
-	internal.RunWithTimeout(t, runTest, 10*time.Millisecond)
+	testutil.RunWithTimeout(t, runTest, 10*time.Millisecond)
 }
 
 func TestViewport_SelectionOff_WrapOn_RegexToHighlightManyMatches(t *testing.T) {
@@ -3328,16 +3461,16 @@ func TestViewport_SelectionOff_WrapOn_RegexToHighlightManyMatches(t *testing.T)
 			HighlightStyleIfSelected: lipgloss.NewStyle().Foreground(red),
 			SelectedItemStyle:        selectionStyle,
 		})
-		expectedView := pad(vp.GetWidth(), vp.GetHeight(), []string{
+		expectedView := testutil.Pad(vp.GetWidth(), vp.GetHeight(), []string{
 			"header",
 			strings.Repeat("\x1b[38;2;0;255;0mr\x1b[m", 10),
 			strings.Repeat("\x1b[38;2;0;255;0mr\x1b[m", 10),
 			strings.Repeat("\x1b[38;2;0;255;0mr\x1b[m", 10),
 			"99% (1/1)",
 		})
-		internal.CmpStr(t, expectedView, vp.View())
+		testutil.CmpStr(t, expectedView, vp.View())
 	}
-	internal.RunWithTimeout(t, runTest, 10*time.Millisecond)
+	testutil.RunWithTimeout(t, runTest, 10*time.Millisecond)
 }
 
 func TestViewport_SelectionOff_WrapOn_RegexToHighlightMissesWrap(t *testing.T) {
@@ -3357,26 +3490,26 @@ func TestViewport_SelectionOff_WrapOn_RegexToHighlightMissesWrap(t *testing.T) {
 			SelectedItemStyle:        selectionStyle,
 		})
 		// regex matches aren't shown for wrapped lines for performance reasons (lines could be extremely long)
-		expectedView := pad(vp.GetWidth(), vp.GetHeight(), []string{
+		expectedView := testutil.Pad(vp.GetWidth(), vp.GetHeight(), []string{
 			"header",
 			"this is to",
 			"o long and",
 			" triggers ",
 			"99% (1/1)",
 		})
-		internal.CmpStr(t, expectedView, vp.View())
+		testutil.CmpStr(t, expectedView, vp.View())
 
 		vp.SetRegexToHighlight(regexp.MustCompile("this.*to"))
-		expectedView = pad(vp.GetWidth(), vp.GetHeight(), []string{
+		expectedView = testutil.Pad(vp.GetWidth(), vp.GetHeight(), []string{
 			"header",
 			"\x1b[38;2;0;255;0mthis is to\x1b[m",
 			"o long and",
 			" triggers ",
 			"99% (1/1)",
 		})
-		internal.CmpStr(t, expectedView, vp.View())
+		testutil.CmpStr(t, expectedView, vp.View())
 	}
-	internal.RunWithTimeout(t, runTest, 10*time.Millisecond)
+	testutil.RunWithTimeout(t, runTest, 10*time.Millisecond)
 }
 
 func TestViewport_SelectionOff_WrapOn_StringToHighlightAnsi(t *testing.T) {
@@ -3394,21 +3527,21 @@ func TestViewport_SelectionOff_WrapOn_StringToHighlightAnsi(t *testing.T) {
 		HighlightStyleIfSelected: lipgloss.NewStyle(),
 		SelectedItemStyle:        selectionStyle,
 	})
-	expectedView := pad(vp.GetWidth(), vp.GetHeight(), []string{
+	expectedView := testutil.Pad(vp.GetWidth(), vp.GetHeight(), []string{
 		"header",
 		"lin\x1b[38;2;0;0;255me\x1b[m \x1b[38;2;255;0;0mr\x1b[m\x1b[38;2;0;0;255me\x1b[m\x1b[38;2;255;0;0md\x1b[m \x1b[38;2;0;0;255me\x1b[m",
 		" again",
 	})
-	internal.CmpStr(t, expectedView, vp.View())
+	testutil.CmpStr(t, expectedView, vp.View())
 
 	// should not highlight the ansi escape codes themselves
 	vp.SetStringToHighlight("38")
-	expectedView = pad(vp.GetWidth(), vp.GetHeight(), []string{
+	expectedView = testutil.Pad(vp.GetWidth(), vp.GetHeight(), []string{
 		"header",
 		"line \x1b[38;2;255;0;0mred\x1b[m e",
 		" again",
 	})
-	internal.CmpStr(t, expectedView, vp.View())
+	testutil.CmpStr(t, expectedView, vp.View())
 }
 
 func TestViewport_SelectionOff_WrapOn_RegexToHighlightAnsi(t *testing.T) {
@@ -3426,21 +3559,21 @@ func TestViewport_SelectionOff_WrapOn_RegexToHighlightAnsi(t *testing.T) {
 		HighlightStyleIfSelected: lipgloss.NewStyle(),
 		SelectedItemStyle:        selectionStyle,
 	})
-	expectedView := pad(vp.GetWidth(), vp.GetHeight(), []string{
+	expectedView := testutil.Pad(vp.GetWidth(), vp.GetHeight(), []string{
 		"header",
 		"lin\x1b[38;2;0;0;255me\x1b[m \x1b[38;2;255;0;0mr\x1b[m\x1b[38;2;0;0;255me\x1b[m\x1b[38;2;255;0;0md\x1b[m \x1b[38;2;0;0;255me\x1b[m",
 		" again",
 	})
-	internal.CmpStr(t, expectedView, vp.View())
+	testutil.CmpStr(t, expectedView, vp.View())
 
 	// should not highlight the ansi escape codes themselves
 	vp.SetRegexToHighlight(regexp.MustCompile("38"))
-	expectedView = pad(vp.GetWidth(), vp.GetHeight(), []string{
+	expectedView = testutil.Pad(vp.GetWidth(), vp.GetHeight(), []string{
 		"header",
 		"line \x1b[38;2;255;0;0mred\x1b[m e",
 		" again",
 	})
-	internal.CmpStr(t, expectedView, vp.View())
+	testutil.CmpStr(t, expectedView, vp.View())
 }
 
 func TestViewport_SelectionOff_WrapOn_SuperLongWrappedLine(t *testing.T) {
@@ -3454,46 +3587,46 @@ func TestViewport_SelectionOff_WrapOn_SuperLongWrappedLine(t *testing.T) {
 			strings.Repeat("12345678", 1000000),
 			"smol",
 		})
-		expectedView := pad(vp.GetWidth(), vp.GetHeight(), []string{
+		expectedView := testutil.Pad(vp.GetWidth(), vp.GetHeight(), []string{
 			"header",
 			"smol",
 			"1234567812",
 			"3456781234",
 			"66% (2/3)",
 		})
-		internal.CmpStr(t, expectedView, vp.View())
+		testutil.CmpStr(t, expectedView, vp.View())
 
 		vp, _ = vp.Update(downKeyMsg)
-		expectedView = pad(vp.GetWidth(), vp.GetHeight(), []string{
+		expectedView = testutil.Pad(vp.GetWidth(), vp.GetHeight(), []string{
 			"header",
 			"1234567812",
 			"3456781234",
 			"5678123456",
 			"66% (2/3)",
 		})
-		internal.CmpStr(t, expectedView, vp.View())
+		testutil.CmpStr(t, expectedView, vp.View())
 
 		vp, _ = vp.Update(downKeyMsg)
-		expectedView = pad(vp.GetWidth(), vp.GetHeight(), []string{
+		expectedView = testutil.Pad(vp.GetWidth(), vp.GetHeight(), []string{
 			"header",
 			"3456781234",
 			"5678123456",
 			"7812345678",
 			"66% (2/3)",
 		})
-		internal.CmpStr(t, expectedView, vp.View())
+		testutil.CmpStr(t, expectedView, vp.View())
 
 		vp, _ = vp.Update(goToBottomKeyMsg)
-		expectedView = pad(vp.GetWidth(), vp.GetHeight(), []string{
+		expectedView = testutil.Pad(vp.GetWidth(), vp.GetHeight(), []string{
 			"header",
 			"5678123456",
 			"7812345678",
 			"smol",
 			"100% (3/3)",
 		})
-		internal.CmpStr(t, expectedView, vp.View())
+		testutil.CmpStr(t, expectedView, vp.View())
 	}
-	internal.RunWithTimeout(t, runTest, 500*time.Millisecond)
+	testutil.RunWithTimeout(t, runTest, 500*time.Millisecond)
 }
 
 func TestViewport_SelectionOff_WrapOn_StringToHighlightAnsiUnicode(t *testing.T) {
@@ -3513,14 +3646,14 @@ func TestViewport_SelectionOff_WrapOn_StringToHighlightAnsiUnicode(t *testing.T)
 		HighlightStyleIfSelected: lipgloss.NewStyle().Foreground(red),
 		SelectedItemStyle:        selectionStyle,
 	})
-	expectedView := pad(vp.GetWidth(), vp.GetHeight(), []string{
+	expectedView := testutil.Pad(vp.GetWidth(), vp.GetHeight(), []string{
 		"A💖中é",
 		"A💖\x1b[38;2;0;255;0m中é\x1b[m",
 		"A💖\x1b[38;2;0;255;0m中é\x1b[mA💖",
 		"\x1b[38;2;0;255;0m中é\x1b[m",
 		"100% (2/2)",
 	})
-	internal.CmpStr(t, expectedView, vp.View())
+	testutil.CmpStr(t, expectedView, vp.View())
 }
 
 func TestViewport_SelectionOff_WrapOn_EnableSelectionShowsTopLineInItem(t *testing.T) {
@@ -3535,21 +3668,21 @@ func TestViewport_SelectionOff_WrapOn_EnableSelectionShowsTopLineInItem(t *testi
 	})
 	vp, _ = vp.Update(downKeyMsg)
 	vp, _ = vp.Update(downKeyMsg)
-	expectedView := pad(vp.GetWidth(), vp.GetHeight(), []string{
+	expectedView := testutil.Pad(vp.GetWidth(), vp.GetHeight(), []string{
 		"very long ",
 		"line",
 		"another sh",
 		"75% (3/4)",
 	})
-	internal.CmpStr(t, expectedView, vp.View())
+	testutil.CmpStr(t, expectedView, vp.View())
 	vp.SetSelectionEnabled(true)
-	expectedView = pad(vp.GetWidth(), vp.GetHeight(), []string{
+	expectedView = testutil.Pad(vp.GetWidth(), vp.GetHeight(), []string{
 		"\x1b[38;2;0;0;255mthis is a \x1b[m",
 		"\x1b[38;2;0;0;255mvery long \x1b[m",
 		"\x1b[38;2;0;0;255mline\x1b[m",
 		"50% (2/4)",
 	})
-	internal.CmpStr(t, expectedView, vp.View())
+	testutil.CmpStr(t, expectedView, vp.View())
 }
 
 // # SELECTION ENABLED, WRAP ON
@@ -3559,11 +3692,11 @@ func TestViewport_SelectionOn_WrapOn_Empty(t *testing.T) {
 	vp := newViewport(w, h)
 	vp.SetWrapText(true)
 	vp.SetSelectionEnabled(true)
-	expectedView := pad(vp.GetWidth(), vp.GetHeight(), []string{})
-	internal.CmpStr(t, expectedView, vp.View())
+	expectedView := testutil.Pad(vp.GetWidth(), vp.GetHeight(), []string{})
+	testutil.CmpStr(t, expectedView, vp.View())
 	vp.SetHeader([]string{"header"})
-	expectedView = pad(vp.GetWidth(), vp.GetHeight(), []string{"header"})
-	internal.CmpStr(t, expectedView, vp.View())
+	expectedView = testutil.Pad(vp.GetWidth(), vp.GetHeight(), []string{"header"})
+	testutil.CmpStr(t, expectedView, vp.View())
 }
 
 func TestViewport_SelectionOn_WrapOn_SmolDimensions(t *testing.T) {
@@ -3573,33 +3706,33 @@ func TestViewport_SelectionOn_WrapOn_SmolDimensions(t *testing.T) {
 	vp.SetSelectionEnabled(true)
 	vp.SetHeader([]string{"header"})
 	setContent(&vp, []string{"hi"})
-	expectedView := pad(vp.GetWidth(), vp.GetHeight(), []string{""})
-	internal.CmpStr(t, expectedView, vp.View())
+	expectedView := testutil.Pad(vp.GetWidth(), vp.GetHeight(), []string{""})
+	testutil.CmpStr(t, expectedView, vp.View())
 
 	vp.SetWidth(1)
 	vp.SetHeight(1)
-	expectedView = pad(vp.GetWidth(), vp.GetHeight(), []string{"h"})
-	internal.CmpStr(t, expectedView, vp.View())
+	expectedView = testutil.Pad(vp.GetWidth(), vp.GetHeight(), []string{"h"})
+	testutil.CmpStr(t, expectedView, vp.View())
 
 	vp.SetWidth(2)
 	vp.SetHeight(2)
-	expectedView = pad(vp.GetWidth(), vp.GetHeight(), []string{"he", "ad"})
-	internal.CmpStr(t, expectedView, vp.View())
+	expectedView = testutil.Pad(vp.GetWidth(), vp.GetHeight(), []string{"he", "ad"})
+	testutil.CmpStr(t, expectedView, vp.View())
 
 	vp.SetWidth(3)
 	vp.SetHeight(3)
-	expectedView = pad(vp.GetWidth(), vp.GetHeight(), []string{"hea", "der", ""})
-	internal.CmpStr(t, expectedView, vp.View())
+	expectedView = testutil.Pad(vp.GetWidth(), vp.GetHeight(), []string{"hea", "der", ""})
+	testutil.CmpStr(t, expectedView, vp.View())
 
 	vp.SetWidth(4)
 	vp.SetHeight(4)
-	expectedView = pad(vp.GetWidth(), vp.GetHeight(), []string{
+	expectedView = testutil.Pad(vp.GetWidth(), vp.GetHeight(), []string{
 		"head",
 		"er",
 		"\x1b[38;2;0;0;255mhi\x1b[m",
 		"1...",
 	})
-	internal.CmpStr(t, expectedView, vp.View())
+	testutil.CmpStr(t, expectedView, vp.View())
 }
 
 func TestViewport_SelectionOn_WrapOn_Basic(t *testing.T) {
@@ -3614,7 +3747,7 @@ func TestViewport_SelectionOn_WrapOn_Basic(t *testing.T) {
 		lipgloss.NewStyle().Foreground(red).Render("a really really long line"),
 		lipgloss.NewStyle().Foreground(red).Render("a") + " really really long line",
 	})
-	expectedView := pad(vp.GetWidth(), vp.GetHeight(), []string{
+	expectedView := testutil.Pad(vp.GetWidth(), vp.GetHeight(), []string{
 		"header",
 		"\x1b[38;2;0;0;255mfirst line\x1b[m",
 		"\x1b[38;2;255;0;0msecond\x1b[m line",
@@ -3622,7 +3755,7 @@ func TestViewport_SelectionOn_WrapOn_Basic(t *testing.T) {
 		"\x1b[38;2;255;0;0m long line\x1b[m",
 		"25% (1/4)",
 	})
-	internal.CmpStr(t, expectedView, vp.View())
+	testutil.CmpStr(t, expectedView, vp.View())
 }
 
 func TestViewport_SelectionOn_WrapOn_GetConfigs(t *testing.T) {
@@ -3665,7 +3798,7 @@ func TestViewport_SelectionOn_WrapOn_ShowFooter(t *testing.T) {
 		lipgloss.NewStyle().Foreground(red).Render("a really really long line"),
 		lipgloss.NewStyle().Foreground(red).Render("a") + " really really long line",
 	})
-	expectedView := pad(vp.GetWidth(), vp.GetHeight(), []string{
+	expectedView := testutil.Pad(vp.GetWidth(), vp.GetHeight(), []string{
 		"header",
 		"\x1b[38;2;0;0;255mfirst line\x1b[m",
 		"\x1b[38;2;255;0;0msecond\x1b[m line",
@@ -3674,10 +3807,10 @@ func TestViewport_SelectionOn_WrapOn_ShowFooter(t *testing.T) {
 		"\x1b[38;2;255;0;0ma\x1b[m really really",
 		"25% (1/4)",
 	})
-	internal.CmpStr(t, expectedView, vp.View())
+	testutil.CmpStr(t, expectedView, vp.View())
 
 	vp.SetHeight(8)
-	expectedView = pad(vp.GetWidth(), vp.GetHeight(), []string{
+	expectedView = testutil.Pad(vp.GetWidth(), vp.GetHeight(), []string{
 		"header",
 		"\x1b[38;2;0;0;255mfirst line\x1b[m",
 		"\x1b[38;2;255;0;0msecond\x1b[m line",
@@ -3687,10 +3820,10 @@ func TestViewport_SelectionOn_WrapOn_ShowFooter(t *testing.T) {
 		" long line",
 		"25% (1/4)",
 	})
-	internal.CmpStr(t, expectedView, vp.View())
+	testutil.CmpStr(t, expectedView, vp.View())
 
 	vp.SetHeight(9)
-	expectedView = pad(vp.GetWidth(), vp.GetHeight(), []string{
+	expectedView = testutil.Pad(vp.GetWidth(), vp.GetHeight(), []string{
 		"header",
 		"\x1b[38;2;0;0;255mfirst line\x1b[m",
 		"\x1b[38;2;255;0;0msecond\x1b[m line",
@@ -3699,7 +3832,7 @@ func TestViewport_SelectionOn_WrapOn_ShowFooter(t *testing.T) {
 		"\x1b[38;2;255;0;0ma\x1b[m really really",
 		" long line",
 	})
-	internal.CmpStr(t, expectedView, vp.View())
+	testutil.CmpStr(t, expectedView, vp.View())
 }
 
 func TestViewport_SelectionOn_WrapOn_FooterStyle(t *testing.T) {
@@ -3720,14 +3853,14 @@ func TestViewport_SelectionOn_WrapOn_FooterStyle(t *testing.T) {
 		"3",
 		"4",
 	})
-	expectedView := pad(vp.GetWidth(), vp.GetHeight(), []string{
+	expectedView := testutil.Pad(vp.GetWidth(), vp.GetHeight(), []string{
 		"header",
 		"\x1b[38;2;0;0;255m1\x1b[m",
 		"2",
 		"3",
 		"\x1b[38;2;255;0;0m25% (1/4)\x1b[m",
 	})
-	internal.CmpStr(t, expectedView, vp.View())
+	testutil.CmpStr(t, expectedView, vp.View())
 }
 
 func TestViewport_SelectionOn_WrapOn_FooterDisabled(t *testing.T) {
@@ -3742,24 +3875,24 @@ func TestViewport_SelectionOn_WrapOn_FooterDisabled(t *testing.T) {
 		"third line",
 		"fourth line",
 	})
-	expectedView := pad(vp.GetWidth(), vp.GetHeight(), []string{
+	expectedView := testutil.Pad(vp.GetWidth(), vp.GetHeight(), []string{
 		"header",
 		"\x1b[38;2;0;0;255mfirst line\x1b[m",
 		"second line",
 		"third line",
 		"25% (1/4)",
 	})
-	internal.CmpStr(t, expectedView, vp.View())
+	testutil.CmpStr(t, expectedView, vp.View())
 
 	vp.SetFooterEnabled(false)
-	expectedView = pad(vp.GetWidth(), vp.GetHeight(), []string{
+	expectedView = testutil.Pad(vp.GetWidth(), vp.GetHeight(), []string{
 		"header",
 		"\x1b[38;2;0;0;255mfirst line\x1b[m",
 		"second line",
 		"third line",
 		"fourth line",
 	})
-	internal.CmpStr(t, expectedView, vp.View())
+	testutil.CmpStr(t, expectedView, vp.View())
 }
 
 func TestViewport_SelectionOn_WrapOn_SpaceAround(t *testing.T) {
@@ -3774,14 +3907,14 @@ func TestViewport_SelectionOn_WrapOn_SpaceAround(t *testing.T) {
 		"               first line               ",
 	})
 	// trailing space is not trimmed
-	expectedView := pad(vp.GetWidth(), vp.GetHeight(), []string{
+	expectedView := testutil.Pad(vp.GetWidth(), vp.GetHeight(), []string{
 		"header",
 		"\x1b[38;2;0;0;255m    first line \x1b[m",
 		"\x1b[38;2;0;0;255m    \x1b[m",
 		"          first",
 		"33% (1/3)",
 	})
-	internal.CmpStr(t, expectedView, vp.View())
+	testutil.CmpStr(t, expectedView, vp.View())
 }
 
 func TestViewport_SelectionOn_WrapOn_MultiHeader(t *testing.T) {
@@ -3794,55 +3927,55 @@ func TestViewport_SelectionOn_WrapOn_MultiHeader(t *testing.T) {
 		"line1",
 		"line2",
 	})
-	expectedView := pad(vp.GetWidth(), vp.GetHeight(), []string{
+	expectedView := testutil.Pad(vp.GetWidth(), vp.GetHeight(), []string{
 		"header1",
 		"header2",
 	})
-	internal.CmpStr(t, expectedView, vp.View())
+	testutil.CmpStr(t, expectedView, vp.View())
 
 	vp.SetHeight(3)
-	expectedView = pad(vp.GetWidth(), vp.GetHeight(), []string{
+	expectedView = testutil.Pad(vp.GetWidth(), vp.GetHeight(), []string{
 		"header1",
 		"header2",
 	})
-	internal.CmpStr(t, expectedView, vp.View())
+	testutil.CmpStr(t, expectedView, vp.View())
 
 	vp.SetHeight(4)
-	expectedView = pad(vp.GetWidth(), vp.GetHeight(), []string{
+	expectedView = testutil.Pad(vp.GetWidth(), vp.GetHeight(), []string{
 		"header1",
 		"header2",
 		"\x1b[38;2;0;0;255mline1\x1b[m",
 		"50% (1/2)",
 	})
-	internal.CmpStr(t, expectedView, vp.View())
+	testutil.CmpStr(t, expectedView, vp.View())
 
 	vp, _ = vp.Update(downKeyMsg)
-	expectedView = pad(vp.GetWidth(), vp.GetHeight(), []string{
+	expectedView = testutil.Pad(vp.GetWidth(), vp.GetHeight(), []string{
 		"header1",
 		"header2",
 		"\x1b[38;2;0;0;255mline2\x1b[m",
 		"100% (2/2)",
 	})
-	internal.CmpStr(t, expectedView, vp.View())
+	testutil.CmpStr(t, expectedView, vp.View())
 
 	vp.SetHeight(5)
-	expectedView = pad(vp.GetWidth(), vp.GetHeight(), []string{
+	expectedView = testutil.Pad(vp.GetWidth(), vp.GetHeight(), []string{
 		"header1",
 		"header2",
 		"line1",
 		"\x1b[38;2;0;0;255mline2\x1b[m",
 		"100% (2/2)",
 	})
-	internal.CmpStr(t, expectedView, vp.View())
+	testutil.CmpStr(t, expectedView, vp.View())
 
 	vp.SetHeight(6)
-	expectedView = pad(vp.GetWidth(), vp.GetHeight(), []string{
+	expectedView = testutil.Pad(vp.GetWidth(), vp.GetHeight(), []string{
 		"header1",
 		"header2",
 		"line1",
 		"\x1b[38;2;0;0;255mline2\x1b[m",
 	})
-	internal.CmpStr(t, expectedView, vp.View())
+	testutil.CmpStr(t, expectedView, vp.View())
 }
 
 func TestViewport_SelectionOn_WrapOn_OverflowLine(t *testing.T) {
@@ -3855,7 +3988,7 @@ func TestViewport_SelectionOn_WrapOn_OverflowLine(t *testing.T) {
 		"123456789012345",
 		"1234567890123456",
 	})
-	expectedView := pad(vp.GetWidth(), vp.GetHeight(), []string{
+	expectedView := testutil.Pad(vp.GetWidth(), vp.GetHeight(), []string{
 		"long header ove",
 		"rflows",
 		"\x1b[38;2;0;0;255m123456789012345\x1b[m",
@@ -3863,7 +3996,7 @@ func TestViewport_SelectionOn_WrapOn_OverflowLine(t *testing.T) {
 		"6",
 		"50% (1/2)",
 	})
-	internal.CmpStr(t, expectedView, vp.View())
+	testutil.CmpStr(t, expectedView, vp.View())
 }
 
 func TestViewport_SelectionOn_WrapOn_OverflowHeight(t *testing.T) {
@@ -3881,7 +4014,7 @@ func TestViewport_SelectionOn_WrapOn_OverflowHeight(t *testing.T) {
 		"1234567890123456",
 	})
 	vp.SetSelectedItemIdx(1)
-	expectedView := pad(vp.GetWidth(), vp.GetHeight(), []string{
+	expectedView := testutil.Pad(vp.GetWidth(), vp.GetHeight(), []string{
 		"header",
 		"123456789012345",
 		"\x1b[38;2;0;0;255m123456789012345\x1b[m",
@@ -3889,7 +4022,7 @@ func TestViewport_SelectionOn_WrapOn_OverflowHeight(t *testing.T) {
 		"123456789012345",
 		"33% (2/6)",
 	})
-	internal.CmpStr(t, expectedView, vp.View())
+	testutil.CmpStr(t, expectedView, vp.View())
 }
 
 func TestViewport_SelectionOn_WrapOn_Scrolling(t *testing.T) {
@@ -3910,12 +4043,12 @@ func TestViewport_SelectionOn_WrapOn_Scrolling(t *testing.T) {
 	}
 	validate := func(expectedView string) {
 		// set content multiple times to confirm no side effects of doing it
-		internal.CmpStr(t, expectedView, vp.View())
+		testutil.CmpStr(t, expectedView, vp.View())
 		doSetContent()
-		internal.CmpStr(t, expectedView, vp.View())
+		testutil.CmpStr(t, expectedView, vp.View())
 	}
 	doSetContent()
-	expectedView := pad(vp.GetWidth(), vp.GetHeight(), []string{
+	expectedView := testutil.Pad(vp.GetWidth(), vp.GetHeight(), []string{
 		"header",
 		"\x1b[38;2;0;0;255mfirst\x1b[m",
 		"second",
@@ -3931,7 +4064,7 @@ func TestViewport_SelectionOn_WrapOn_Scrolling(t *testing.T) {
 
 	// scrolling down by one
 	vp, _ = vp.Update(downKeyMsg)
-	expectedView = pad(vp.GetWidth(), vp.GetHeight(), []string{
+	expectedView = testutil.Pad(vp.GetWidth(), vp.GetHeight(), []string{
 		"header",
 		"first",
 		"\x1b[38;2;0;0;255msecond\x1b[m",
@@ -3943,7 +4076,7 @@ func TestViewport_SelectionOn_WrapOn_Scrolling(t *testing.T) {
 
 	// scrolling down by one again
 	vp, _ = vp.Update(downKeyMsg)
-	expectedView = pad(vp.GetWidth(), vp.GetHeight(), []string{
+	expectedView = testutil.Pad(vp.GetWidth(), vp.GetHeight(), []string{
 		"header",
 		"first",
 		"second",
@@ -3957,7 +4090,7 @@ func TestViewport_SelectionOn_WrapOn_Scrolling(t *testing.T) {
 	vp, _ = vp.Update(downKeyMsg)
 	vp, _ = vp.Update(downKeyMsg)
 	vp, _ = vp.Update(downKeyMsg)
-	expectedView = pad(vp.GetWidth(), vp.GetHeight(), []string{
+	expectedView = testutil.Pad(vp.GetWidth(), vp.GetHeight(), []string{
 		"header",
 		"third",
 		"fourth",
@@ -3983,7 +4116,7 @@ func TestViewport_SelectionOn_WrapOn_ScrollToItem(t *testing.T) {
 		"the second line",
 		"the third line",
 	})
-	expectedView := pad(vp.GetWidth(), vp.GetHeight(), []string{
+	expectedView := testutil.Pad(vp.GetWidth(), vp.GetHeight(), []string{
 		"header",
 		"\x1b[38;2;0;0;255mthe first \x1b[m",
 		"\x1b[38;2;0;0;255mline\x1b[m",
@@ -3991,11 +4124,11 @@ func TestViewport_SelectionOn_WrapOn_ScrollToItem(t *testing.T) {
 		" line",
 		"33% (1/3)",
 	})
-	internal.CmpStr(t, expectedView, vp.View())
+	testutil.CmpStr(t, expectedView, vp.View())
 
 	// attempting to scroll so selection out of view is no-op
 	vp.ScrollSoItemIdxInView(2)
-	expectedView = pad(vp.GetWidth(), vp.GetHeight(), []string{
+	expectedView = testutil.Pad(vp.GetWidth(), vp.GetHeight(), []string{
 		"header",
 		"\x1b[38;2;0;0;255mthe first \x1b[m",
 		"\x1b[38;2;0;0;255mline\x1b[m",
@@ -4003,11 +4136,11 @@ func TestViewport_SelectionOn_WrapOn_ScrollToItem(t *testing.T) {
 		" line",
 		"33% (1/3)",
 	})
-	internal.CmpStr(t, expectedView, vp.View())
+	testutil.CmpStr(t, expectedView, vp.View())
 
 	// move selection down
 	vp, _ = vp.Update(downKeyMsg)
-	expectedView = pad(vp.GetWidth(), vp.GetHeight(), []string{
+	expectedView = testutil.Pad(vp.GetWidth(), vp.GetHeight(), []string{
 		"header",
 		"the first",
 		"line",
@@ -4015,11 +4148,11 @@ func TestViewport_SelectionOn_WrapOn_ScrollToItem(t *testing.T) {
 		"\x1b[38;2;0;0;255m line\x1b[m",
 		"66% (2/3)",
 	})
-	internal.CmpStr(t, expectedView, vp.View())
+	testutil.CmpStr(t, expectedView, vp.View())
 
 	// scroll so third item in view
 	vp.ScrollSoItemIdxInView(2)
-	expectedView = pad(vp.GetWidth(), vp.GetHeight(), []string{
+	expectedView = testutil.Pad(vp.GetWidth(), vp.GetHeight(), []string{
 		"header",
 		"\x1b[38;2;0;0;255mthe second\x1b[m",
 		"\x1b[38;2;0;0;255m line\x1b[m",
@@ -4027,7 +4160,7 @@ func TestViewport_SelectionOn_WrapOn_ScrollToItem(t *testing.T) {
 		"line",
 		"66% (2/3)",
 	})
-	internal.CmpStr(t, expectedView, vp.View())
+	testutil.CmpStr(t, expectedView, vp.View())
 }
 
 func TestViewport_SelectionOn_WrapOn_BulkScrolling(t *testing.T) {
@@ -4041,81 +4174,81 @@ func TestViewport_SelectionOn_WrapOn_BulkScrolling(t *testing.T) {
 		"the second line",
 		"the third line",
 	})
-	expectedView := pad(vp.GetWidth(), vp.GetHeight(), []string{
+	expectedView := testutil.Pad(vp.GetWidth(), vp.GetHeight(), []string{
 		"header",
 		"\x1b[38;2;0;0;255mthe first \x1b[m",
 		"\x1b[38;2;0;0;255mline\x1b[m",
 		"33% (1/3)",
 	})
-	internal.CmpStr(t, expectedView, vp.View())
+	testutil.CmpStr(t, expectedView, vp.View())
 
 	// full page down
 	vp, _ = vp.Update(fullPgDownKeyMsg)
-	expectedView = pad(vp.GetWidth(), vp.GetHeight(), []string{
+	expectedView = testutil.Pad(vp.GetWidth(), vp.GetHeight(), []string{
 		"header",
 		"\x1b[38;2;0;0;255mthe second\x1b[m",
 		"\x1b[38;2;0;0;255m line\x1b[m",
 		"66% (2/3)",
 	})
-	internal.CmpStr(t, expectedView, vp.View())
+	testutil.CmpStr(t, expectedView, vp.View())
 
 	// half page down
 	vp, _ = vp.Update(halfPgDownKeyMsg)
-	expectedView = pad(vp.GetWidth(), vp.GetHeight(), []string{
+	expectedView = testutil.Pad(vp.GetWidth(), vp.GetHeight(), []string{
 		"header",
 		"\x1b[38;2;0;0;255mthe third \x1b[m",
 		"\x1b[38;2;0;0;255mline\x1b[m",
 		"100% (3/3)",
 	})
-	internal.CmpStr(t, expectedView, vp.View())
+	testutil.CmpStr(t, expectedView, vp.View())
 
 	// half page down
 	vp, _ = vp.Update(halfPgDownKeyMsg)
-	internal.CmpStr(t, expectedView, vp.View())
+	testutil.CmpStr(t, expectedView, vp.View())
 
 	// full page up
 	vp, _ = vp.Update(fullPgUpKeyMsg)
-	expectedView = pad(vp.GetWidth(), vp.GetHeight(), []string{
+	expectedView = testutil.Pad(vp.GetWidth(), vp.GetHeight(), []string{
 		"header",
 		"\x1b[38;2;0;0;255mthe second\x1b[m",
 		"\x1b[38;2;0;0;255m line\x1b[m",
 		"66% (2/3)",
 	})
-	internal.CmpStr(t, expectedView, vp.View())
+	testutil.CmpStr(t, expectedView, vp.View())
 
 	// half page up
 	vp, _ = vp.Update(halfPgUpKeyMsg)
-	expectedView = pad(vp.GetWidth(), vp.GetHeight(), []string{
+	expectedView = testutil.Pad(vp.GetWidth(), vp.GetHeight(), []string{
 		"header",
 		"\x1b[38;2;0;0;255mthe first \x1b[m",
 		"\x1b[38;2;0;0;255mline\x1b[m",
 		"33% (1/3)",
 	})
-	internal.CmpStr(t, expectedView, vp.View())
+	testutil.CmpStr(t, expectedView, vp.View())
 
 	// half page up
 	vp, _ = vp.Update(halfPgUpKeyMsg)
-	internal.CmpStr(t, expectedView, vp.View())
+	testutil.CmpStr(t, expectedView, vp.View())
 
 	// go to bottom
 	vp, _ = vp.Update(goToBottomKeyMsg)
-	expectedView = pad(vp.GetWidth(), vp.GetHeight(), []string{
+	expectedView = testutil.Pad(vp.GetWidth(), vp.GetHeight(), []string{
 		"header",
 		"\x1b[38;2;0;0;255mthe third \x1b[m",
 		"\x1b[38;2;0;0;255mline\x1b[m",
 		"100% (3/3)",
 	})
-	internal.CmpStr(t, expectedView, vp.View())
+	testutil.CmpStr(t, expectedView, vp.View())
 
 	// go to top
 	vp, _ = vp.Update(goToTopKeyMsg)
-	expectedView = pad(vp.GetWidth(), vp.GetHeight(), []string{
+	expectedView = testutil.Pad(vp.GetWidth(), vp.GetHeight(), []string{
 		"header",
 		"\x1b[38;2;0;0;255mthe first \x1b[m",
 		"\x1b[38;2;0;0;255mline\x1b[m",
 		"33% (1/3)",
 	})
-	internal.CmpStr(t, expectedView, vp.View())
+	testutil.CmpStr(t, expectedView, vp.View())
 }
 
 func TestViewport_SelectionOn_WrapOn_Panning(t *testing.T) {
@@ -4136,12 +4269,12 @@ func TestViewport_SelectionOn_WrapOn_Panning(t *testing.T) {
 	}
 	validate := func(expectedView string) {
 		// set content multiple times to confirm no side effects of doing it
-		internal.CmpStr(t, expectedView, vp.View())
+		testutil.CmpStr(t, expectedView, vp.View())
 		doSetContent()
-		internal.CmpStr(t, expectedView, vp.View())
+		testutil.CmpStr(t, expectedView, vp.View())
 	}
 	doSetContent()
-	expectedView := pad(vp.GetWidth(), vp.GetHeight(), []string{
+	expectedView := testutil.Pad(vp.GetWidth(), vp.GetHeight(), []string{
 		"header lon",
 		"g",
 		"\x1b[38;2;0;0;255mfirst line\x1b[m",
@@ -4158,7 +4291,7 @@ func TestViewport_SelectionOn_WrapOn_Panning(t *testing.T) {
 
 	// scroll down
 	vp, _ = vp.Update(downKeyMsg)
-	expectedView = pad(vp.GetWidth(), vp.GetHeight(), []string{
+	expectedView = testutil.Pad(vp.GetWidth(), vp.GetHeight(), []string{
 		"header lon",
 		"g",
 		"\x1b[38;2;0;0;255msecond lin\x1b[m",
@@ -4175,7 +4308,7 @@ func TestViewport_SelectionOn_WrapOn_Panning(t *testing.T) {
 
 	// scroll down
 	vp, _ = vp.Update(downKeyMsg)
-	expectedView = pad(vp.GetWidth(), vp.GetHeight(), []string{
+	expectedView = testutil.Pad(vp.GetWidth(), vp.GetHeight(), []string{
 		"header lon",
 		"g",
 		"\x1b[38;2;0;0;255mthird line\x1b[m",
@@ -4188,7 +4321,7 @@ func TestViewport_SelectionOn_WrapOn_Panning(t *testing.T) {
 
 	// scroll down
 	vp, _ = vp.Update(downKeyMsg)
-	expectedView = pad(vp.GetWidth(), vp.GetHeight(), []string{
+	expectedView = testutil.Pad(vp.GetWidth(), vp.GetHeight(), []string{
 		"header lon",
 		"g",
 		"airly long",
@@ -4201,7 +4334,7 @@ func TestViewport_SelectionOn_WrapOn_Panning(t *testing.T) {
 
 	// scroll down
 	vp, _ = vp.Update(downKeyMsg)
-	expectedView = pad(vp.GetWidth(), vp.GetHeight(), []string{
+	expectedView = testutil.Pad(vp.GetWidth(), vp.GetHeight(), []string{
 		"header lon",
 		"g",
 		"fourth kin",
@@ -4214,7 +4347,7 @@ func TestViewport_SelectionOn_WrapOn_Panning(t *testing.T) {
 
 	// scroll down
 	vp, _ = vp.Update(downKeyMsg)
-	expectedView = pad(vp.GetWidth(), vp.GetHeight(), []string{
+	expectedView = testutil.Pad(vp.GetWidth(), vp.GetHeight(), []string{
 		"header lon",
 		"g",
 		"da long",
@@ -4227,7 +4360,7 @@ func TestViewport_SelectionOn_WrapOn_Panning(t *testing.T) {
 
 	// scroll up
 	vp, _ = vp.Update(upKeyMsg)
-	expectedView = pad(vp.GetWidth(), vp.GetHeight(), []string{
+	expectedView = testutil.Pad(vp.GetWidth(), vp.GetHeight(), []string{
 		"header lon",
 		"g",
 		"da long",
@@ -4240,7 +4373,7 @@ func TestViewport_SelectionOn_WrapOn_Panning(t *testing.T) {
 
 	// scroll up
 	vp, _ = vp.Update(upKeyMsg)
-	expectedView = pad(vp.GetWidth(), vp.GetHeight(), []string{
+	expectedView = testutil.Pad(vp.GetWidth(), vp.GetHeight(), []string{
 		"header lon",
 		"g",
 		"\x1b[38;2;0;0;255mfourth kin\x1b[m",
@@ -4253,7 +4386,7 @@ func TestViewport_SelectionOn_WrapOn_Panning(t *testing.T) {
 
 	// scroll up
 	vp, _ = vp.Update(upKeyMsg)
-	expectedView = pad(vp.GetWidth(), vp.GetHeight(), []string{
+	expectedView = testutil.Pad(vp.GetWidth(), vp.GetHeight(), []string{
 		"header lon",
 		"g",
 		"\x1b[38;2;0;0;255mthird line\x1b[m",
@@ -4266,7 +4399,7 @@ func TestViewport_SelectionOn_WrapOn_Panning(t *testing.T) {
 
 	// scroll up
 	vp, _ = vp.Update(upKeyMsg)
-	expectedView = pad(vp.GetWidth(), vp.GetHeight(), []string{
+	expectedView = testutil.Pad(vp.GetWidth(), vp.GetHeight(), []string{
 		"header lon",
 		"g",
 		"\x1b[38;2;0;0;255msecond lin\x1b[m",
@@ -4279,7 +4412,7 @@ func TestViewport_SelectionOn_WrapOn_Panning(t *testing.T) {
 
 	// scroll up
 	vp, _ = vp.Update(upKeyMsg)
-	expectedView = pad(vp.GetWidth(), vp.GetHeight(), []string{
+	expectedView = testutil.Pad(vp.GetWidth(), vp.GetHeight(), []string{
 		"header lon",
 		"g",
 		"\x1b[38;2;0;0;255mfirst line\x1b[m",
@@ -4306,7 +4439,7 @@ func TestViewport_SelectionOn_WrapOn_MaintainSelection(t *testing.T) {
 		"tenth item",
 		"eleventh item",
 	})
-	expectedView := pad(vp.GetWidth(), vp.GetHeight(), []string{
+	expectedView := testutil.Pad(vp.GetWidth(), vp.GetHeight(), []string{
 		"header",
 		"\x1b[38;2;0;0;255msixth item\x1b[m",
 		"seventh it",
@@ -4314,11 +4447,11 @@ func TestViewport_SelectionOn_WrapOn_MaintainSelection(t *testing.T) {
 		"eighth ite",
 		"16% (1/6)",
 	})
-	internal.CmpStr(t, expectedView, vp.View())
+	testutil.CmpStr(t, expectedView, vp.View())
 
 	// selection down
 	vp, _ = vp.Update(downKeyMsg)
-	expectedView = pad(vp.GetWidth(), vp.GetHeight(), []string{
+	expectedView = testutil.Pad(vp.GetWidth(), vp.GetHeight(), []string{
 		"header",
 		"sixth item",
 		"\x1b[38;2;0;0;255mseventh it\x1b[m",
@@ -4326,7 +4459,7 @@ func TestViewport_SelectionOn_WrapOn_MaintainSelection(t *testing.T) {
 		"eighth ite",
 		"33% (2/6)",
 	})
-	internal.CmpStr(t, expectedView, vp.View())
+	testutil.CmpStr(t, expectedView, vp.View())
 
 	// add content above
 	setContent(&vp, []string{
@@ -4342,7 +4475,7 @@ func TestViewport_SelectionOn_WrapOn_MaintainSelection(t *testing.T) {
 		"tenth item",
 		"eleventh item",
 	})
-	expectedView = pad(vp.GetWidth(), vp.GetHeight(), []string{
+	expectedView = testutil.Pad(vp.GetWidth(), vp.GetHeight(), []string{
 		"header",
 		"sixth item",
 		"\x1b[38;2;0;0;255mseventh it\x1b[m",
@@ -4350,7 +4483,7 @@ func TestViewport_SelectionOn_WrapOn_MaintainSelection(t *testing.T) {
 		"eighth ite",
 		"63% (7/11)",
 	})
-	internal.CmpStr(t, expectedView, vp.View())
+	testutil.CmpStr(t, expectedView, vp.View())
 
 	// add content below
 	setContent(&vp, []string{
@@ -4371,7 +4504,7 @@ func TestViewport_SelectionOn_WrapOn_MaintainSelection(t *testing.T) {
 		"fifteenth item",
 		"sixteenth item",
 	})
-	expectedView = pad(vp.GetWidth(), vp.GetHeight(), []string{
+	expectedView = testutil.Pad(vp.GetWidth(), vp.GetHeight(), []string{
 		"header",
 		"sixth item",
 		"\x1b[38;2;0;0;255mseventh it\x1b[m",
@@ -4379,7 +4512,7 @@ func TestViewport_SelectionOn_WrapOn_MaintainSelection(t *testing.T) {
 		"eighth ite",
 		"43% (7/16)",
 	})
-	internal.CmpStr(t, expectedView, vp.View())
+	testutil.CmpStr(t, expectedView, vp.View())
 }
 
 func TestViewport_SelectionOn_WrapOn_StickyTop(t *testing.T) {
@@ -4394,36 +4527,36 @@ func TestViewport_SelectionOn_WrapOn_StickyTop(t *testing.T) {
 	setContent(&vp, []string{
 		"the first line",
 	})
-	expectedView := pad(vp.GetWidth(), vp.GetHeight(), []string{
+	expectedView := testutil.Pad(vp.GetWidth(), vp.GetHeight(), []string{
 		"header",
 		"\x1b[38;2;0;0;255mthe first \x1b[m",
 		"\x1b[38;2;0;0;255mline\x1b[m",
 		"100% (1/1)",
 	})
-	internal.CmpStr(t, expectedView, vp.View())
+	testutil.CmpStr(t, expectedView, vp.View())
 
 	// add content
 	setContent(&vp, []string{
 		"the second line",
 		"the first line",
 	})
-	expectedView = pad(vp.GetWidth(), vp.GetHeight(), []string{
+	expectedView = testutil.Pad(vp.GetWidth(), vp.GetHeight(), []string{
 		"header",
 		"\x1b[38;2;0;0;255mthe second\x1b[m",
 		"\x1b[38;2;0;0;255m line\x1b[m",
 		"50% (1/2)",
 	})
-	internal.CmpStr(t, expectedView, vp.View())
+	testutil.CmpStr(t, expectedView, vp.View())
 
 	// de-activate by moving selection down
 	vp, _ = vp.Update(downKeyMsg)
-	expectedView = pad(vp.GetWidth(), vp.GetHeight(), []string{
+	expectedView = testutil.Pad(vp.GetWidth(), vp.GetHeight(), []string{
 		"header",
 		"\x1b[38;2;0;0;255mthe first \x1b[m",
 		"\x1b[38;2;0;0;255mline\x1b[m",
 		"100% (2/2)",
 	})
-	internal.CmpStr(t, expectedView, vp.View())
+	testutil.CmpStr(t, expectedView, vp.View())
 
 	// add content
 	setContent(&vp, []string{
@@ -4431,13 +4564,13 @@ func TestViewport_SelectionOn_WrapOn_StickyTop(t *testing.T) {
 		"the first line",
 		"the third line",
 	})
-	expectedView = pad(vp.GetWidth(), vp.GetHeight(), []string{
+	expectedView = testutil.Pad(vp.GetWidth(), vp.GetHeight(), []string{
 		"header",
 		"\x1b[38;2;0;0;255mthe first \x1b[m",
 		"\x1b[38;2;0;0;255mline\x1b[m",
 		"66% (2/3)",
 	})
-	internal.CmpStr(t, expectedView, vp.View())
+	testutil.CmpStr(t, expectedView, vp.View())
 }
 
 func TestViewport_SelectionOn_WrapOn_StickyBottom(t *testing.T) {
@@ -4452,19 +4585,19 @@ func TestViewport_SelectionOn_WrapOn_StickyBottom(t *testing.T) {
 	setContent(&vp, []string{
 		"the first line",
 	})
-	expectedView := pad(vp.GetWidth(), vp.GetHeight(), []string{
+	expectedView := testutil.Pad(vp.GetWidth(), vp.GetHeight(), []string{
 		"header",
 		"\x1b[38;2;0;0;255mthe first \x1b[m",
 		"\x1b[38;2;0;0;255mline\x1b[m",
 	})
-	internal.CmpStr(t, expectedView, vp.View())
+	testutil.CmpStr(t, expectedView, vp.View())
 
 	// add content
 	setContent(&vp, []string{
 		"the second line",
 		"the first line",
 	})
-	expectedView = pad(vp.GetWidth(), vp.GetHeight(), []string{
+	expectedView = testutil.Pad(vp.GetWidth(), vp.GetHeight(), []string{
 		"header",
 		"the second",
 		" line",
@@ -4472,7 +4605,7 @@ func TestViewport_SelectionOn_WrapOn_StickyBottom(t *testing.T) {
 		"\x1b[38;2;0;0;255mline\x1b[m",
 		"100% (2/2)",
 	})
-	internal.CmpStr(t, expectedView, vp.View())
+	testutil.CmpStr(t, expectedView, vp.View())
 
 	// add longer content at bottom
 	setContent(&vp, []string{
@@ -4480,7 +4613,7 @@ func TestViewport_SelectionOn_WrapOn_StickyBottom(t *testing.T) {
 		"the first line",
 		"a very long line that wraps a lot",
 	})
-	expectedView = pad(vp.GetWidth(), vp.GetHeight(), []string{
+	expectedView = testutil.Pad(vp.GetWidth(), vp.GetHeight(), []string{
 		"header",
 		"\x1b[38;2;0;0;255ma very lon\x1b[m",
 		"\x1b[38;2;0;0;255mg line tha\x1b[m",
@@ -4488,11 +4621,11 @@ func TestViewport_SelectionOn_WrapOn_StickyBottom(t *testing.T) {
 		"\x1b[38;2;0;0;255mlot\x1b[m",
 		"100% (3/3)",
 	})
-	internal.CmpStr(t, expectedView, vp.View())
+	testutil.CmpStr(t, expectedView, vp.View())
 
 	// de-activate by moving selection up
 	vp, _ = vp.Update(upKeyMsg)
-	expectedView = pad(vp.GetWidth(), vp.GetHeight(), []string{
+	expectedView = testutil.Pad(vp.GetWidth(), vp.GetHeight(), []string{
 		"header",
 		"\x1b[38;2;0;0;255mthe first \x1b[m",
 		"\x1b[38;2;0;0;255mline\x1b[m",
@@ -4500,7 +4633,7 @@ func TestViewport_SelectionOn_WrapOn_StickyBottom(t *testing.T) {
 		"g line tha",
 		"66% (2/3)",
 	})
-	internal.CmpStr(t, expectedView, vp.View())
+	testutil.CmpStr(t, expectedView, vp.View())
 
 	// add content
 	setContent(&vp, []string{
@@ -4509,7 +4642,7 @@ func TestViewport_SelectionOn_WrapOn_StickyBottom(t *testing.T) {
 		"a very long line that wraps a lot",
 		"the third line",
 	})
-	expectedView = pad(vp.GetWidth(), vp.GetHeight(), []string{
+	expectedView = testutil.Pad(vp.GetWidth(), vp.GetHeight(), []string{
 		"header",
 		"\x1b[38;2;0;0;255mthe first \x1b[m",
 		"\x1b[38;2;0;0;255mline\x1b[m",
@@ -4517,7 +4650,7 @@ func TestViewport_SelectionOn_WrapOn_StickyBottom(t *testing.T) {
 		"g line tha",
 		"50% (2/4)",
 	})
-	internal.CmpStr(t, expectedView, vp.View())
+	testutil.CmpStr(t, expectedView, vp.View())
 }
 
 func TestViewport_SelectionOn_WrapOn_StickyBottomOverflowHeight(t *testing.T) {
@@ -4532,23 +4665,23 @@ func TestViewport_SelectionOn_WrapOn_StickyBottomOverflowHeight(t *testing.T) {
 
 	// test covers case where first set content to empty, then overflow height
 	setContent(&vp, []string{})
-	expectedView := pad(vp.GetWidth(), vp.GetHeight(), []string{
+	expectedView := testutil.Pad(vp.GetWidth(), vp.GetHeight(), []string{
 		"header",
 	})
-	internal.CmpStr(t, expectedView, vp.View())
+	testutil.CmpStr(t, expectedView, vp.View())
 
 	setContent(&vp, []string{
 		"the second line",
 		"the first line",
 		"the third line",
 	})
-	expectedView = pad(vp.GetWidth(), vp.GetHeight(), []string{
+	expectedView = testutil.Pad(vp.GetWidth(), vp.GetHeight(), []string{
 		"header",
 		"\x1b[38;2;0;0;255mthe third \x1b[m",
 		"\x1b[38;2;0;0;255mline\x1b[m",
 		"100% (3/3)",
 	})
-	internal.CmpStr(t, expectedView, vp.View())
+	testutil.CmpStr(t, expectedView, vp.View())
 }
 
 func TestViewport_SelectionOn_WrapOn_StickyTopBottom(t *testing.T) {
@@ -4564,36 +4697,36 @@ func TestViewport_SelectionOn_WrapOn_StickyTopBottom(t *testing.T) {
 	setContent(&vp, []string{
 		"the first line",
 	})
-	expectedView := pad(vp.GetWidth(), vp.GetHeight(), []string{
+	expectedView := testutil.Pad(vp.GetWidth(), vp.GetHeight(), []string{
 		"header",
 		"\x1b[38;2;0;0;255mthe first \x1b[m",
 		"\x1b[38;2;0;0;255mline\x1b[m",
 		"100% (1/1)",
 	})
-	internal.CmpStr(t, expectedView, vp.View())
+	testutil.CmpStr(t, expectedView, vp.View())
 
 	// add content, top sticky wins out arbitrarily when both set
 	setContent(&vp, []string{
 		"the second line",
 		"the first line",
 	})
-	expectedView = pad(vp.GetWidth(), vp.GetHeight(), []string{
+	expectedView = testutil.Pad(vp.GetWidth(), vp.GetHeight(), []string{
 		"header",
 		"\x1b[38;2;0;0;255mthe second\x1b[m",
 		"\x1b[38;2;0;0;255m line\x1b[m",
 		"50% (1/2)",
 	})
-	internal.CmpStr(t, expectedView, vp.View())
+	testutil.CmpStr(t, expectedView, vp.View())
 
 	// selection to bottom
 	vp, _ = vp.Update(downKeyMsg)
-	expectedView = pad(vp.GetWidth(), vp.GetHeight(), []string{
+	expectedView = testutil.Pad(vp.GetWidth(), vp.GetHeight(), []string{
 		"header",
 		"\x1b[38;2;0;0;255mthe first \x1b[m",
 		"\x1b[38;2;0;0;255mline\x1b[m",
 		"100% (2/2)",
 	})
-	internal.CmpStr(t, expectedView, vp.View())
+	testutil.CmpStr(t, expectedView, vp.View())
 
 	// add content
 	setContent(&vp, []string{
@@ -4601,23 +4734,23 @@ func TestViewport_SelectionOn_WrapOn_StickyTopBottom(t *testing.T) {
 		"the first line",
 		"the third line",
 	})
-	expectedView = pad(vp.GetWidth(), vp.GetHeight(), []string{
+	expectedView = testutil.Pad(vp.GetWidth(), vp.GetHeight(), []string{
 		"header",
 		"\x1b[38;2;0;0;255mthe third \x1b[m",
 		"\x1b[38;2;0;0;255mline\x1b[m",
 		"100% (3/3)",
 	})
-	internal.CmpStr(t, expectedView, vp.View())
+	testutil.CmpStr(t, expectedView, vp.View())
 
 	// de-activate by moving selection up
 	vp, _ = vp.Update(upKeyMsg)
-	expectedView = pad(vp.GetWidth(), vp.GetHeight(), []string{
+	expectedView = testutil.Pad(vp.GetWidth(), vp.GetHeight(), []string{
 		"header",
 		"\x1b[38;2;0;0;255mthe first \x1b[m",
 		"\x1b[38;2;0;0;255mline\x1b[m",
 		"66% (2/3)",
 	})
-	internal.CmpStr(t, expectedView, vp.View())
+	testutil.CmpStr(t, expectedView, vp.View())
 
 	// add content
 	setContent(&vp, []string{
@@ -4626,13 +4759,13 @@ func TestViewport_SelectionOn_WrapOn_StickyTopBottom(t *testing.T) {
 		"the third line",
 		"the fourth line",
 	})
-	expectedView = pad(vp.GetWidth(), vp.GetHeight(), []string{
+	expectedView = testutil.Pad(vp.GetWidth(), vp.GetHeight(), []string{
 		"header",
 		"\x1b[38;2;0;0;255mthe first \x1b[m",
 		"\x1b[38;2;0;0;255mline\x1b[m",
 		"50% (2/4)",
 	})
-	internal.CmpStr(t, expectedView, vp.View())
+	testutil.CmpStr(t, expectedView, vp.View())
 }
 
 func TestViewport_SelectionOn_WrapOn_StickyBottomLongLine(t *testing.T) {
@@ -4648,19 +4781,19 @@ func TestViewport_SelectionOn_WrapOn_StickyBottomLongLine(t *testing.T) {
 		"first line",
 		"next line",
 	})
-	expectedView := pad(vp.GetWidth(), vp.GetHeight(), []string{
+	expectedView := testutil.Pad(vp.GetWidth(), vp.GetHeight(), []string{
 		"header",
 		"first line",
 		"\x1b[38;2;0;0;255mnext line\x1b[m",
 	})
-	internal.CmpStr(t, expectedView, vp.View())
+	testutil.CmpStr(t, expectedView, vp.View())
 
 	setContent(&vp, []string{
 		"first line",
 		"next line",
 		"a very long line at the bottom that wraps many times",
 	})
-	expectedView = pad(vp.GetWidth(), vp.GetHeight(), []string{
+	expectedView = testutil.Pad(vp.GetWidth(), vp.GetHeight(), []string{
 		"header",
 		"first line",
 		"next line",
@@ -4672,7 +4805,7 @@ func TestViewport_SelectionOn_WrapOn_StickyBottomLongLine(t *testing.T) {
 		"\x1b[38;2;0;0;255mes\x1b[m",
 		"100% (3/3)",
 	})
-	internal.CmpStr(t, expectedView, vp.View())
+	testutil.CmpStr(t, expectedView, vp.View())
 }
 
 func TestViewport_SelectionOn_WrapOn_RemoveLogsWhenSelectionBottom(t *testing.T) {
@@ -4689,33 +4822,33 @@ func TestViewport_SelectionOn_WrapOn_RemoveLogsWhenSelectionBottom(t *testing.T)
 		"the third line",
 		"the fourth line",
 	})
-	expectedView := pad(vp.GetWidth(), vp.GetHeight(), []string{
+	expectedView := testutil.Pad(vp.GetWidth(), vp.GetHeight(), []string{
 		"header",
 		"\x1b[38;2;0;0;255mthe second\x1b[m",
 		"25% (1/4)",
 	})
-	internal.CmpStr(t, expectedView, vp.View())
+	testutil.CmpStr(t, expectedView, vp.View())
 
 	// selection to bottom
 	vp.SetSelectedItemIdx(3)
-	expectedView = pad(vp.GetWidth(), vp.GetHeight(), []string{
+	expectedView = testutil.Pad(vp.GetWidth(), vp.GetHeight(), []string{
 		"header",
 		"\x1b[38;2;0;0;255mthe fourth\x1b[m",
 		"100% (4/4)",
 	})
-	internal.CmpStr(t, expectedView, vp.View())
+	testutil.CmpStr(t, expectedView, vp.View())
 
 	// remove content
 	setContent(&vp, []string{
 		"the second line",
 		"the first line",
 	})
-	expectedView = pad(vp.GetWidth(), vp.GetHeight(), []string{
+	expectedView = testutil.Pad(vp.GetWidth(), vp.GetHeight(), []string{
 		"header",
 		"\x1b[38;2;0;0;255mthe first \x1b[m",
 		"100% (2/2)",
 	})
-	internal.CmpStr(t, expectedView, vp.View())
+	testutil.CmpStr(t, expectedView, vp.View())
 }
 
 func TestViewport_SelectionOn_WrapOn_ChangeHeight(t *testing.T) {
@@ -4732,16 +4865,16 @@ func TestViewport_SelectionOn_WrapOn_ChangeHeight(t *testing.T) {
 		"the fifth line",
 		"the sixth line",
 	})
-	expectedView := pad(vp.GetWidth(), vp.GetHeight(), []string{
+	expectedView := testutil.Pad(vp.GetWidth(), vp.GetHeight(), []string{
 		"header",
 		"\x1b[38;2;0;0;255mthe first \x1b[m",
 		"16% (1/6)",
 	})
-	internal.CmpStr(t, expectedView, vp.View())
+	testutil.CmpStr(t, expectedView, vp.View())
 
 	// increase height
 	vp.SetHeight(6)
-	expectedView = pad(vp.GetWidth(), vp.GetHeight(), []string{
+	expectedView = testutil.Pad(vp.GetWidth(), vp.GetHeight(), []string{
 		"header",
 		"\x1b[38;2;0;0;255mthe first \x1b[m",
 		"\x1b[38;2;0;0;255mline\x1b[m",
@@ -4749,12 +4882,12 @@ func TestViewport_SelectionOn_WrapOn_ChangeHeight(t *testing.T) {
 		" line",
 		"16% (1/6)",
 	})
-	internal.CmpStr(t, expectedView, vp.View())
+	testutil.CmpStr(t, expectedView, vp.View())
 
 	// move selection to third line
 	vp, _ = vp.Update(downKeyMsg)
 	vp, _ = vp.Update(downKeyMsg)
-	expectedView = pad(vp.GetWidth(), vp.GetHeight(), []string{
+	expectedView = testutil.Pad(vp.GetWidth(), vp.GetHeight(), []string{
 		"header",
 		"the second",
 		" line",
@@ -4762,20 +4895,20 @@ func TestViewport_SelectionOn_WrapOn_ChangeHeight(t *testing.T) {
 		"\x1b[38;2;0;0;255mline\x1b[m",
 		"50% (3/6)",
 	})
-	internal.CmpStr(t, expectedView, vp.View())
+	testutil.CmpStr(t, expectedView, vp.View())
 
 	// reduce height
 	vp.SetHeight(3)
-	expectedView = pad(vp.GetWidth(), vp.GetHeight(), []string{
+	expectedView = testutil.Pad(vp.GetWidth(), vp.GetHeight(), []string{
 		"header",
 		"\x1b[38;2;0;0;255mthe third \x1b[m",
 		"50% (3/6)",
 	})
-	internal.CmpStr(t, expectedView, vp.View())
+	testutil.CmpStr(t, expectedView, vp.View())
 
 	// increase height
 	vp.SetHeight(8)
-	expectedView = pad(vp.GetWidth(), vp.GetHeight(), []string{
+	expectedView = testutil.Pad(vp.GetWidth(), vp.GetHeight(), []string{
 		"header",
 		"\x1b[38;2;0;0;255mthe third \x1b[m",
 		"\x1b[38;2;0;0;255mline\x1b[m",
@@ -4785,13 +4918,13 @@ func TestViewport_SelectionOn_WrapOn_ChangeHeight(t *testing.T) {
 		"line",
 		"50% (3/6)",
 	})
-	internal.CmpStr(t, expectedView, vp.View())
+	testutil.CmpStr(t, expectedView, vp.View())
 
 	// move selection to last line
 	vp, _ = vp.Update(downKeyMsg)
 	vp, _ = vp.Update(downKeyMsg)
 	vp, _ = vp.Update(downKeyMsg)
-	expectedView = pad(vp.GetWidth(), vp.GetHeight(), []string{
+	expectedView = testutil.Pad(vp.GetWidth(), vp.GetHeight(), []string{
 		"header",
 		"the fourth",
 		" line",
@@ -4801,16 +4934,16 @@ func TestViewport_SelectionOn_WrapOn_ChangeHeight(t *testing.T) {
 		"\x1b[38;2;0;0;255mline\x1b[m",
 		"100% (6/6)",
 	})
-	internal.CmpStr(t, expectedView, vp.View())
+	testutil.CmpStr(t, expectedView, vp.View())
 
 	// reduce height
 	vp.SetHeight(3)
-	expectedView = pad(vp.GetWidth(), vp.GetHeight(), []string{
+	expectedView = testutil.Pad(vp.GetWidth(), vp.GetHeight(), []string{
 		"header",
 		"\x1b[38;2;0;0;255mthe sixth \x1b[m",
 		"100% (6/6)",
 	})
-	internal.CmpStr(t, expectedView, vp.View())
+	testutil.CmpStr(t, expectedView, vp.View())
 }
 
 func TestViewport_SelectionOn_WrapOn_ChangeContent(t *testing.T) {
@@ -4827,46 +4960,46 @@ func TestViewport_SelectionOn_WrapOn_ChangeContent(t *testing.T) {
 		"the fifth line",
 		"the sixth line",
 	})
-	expectedView := pad(vp.GetWidth(), vp.GetHeight(), []string{
+	expectedView := testutil.Pad(vp.GetWidth(), vp.GetHeight(), []string{
 		"header",
 		"\x1b[38;2;0;0;255mthe first \x1b[m",
 		"\x1b[38;2;0;0;255mline\x1b[m",
 		"the second",
 		"16% (1/6)",
 	})
-	internal.CmpStr(t, expectedView, vp.View())
+	testutil.CmpStr(t, expectedView, vp.View())
 
 	// move selection to bottom
 	vp.SetSelectedItemIdx(5)
-	expectedView = pad(vp.GetWidth(), vp.GetHeight(), []string{
+	expectedView = testutil.Pad(vp.GetWidth(), vp.GetHeight(), []string{
 		"header",
 		"line",
 		"\x1b[38;2;0;0;255mthe sixth \x1b[m",
 		"\x1b[38;2;0;0;255mline\x1b[m",
 		"100% (6/6)",
 	})
-	internal.CmpStr(t, expectedView, vp.View())
+	testutil.CmpStr(t, expectedView, vp.View())
 
 	// remove content
 	setContent(&vp, []string{
 		"the second line",
 		"the third line",
 	})
-	expectedView = pad(vp.GetWidth(), vp.GetHeight(), []string{
+	expectedView = testutil.Pad(vp.GetWidth(), vp.GetHeight(), []string{
 		"header",
 		" line",
 		"\x1b[38;2;0;0;255mthe third \x1b[m",
 		"\x1b[38;2;0;0;255mline\x1b[m",
 		"100% (2/2)",
 	})
-	internal.CmpStr(t, expectedView, vp.View())
+	testutil.CmpStr(t, expectedView, vp.View())
 
 	// remove all content
 	setContent(&vp, []string{})
-	expectedView = pad(vp.GetWidth(), vp.GetHeight(), []string{
+	expectedView = testutil.Pad(vp.GetWidth(), vp.GetHeight(), []string{
 		"header",
 	})
-	internal.CmpStr(t, expectedView, vp.View())
+	testutil.CmpStr(t, expectedView, vp.View())
 
 	// add content
 	setContent(&vp, []string{
@@ -4877,14 +5010,14 @@ func TestViewport_SelectionOn_WrapOn_ChangeContent(t *testing.T) {
 		"the fifth line",
 		"the sixth line",
 	})
-	expectedView = pad(vp.GetWidth(), vp.GetHeight(), []string{
+	expectedView = testutil.Pad(vp.GetWidth(), vp.GetHeight(), []string{
 		"header",
 		"\x1b[38;2;0;0;255mthe first \x1b[m",
 		"\x1b[38;2;0;0;255mline\x1b[m",
 		"the second",
 		"16% (1/6)",
 	})
-	internal.CmpStr(t, expectedView, vp.View())
+	testutil.CmpStr(t, expectedView, vp.View())
 }
 
 func TestViewport_SelectionOn_WrapOn_StringToHighlight(t *testing.T) {
@@ -4906,50 +5039,50 @@ func TestViewport_SelectionOn_WrapOn_StringToHighlight(t *testing.T) {
 		"second",
 		"third",
 	})
-	expectedView := pad(vp.GetWidth(), vp.GetHeight(), []string{
+	expectedView := testutil.Pad(vp.GetWidth(), vp.GetHeight(), []string{
 		"header",
 		"\x1b[38;2;0;0;255mfirst\x1b[m",
 		"\x1b[38;2;0;255;0msecond\x1b[m",
 		"\x1b[38;2;0;255;0msecond\x1b[m",
 		"25% (1/4)",
 	})
-	internal.CmpStr(t, expectedView, vp.View())
+	testutil.CmpStr(t, expectedView, vp.View())
 
 	vp.SetStringToHighlight("first")
-	expectedView = pad(vp.GetWidth(), vp.GetHeight(), []string{
+	expectedView = testutil.Pad(vp.GetWidth(), vp.GetHeight(), []string{
 		"header",
 		"\x1b[38;2;255;0;0mfirst\x1b[m",
 		"second",
 		"second",
 		"25% (1/4)",
 	})
-	internal.CmpStr(t, expectedView, vp.View())
+	testutil.CmpStr(t, expectedView, vp.View())
 
 	setContent(&vp, []string{
 		"averylongwordthatwrapsover",
 	})
 	vp.SetStringToHighlight("wraps")
-	expectedView = pad(vp.GetWidth(), vp.GetHeight(), []string{
+	expectedView = testutil.Pad(vp.GetWidth(), vp.GetHeight(), []string{
 		"header",
 		"\x1b[38;2;0;0;255maverylongw\x1b[m",
 		"\x1b[38;2;0;0;255mordthat\x1b[m\x1b[38;2;255;0;0mwra\x1b[m",
 		"\x1b[38;2;255;0;0mps\x1b[m\x1b[38;2;0;0;255mover\x1b[m",
 		"100% (1/1)",
 	})
-	internal.CmpStr(t, expectedView, vp.View())
+	testutil.CmpStr(t, expectedView, vp.View())
 
 	setContent(&vp, []string{
 		"a super long line a super long line a super long line a super long line a super long line a super long line a super long line a super long line a super long line a super long line a super long line a super long line a super long line a super long line a super long line a super long line a super long line a super long line a super long line a super long line a super long line a super long line a super long line a super long line a super long line a super long line a super long line a super long line a super long line a super long line a super long line a super long line a super long line a super long line a super long line a super long line a super long line a super long line a super long line ",
 	})
 	vp.SetStringToHighlight("l")
-	expectedView = pad(vp.GetWidth(), vp.GetHeight(), []string{
+	expectedView = testutil.Pad(vp.GetWidth(), vp.GetHeight(), []string{
 		"header",
 		"\x1b[38;2;0;0;255ma super \x1b[m\x1b[38;2;255;0;0ml\x1b[m\x1b[38;2;0;0;255mo\x1b[m",
 		"\x1b[38;2;0;0;255mng \x1b[m\x1b[38;2;255;0;0ml\x1b[m\x1b[38;2;0;0;255mine a \x1b[m",
 		"\x1b[38;2;0;0;255msuper \x1b[m\x1b[38;2;255;0;0ml\x1b[m\x1b[38;2;0;0;255mong\x1b[m",
 		"100% (1/1)",
 	})
-	internal.CmpStr(t, expectedView, vp.View())
+	testutil.CmpStr(t, expectedView, vp.View())
 }
 
 func TestViewport_SelectionOn_WrapOn_RegexToHighlight(t *testing.T) {
@@ -4971,24 +5104,24 @@ func TestViewport_SelectionOn_WrapOn_RegexToHighlight(t *testing.T) {
 		"second",
 		"third",
 	})
-	expectedView := pad(vp.GetWidth(), vp.GetHeight(), []string{
+	expectedView := testutil.Pad(vp.GetWidth(), vp.GetHeight(), []string{
 		"header",
 		"\x1b[38;2;0;0;255mfirst\x1b[m",
 		"\x1b[38;2;0;255;0msecond\x1b[m",
 		"\x1b[38;2;0;255;0msecond\x1b[m",
 		"25% (1/4)",
 	})
-	internal.CmpStr(t, expectedView, vp.View())
+	testutil.CmpStr(t, expectedView, vp.View())
 
 	vp.SetRegexToHighlight(regexp.MustCompile("fi?rst"))
-	expectedView = pad(vp.GetWidth(), vp.GetHeight(), []string{
+	expectedView = testutil.Pad(vp.GetWidth(), vp.GetHeight(), []string{
 		"header",
 		"\x1b[38;2;255;0;0mfirst\x1b[m",
 		"second",
 		"second",
 		"25% (1/4)",
 	})
-	internal.CmpStr(t, expectedView, vp.View())
+	testutil.CmpStr(t, expectedView, vp.View())
 }
 
 func TestViewport_SelectionOn_WrapOn_StringToHighlightManyMatches(t *testing.T) {
@@ -5008,16 +5141,16 @@ func TestViewport_SelectionOn_WrapOn_StringToHighlightManyMatches(t *testing.T)
 			HighlightStyleIfSelected: lipgloss.NewStyle().Foreground(red),
 			SelectedItemStyle:        selectionStyle,
 		})
-		expectedView := pad(vp.GetWidth(), vp.GetHeight(), []string{
+		expectedView := testutil.Pad(vp.GetWidth(), vp.GetHeight(), []string{
 			"header",
 			strings.Repeat("\x1b[38;2;255;0;0mr\x1b[m", 10),
 			strings.Repeat("\x1b[38;2;255;0;0mr\x1b[m", 10),
 			strings.Repeat("\x1b[38;2;255;0;0mr\x1b[m", 10),
 			"100% (1/1)",
 		})
-		internal.CmpStr(t, expectedView, vp.View())
+		testutil.CmpStr(t, expectedView, vp.View())
 	}
-	internal.RunWithTimeout(t, runTest, 10*time.Millisecond)
+	testutil.RunWithTimeout(t, runTest, 10*time.Millisecond)
 }
 
 func TestViewport_SelectionOn_WrapOn_RegexToHighlightManyMatches(t *testing.T) {
@@ -5037,16 +5170,16 @@ func TestViewport_SelectionOn_WrapOn_RegexToHighlightManyMatches(t *testing.T) {
 			HighlightStyleIfSelected: lipgloss.NewStyle().Foreground(red),
 			SelectedItemStyle:        selectionStyle,
 		})
-		expectedView := pad(vp.GetWidth(), vp.GetHeight(), []string{
+		expectedView := testutil.Pad(vp.GetWidth(), vp.GetHeight(), []string{
 			"header",
 			strings.Repeat("\x1b[38;2;255;0;0mr\x1b[m", 10),
 			strings.Repeat("\x1b[38;2;255;0;0mr\x1b[m", 10),
 			strings.Repeat("\x1b[38;2;255;0;0mr\x1b[m", 10),
 			"100% (1/1)",
 		})
-		internal.CmpStr(t, expectedView, vp.View())
+		testutil.CmpStr(t, expectedView, vp.View())
 	}
-	internal.RunWithTimeout(t, runTest, 10*time.Millisecond)
+	testutil.RunWithTimeout(t, runTest, 10*time.Millisecond)
 }
 
 func TestViewport_SelectionOn_WrapOn_AnsiOnSelection(t *testing.T) {
@@ -5058,14 +5191,14 @@ func TestViewport_SelectionOn_WrapOn_AnsiOnSelection(t *testing.T) {
 	setContent(&vp, []string{
 		"line with some \x1b[38;2;255;0;0mred\x1b[m text",
 	})
-	expectedView := pad(vp.GetWidth(), vp.GetHeight(), []string{
+	expectedView := testutil.Pad(vp.GetWidth(), vp.GetHeight(), []string{
 		"header",
 		"\x1b[38;2;0;0;255mline with \x1b[m",
 		"\x1b[38;2;0;0;255msome \x1b[m\x1b[38;2;255;0;0mred\x1b[m\x1b[38;2;0;0;255m t\x1b[m",
 		"\x1b[38;2;0;0;255mext\x1b[m",
 		"100% (1/1)",
 	})
-	internal.CmpStr(t, expectedView, vp.View())
+	testutil.CmpStr(t, expectedView, vp.View())
 }
 
 func TestViewport_SelectionOn_WrapOn_SelectionEmpty(t *testing.T) {
@@ -5077,11 +5210,11 @@ func TestViewport_SelectionOn_WrapOn_SelectionEmpty(t *testing.T) {
 	setContent(&vp, []string{
 		"",
 	})
-	expectedView := pad(vp.GetWidth(), vp.GetHeight(), []string{
+	expectedView := testutil.Pad(vp.GetWidth(), vp.GetHeight(), []string{
 		"header",
 		"\x1b[38;2;0;0;255m \x1b[m",
 	})
-	internal.CmpStr(t, expectedView, vp.View())
+	testutil.CmpStr(t, expectedView, vp.View())
 }
 
 func TestViewport_SelectionOn_WrapOn_ExtraSlash(t *testing.T) {
@@ -5093,14 +5226,14 @@ func TestViewport_SelectionOn_WrapOn_ExtraSlash(t *testing.T) {
 	setContent(&vp, []string{
 		"|2024|\x1b[38;2;0mfl..lq\x1b[m/\x1b[38;2;0mflask-3\x1b[m|",
 	})
-	expectedView := pad(vp.GetWidth(), vp.GetHeight(), []string{
+	expectedView := testutil.Pad(vp.GetWidth(), vp.GetHeight(), []string{
 		"header",
 		"\x1b[38;2;0;0;255m|2024|\x1b[m\x1b[38;2;0mfl..\x1b[m",
 		"\x1b[38;2;0mlq\x1b[m\x1b[38;2;0;0;255m/\x1b[m\x1b[38;2;0mflask-3\x1b[m",
 		"\x1b[38;2;0;0;255m|\x1b[m",
 		"100% (1/1)",
 	})
-	internal.CmpStr(t, expectedView, vp.View())
+	testutil.CmpStr(t, expectedView, vp.View())
 }
 
 func TestViewport_SelectionOn_WrapOn_SuperLongWrappedLine(t *testing.T) {
@@ -5115,36 +5248,36 @@ func TestViewport_SelectionOn_WrapOn_SuperLongWrappedLine(t *testing.T) {
 			strings.Repeat("12345678", 1000000),
 			"smol",
 		})
-		expectedView := pad(vp.GetWidth(), vp.GetHeight(), []string{
+		expectedView := testutil.Pad(vp.GetWidth(), vp.GetHeight(), []string{
 			"header",
 			"\x1b[38;2;0;0;255msmol\x1b[m",
 			"1234567812",
 			"3456781234",
 			"33% (1/3)",
 		})
-		internal.CmpStr(t, expectedView, vp.View())
+		testutil.CmpStr(t, expectedView, vp.View())
 
 		vp, _ = vp.Update(downKeyMsg)
-		expectedView = pad(vp.GetWidth(), vp.GetHeight(), []string{
+		expectedView = testutil.Pad(vp.GetWidth(), vp.GetHeight(), []string{
 			"header",
 			"\x1b[38;2;0;0;255m1234567812\x1b[m",
 			"\x1b[38;2;0;0;255m3456781234\x1b[m",
 			"\x1b[38;2;0;0;255m5678123456\x1b[m",
 			"66% (2/3)",
 		})
-		internal.CmpStr(t, expectedView, vp.View())
+		testutil.CmpStr(t, expectedView, vp.View())
 
 		vp, _ = vp.Update(downKeyMsg)
-		expectedView = pad(vp.GetWidth(), vp.GetHeight(), []string{
+		expectedView = testutil.Pad(vp.GetWidth(), vp.GetHeight(), []string{
 			"header",
 			"5678123456",
 			"7812345678",
 			"\x1b[38;2;0;0;255msmol\x1b[m",
 			"100% (3/3)",
 		})
-		internal.CmpStr(t, expectedView, vp.View())
+		testutil.CmpStr(t, expectedView, vp.View())
 	}
-	internal.RunWithTimeout(t, runTest, 500*time.Millisecond)
+	testutil.RunWithTimeout(t, runTest, 500*time.Millisecond)
 }
 
 func TestViewport_SelectionOn_WrapOn_StringToHighlightAnsiUnicode(t *testing.T) {
@@ -5165,14 +5298,14 @@ func TestViewport_SelectionOn_WrapOn_StringToHighlightAnsiUnicode(t *testing.T)
 		HighlightStyleIfSelected: lipgloss.NewStyle().Foreground(red),
 		SelectedItemStyle:        selectionStyle,
 	})
-	expectedView := pad(vp.GetWidth(), vp.GetHeight(), []string{
+	expectedView := testutil.Pad(vp.GetWidth(), vp.GetHeight(), []string{
 		"A💖中é",
 		"\x1b[38;2;0;0;255mA💖\x1b[m\x1b[38;2;255;0;0m中é\x1b[m",
 		"A💖\x1b[38;2;0;255;0m中é\x1b[mA💖",
 		"\x1b[38;2;0;255;0m中é\x1b[m",
 		"50% (1/2)",
 	})
-	internal.CmpStr(t, expectedView, vp.View())
+	testutil.CmpStr(t, expectedView, vp.View())
 }
 
 // # OTHER
@@ -5192,7 +5325,7 @@ func TestViewport_SelectionOn_ToggleWrap_PreserveSelection(t *testing.T) {
 	})
 
 	// wrap off, selection on first line
-	expectedView := pad(vp.GetWidth(), vp.GetHeight(), []string{
+	expectedView := testutil.Pad(vp.GetWidth(), vp.GetHeight(), []string{
 		"header",
 		"\x1b[38;2;0;0;255mfirst line t...\x1b[m",
 		"second line ...",
@@ -5200,12 +5333,12 @@ func TestViewport_SelectionOn_ToggleWrap_PreserveSelection(t *testing.T) {
 		"fourth",
 		"16% (1/6)",
 	})
-	internal.CmpStr(t, expectedView, vp.View())
+	testutil.CmpStr(t, expectedView, vp.View())
 
 	// move selection to third line
 	vp, _ = vp.Update(downKeyMsg)
 	vp, _ = vp.Update(downKeyMsg)
-	expectedView = pad(vp.GetWidth(), vp.GetHeight(), []string{
+	expectedView = testutil.Pad(vp.GetWidth(), vp.GetHeight(), []string{
 		"header",
 		"first line t...",
 		"second line ...",
@@ -5213,11 +5346,11 @@ func TestViewport_SelectionOn_ToggleWrap_PreserveSelection(t *testing.T) {
 		"fourth",
 		"50% (3/6)",
 	})
-	internal.CmpStr(t, expectedView, vp.View())
+	testutil.CmpStr(t, expectedView, vp.View())
 
 	// toggle wrap on
 	vp.SetWrapText(true)
-	expectedView = pad(vp.GetWidth(), vp.GetHeight(), []string{
+	expectedView = testutil.Pad(vp.GetWidth(), vp.GetHeight(), []string{
 		"header",
 		"longer than the",
 		" first",
@@ -5225,11 +5358,11 @@ func TestViewport_SelectionOn_ToggleWrap_PreserveSelection(t *testing.T) {
 		"\x1b[38;2;0;0;255m is fairly long\x1b[m",
 		"50% (3/6)",
 	})
-	internal.CmpStr(t, expectedView, vp.View())
+	testutil.CmpStr(t, expectedView, vp.View())
 
 	// toggle wrap off
 	vp.SetWrapText(false)
-	expectedView = pad(vp.GetWidth(), vp.GetHeight(), []string{
+	expectedView = testutil.Pad(vp.GetWidth(), vp.GetHeight(), []string{
 		"header",
 		"first line t...",
 		"second line ...",
@@ -5237,13 +5370,13 @@ func TestViewport_SelectionOn_ToggleWrap_PreserveSelection(t *testing.T) {
 		"fourth",
 		"50% (3/6)",
 	})
-	internal.CmpStr(t, expectedView, vp.View())
+	testutil.CmpStr(t, expectedView, vp.View())
 
 	// move selection to last line
 	vp, _ = vp.Update(downKeyMsg)
 	vp, _ = vp.Update(downKeyMsg)
 	vp, _ = vp.Update(downKeyMsg)
-	expectedView = pad(vp.GetWidth(), vp.GetHeight(), []string{
+	expectedView = testutil.Pad(vp.GetWidth(), vp.GetHeight(), []string{
 		"header",
 		"third line t...",
 		"fourth",
@@ -5251,11 +5384,11 @@ func TestViewport_SelectionOn_ToggleWrap_PreserveSelection(t *testing.T) {
 		"\x1b[38;2;0;0;255msixth\x1b[m",
 		"100% (6/6)",
 	})
-	internal.CmpStr(t, expectedView, vp.View())
+	testutil.CmpStr(t, expectedView, vp.View())
 
 	// toggle wrap on
 	vp.SetWrapText(true)
-	expectedView = pad(vp.GetWidth(), vp.GetHeight(), []string{
+	expectedView = testutil.Pad(vp.GetWidth(), vp.GetHeight(), []string{
 		"header",
 		"fourth",
 		"fifth line that",
@@ -5263,11 +5396,11 @@ func TestViewport_SelectionOn_ToggleWrap_PreserveSelection(t *testing.T) {
 		"\x1b[38;2;0;0;255msixth\x1b[m",
 		"100% (6/6)",
 	})
-	internal.CmpStr(t, expectedView, vp.View())
+	testutil.CmpStr(t, expectedView, vp.View())
 
 	// toggle wrap off
 	vp.SetWrapText(false)
-	expectedView = pad(vp.GetWidth(), vp.GetHeight(), []string{
+	expectedView = testutil.Pad(vp.GetWidth(), vp.GetHeight(), []string{
 		"header",
 		"third line t...",
 		"fourth",
@@ -5275,7 +5408,7 @@ func TestViewport_SelectionOn_ToggleWrap_PreserveSelection(t *testing.T) {
 		"\x1b[38;2;0;0;255msixth\x1b[m",
 		"100% (6/6)",
 	})
-	internal.CmpStr(t, expectedView, vp.View())
+	testutil.CmpStr(t, expectedView, vp.View())
 }
 
 func TestViewport_SelectionOn_ToggleWrap_PreserveSelectionInView(t *testing.T) {
@@ -5290,7 +5423,7 @@ func TestViewport_SelectionOn_ToggleWrap_PreserveSelectionInView(t *testing.T) {
 		"third line that is fairly long",
 	})
 	vp.SetSelectedItemIdx(3)
-	expectedView := pad(vp.GetWidth(), vp.GetHeight(), []string{
+	expectedView := testutil.Pad(vp.GetWidth(), vp.GetHeight(), []string{
 		"header",
 		"a really rea...",
 		"first line t...",
@@ -5298,11 +5431,11 @@ func TestViewport_SelectionOn_ToggleWrap_PreserveSelectionInView(t *testing.T) {
 		"\x1b[38;2;0;0;255mthird line t...\x1b[m",
 		"100% (4/4)",
 	})
-	internal.CmpStr(t, expectedView, vp.View())
+	testutil.CmpStr(t, expectedView, vp.View())
 
 	// toggle wrap, full wrapped selection should remain in view
 	vp.SetWrapText(true)
-	expectedView = pad(vp.GetWidth(), vp.GetHeight(), []string{
+	expectedView = testutil.Pad(vp.GetWidth(), vp.GetHeight(), []string{
 		"header",
 		"longer than the",
 		" first",
@@ -5310,11 +5443,11 @@ func TestViewport_SelectionOn_ToggleWrap_PreserveSelectionInView(t *testing.T) {
 		"\x1b[38;2;0;0;255m is fairly long\x1b[m",
 		"100% (4/4)",
 	})
-	internal.CmpStr(t, expectedView, vp.View())
+	testutil.CmpStr(t, expectedView, vp.View())
 
 	// toggle wrap
 	vp.SetWrapText(false)
-	expectedView = pad(vp.GetWidth(), vp.GetHeight(), []string{
+	expectedView = testutil.Pad(vp.GetWidth(), vp.GetHeight(), []string{
 		"header",
 		"a really rea...",
 		"first line t...",
@@ -5322,7 +5455,7 @@ func TestViewport_SelectionOn_ToggleWrap_PreserveSelectionInView(t *testing.T) {
 		"\x1b[38;2;0;0;255mthird line t...\x1b[m",
 		"100% (4/4)",
 	})
-	internal.CmpStr(t, expectedView, vp.View())
+	testutil.CmpStr(t, expectedView, vp.View())
 }
 
 func TestViewport_SelectionOn_ToggleWrap_ScrollInBounds(t *testing.T) {
@@ -5344,7 +5477,7 @@ func TestViewport_SelectionOn_ToggleWrap_ScrollInBounds(t *testing.T) {
 	vp.SetSelectedItemIdx(5)
 	vp, _ = vp.Update(upKeyMsg)
 	vp, _ = vp.Update(upKeyMsg)
-	expectedView := pad(vp.GetWidth(), vp.GetHeight(), []string{
+	expectedView := testutil.Pad(vp.GetWidth(), vp.GetHeight(), []string{
 		"header",
 		"\x1b[38;2;0;0;255mthe fourth\x1b[m",
 		"\x1b[38;2;0;0;255m line\x1b[m",
@@ -5353,11 +5486,11 @@ func TestViewport_SelectionOn_ToggleWrap_ScrollInBounds(t *testing.T) {
 		"the sixth ",
 		"66% (4/6)",
 	})
-	internal.CmpStr(t, expectedView, vp.View())
+	testutil.CmpStr(t, expectedView, vp.View())
 
 	// toggle wrap
 	vp.SetWrapText(false)
-	expectedView = pad(vp.GetWidth(), vp.GetHeight(), []string{
+	expectedView = testutil.Pad(vp.GetWidth(), vp.GetHeight(), []string{
 		"header",
 		"the sec...",
 		"the thi...",
@@ -5366,5 +5499,1662 @@ func TestViewport_SelectionOn_ToggleWrap_ScrollInBounds(t *testing.T) {
 		"the six...",
 		"66% (4/6)",
 	})
-	internal.CmpStr(t, expectedView, vp.View())
+	testutil.CmpStr(t, expectedView, vp.View())
+}
+
+func TestViewport_SelectionOn_Marks(t *testing.T) {
+	w, h := 15, 4
+	vp := newViewport(w, h)
+	vp.SetSelectionEnabled(true)
+	vp.SetSelectionComparator(RenderableStringCompareFn)
+	vp.SetStyles(Styles{
+		FooterStyle:              lipgloss.NewStyle(),
+		HighlightStyle:           lipgloss.NewStyle(),
+		HighlightStyleIfSelected: lipgloss.NewStyle(),
+		SelectedItemStyle:        selectionStyle,
+		MarkedItemStyle:          lipgloss.NewStyle().Foreground(red),
+	})
+	setContent(&vp, []string{
+		"first",
+		"second",
+		"third",
+		"fourth",
+		"fifth",
+	})
+
+	// mark the second item
+	vp, _ = vp.Update(downKeyMsg)
+	vp, _ = vp.Update(setMarkKeyMsg)
+	vp, _ = vp.Update(aKeyMsg)
+	vp.SetSelectedItemIdx(4)
+	expectedView := testutil.Pad(vp.GetWidth(), vp.GetHeight(), []string{
+		"third",
+		"fourth",
+		"\x1b[38;2;0;0;255mfifth\x1b[m",
+		"100% (5/5)",
+	})
+	testutil.CmpStr(t, expectedView, vp.View())
+
+	// jump back to it
+	vp, _ = vp.Update(jumpToMarkKeyMsg)
+	vp, _ = vp.Update(aKeyMsg)
+	if selectedItemIdx := vp.GetSelectedItemIdx(); selectedItemIdx != 1 {
+		t.Errorf("expected selected item index to be 1, got %v", selectedItemIdx)
+	}
+
+	// jumping to a mark that doesn't exist does nothing
+	vp, _ = vp.Update(jumpToMarkKeyMsg)
+	vp, _ = vp.Update(zKeyMsg)
+	if selectedItemIdx := vp.GetSelectedItemIdx(); selectedItemIdx != 1 {
+		t.Errorf("expected selected item index to be 1, got %v", selectedItemIdx)
+	}
+
+	// marked items are styled when not selected
+	vp.SetSelectedItemIdx(0)
+	expectedView = testutil.Pad(vp.GetWidth(), vp.GetHeight(), []string{
+		"\x1b[38;2;0;0;255mfirst\x1b[m",
+		"\x1b[38;2;255;0;0msecond\x1b[m",
+		"third",
+		"20% (1/5)",
+	})
+	testutil.CmpStr(t, expectedView, vp.View())
+
+	// marks follow their items when content changes
+	setContent(&vp, []string{
+		"zeroth",
+		"first",
+		"second",
+		"third",
+		"fourth",
+		"fifth",
+	})
+	if idx, ok := vp.content.GetMarkIdx('a'); !ok || idx != 2 {
+		t.Errorf("expected mark 'a' at index 2, got %v (exists: %v)", idx, ok)
+	}
+	marks := vp.Marks()
+	if len(marks) != 1 || marks['a'].LineBuffer.Content() != "second" {
+		t.Errorf("expected mark 'a' on 'second', got %v", marks)
+	}
+
+	// marks are removed when their items are removed
+	setContent(&vp, []string{
+		"first",
+		"third",
+	})
+	if marks = vp.Marks(); len(marks) != 0 {
+		t.Errorf("expected no marks, got %v", marks)
+	}
+}
+
+func TestViewport_SelectionOn_CycleMarks(t *testing.T) {
+	w, h := 15, 4
+	vp := newViewport(w, h)
+	vp.SetSelectionEnabled(true)
+	setContent(&vp, []string{
+		"first",
+		"second",
+		"third",
+		"fourth",
+		"fifth",
+	})
+	vp.SetSelectedItemIdx(1)
+	vp.SetMark('a')
+	vp.SetSelectedItemIdx(3)
+	vp.SetMark('b')
+	vp.SetMark('c')
+	vp.SetSelectedItemIdx(2)
+
+	for _, expectedIdx := range []int{3, 1, 3} {
+		vp, _ = vp.Update(nextMarkKeyMsg)
+		if selectedItemIdx := vp.GetSelectedItemIdx(); selectedItemIdx != expectedIdx {
+			t.Errorf("expected selected item index to be %d, got %v", expectedIdx, selectedItemIdx)
+		}
+	}
+	for _, expectedIdx := range []int{1, 3, 1} {
+		vp, _ = vp.Update(prevMarkKeyMsg)
+		if selectedItemIdx := vp.GetSelectedItemIdx(); selectedItemIdx != expectedIdx {
+			t.Errorf("expected selected item index to be %d, got %v", expectedIdx, selectedItemIdx)
+		}
+	}
+
+	// without a comparator, marks stay on the same index
+	setContent(&vp, []string{
+		"first",
+		"second",
+	})
+	if marks := vp.Marks(); len(marks) != 1 || marks['a'].LineBuffer.Content() != "second" {
+		t.Errorf("expected only mark 'a' on 'second', got %v", marks)
+	}
+}
+
+func TestViewport_SelectionOff_Marks(t *testing.T) {
+	w, h := 15, 4
+	vp := newViewport(w, h)
+	vp.SetSelectionComparator(RenderableStringCompareFn)
+	setContent(&vp, []string{
+		"first",
+		"second",
+		"third",
+		"fourth",
+		"fifth",
+	})
+
+	// mark the top item
+	vp, _ = vp.Update(downKeyMsg)
+	vp, _ = vp.Update(setMarkKeyMsg)
+	vp, _ = vp.Update(aKeyMsg)
+	vp, _ = vp.Update(goToTopKeyMsg)
+	expectedView := testutil.Pad(vp.GetWidth(), vp.GetHeight(), []string{
+		"first",
+		"second",
+		"third",
+		"60% (3/5)",
+	})
+	testutil.CmpStr(t, expectedView, vp.View())
+
+	// jumping to the mark scrolls it to the top
+	vp, _ = vp.Update(jumpToMarkKeyMsg)
+	vp, _ = vp.Update(aKeyMsg)
+	expectedView = testutil.Pad(vp.GetWidth(), vp.GetHeight(), []string{
+		"second",
+		"third",
+		"fourth",
+		"80% (4/5)",
+	})
+	testutil.CmpStr(t, expectedView, vp.View())
+
+	// restore persisted marks
+	marks := vp.Marks()
+	vp.ClearMarks()
+	if len(vp.Marks()) != 0 {
+		t.Errorf("expected no marks after clearing")
+	}
+	vp.SetMarks(marks)
+	if idx, ok := vp.content.GetMarkIdx('a'); !ok || idx != 1 {
+		t.Errorf("expected mark 'a' at index 1, got %v (exists: %v)", idx, ok)
+	}
+}
+
+func TestViewport_SelectionOff_WrapOff_ScrollToItemAligned(t *testing.T) {
+	w, h := 15, 4
+	vp := newViewport(w, h)
+	setContent(&vp, []string{
+		"first",
+		"second",
+		"third",
+		"fourth",
+		"fifth",
+		"sixth",
+		"seventh",
+	})
+
+	vp.ScrollToItem(3, AlignTop)
+	expectedView := testutil.Pad(vp.GetWidth(), vp.GetHeight(), []string{
+		"fourth",
+		"fifth",
+		"sixth",
+		"85% (6/7)",
+	})
+	testutil.CmpStr(t, expectedView, vp.View())
+
+	vp.ScrollToItem(3, AlignCenter)
+	expectedView = testutil.Pad(vp.GetWidth(), vp.GetHeight(), []string{
+		"third",
+		"fourth",
+		"fifth",
+		"71% (5/7)",
+	})
+	testutil.CmpStr(t, expectedView, vp.View())
+
+	vp.ScrollToItem(3, AlignBottom)
+	expectedView = testutil.Pad(vp.GetWidth(), vp.GetHeight(), []string{
+		"second",
+		"third",
+		"fourth",
+		"57% (4/7)",
+	})
+	testutil.CmpStr(t, expectedView, vp.View())
+
+	// can't scroll past the bottom
+	vp.ScrollToItem(6, AlignTop)
+	expectedView = testutil.Pad(vp.GetWidth(), vp.GetHeight(), []string{
+		"fifth",
+		"sixth",
+		"seventh",
+		"100% (7/7)",
+	})
+	testutil.CmpStr(t, expectedView, vp.View())
+
+	// can't scroll past the top
+	vp.ScrollToItem(0, AlignBottom)
+	expectedView = testutil.Pad(vp.GetWidth(), vp.GetHeight(), []string{
+		"first",
+		"second",
+		"third",
+		"42% (3/7)",
+	})
+	testutil.CmpStr(t, expectedView, vp.View())
+}
+
+func TestViewport_SelectionOn_WrapOn_ScrollToItemAligned(t *testing.T) {
+	w, h := 10, 6
+	vp := newViewport(w, h)
+	vp.SetSelectionEnabled(true)
+	vp.SetWrapText(true)
+	setContent(&vp, []string{
+		"first",
+		"second",
+		"the third line",
+		"fourth",
+		"fifth",
+		"sixth",
+		"seventh",
+	})
+
+	vp.ScrollToItem(2, AlignTop)
+	expectedView := testutil.Pad(vp.GetWidth(), vp.GetHeight(), []string{
+		"\x1b[38;2;0;0;255mthe third \x1b[m",
+		"\x1b[38;2;0;0;255mline\x1b[m",
+		"fourth",
+		"fifth",
+		"sixth",
+		"42% (3/7)",
+	})
+	testutil.CmpStr(t, expectedView, vp.View())
+
+	vp.ScrollToItem(2, AlignCenter)
+	expectedView = testutil.Pad(vp.GetWidth(), vp.GetHeight(), []string{
+		"second",
+		"\x1b[38;2;0;0;255mthe third \x1b[m",
+		"\x1b[38;2;0;0;255mline\x1b[m",
+		"fourth",
+		"fifth",
+		"42% (3/7)",
+	})
+	testutil.CmpStr(t, expectedView, vp.View())
+
+	vp.ScrollToItem(3, AlignBottom)
+	expectedView = testutil.Pad(vp.GetWidth(), vp.GetHeight(), []string{
+		"first",
+		"second",
+		"the third ",
+		"line",
+		"\x1b[38;2;0;0;255mfourth\x1b[m",
+		"57% (4/7)",
+	})
+	testutil.CmpStr(t, expectedView, vp.View())
+}
+
+func TestViewport_CountPrefix_GoTo(t *testing.T) {
+	w, h := 15, 4
+	vp := newViewport(w, h)
+	vp.SetCountPrefixEnabled(true)
+	content := make([]string, 100)
+	for i := range content {
+		content[i] = "line " + strconv.Itoa(i+1)
+	}
+	setContent(&vp, content)
+
+	// 42G goes to the 42nd item
+	for _, msg := range []tea.KeyPressMsg{{Code: '4', Text: "4"}, {Code: '2', Text: "2"}, goToBottomKeyMsg} {
+		vp, _ = vp.Update(msg)
+	}
+	expectedView := testutil.Pad(vp.GetWidth(), vp.GetHeight(), []string{
+		"line 42",
+		"line 43",
+		"line 44",
+		"44% (44/100)",
+	})
+	testutil.CmpStr(t, expectedView, vp.View())
+
+	// 50% goes halfway through
+	for _, msg := range []tea.KeyPressMsg{{Code: '5', Text: "5"}, {Code: '0', Text: "0"}, percentKeyMsg} {
+		vp, _ = vp.Update(msg)
+	}
+	expectedView = testutil.Pad(vp.GetWidth(), vp.GetHeight(), []string{
+		"line 50",
+		"line 51",
+		"line 52",
+		"52% (52/100)",
+	})
+	testutil.CmpStr(t, expectedView, vp.View())
+
+	// G without a count still goes to the bottom
+	vp, _ = vp.Update(goToBottomKeyMsg)
+	expectedView = testutil.Pad(vp.GetWidth(), vp.GetHeight(), []string{
+		"line 98",
+		"line 99",
+		"line 100",
+		"100% (100/100)",
+	})
+	testutil.CmpStr(t, expectedView, vp.View())
+
+	// selection enabled selects the item
+	vp.SetSelectionEnabled(true)
+	for _, msg := range []tea.KeyPressMsg{{Code: '2', Text: "2"}, {Code: '5', Text: "5"}, percentKeyMsg} {
+		vp, _ = vp.Update(msg)
+	}
+	if selectedItemIdx := vp.GetSelectedItemIdx(); selectedItemIdx != 24 {
+		t.Errorf("expected selected item index to be 24, got %v", selectedItemIdx)
+	}
+	for _, msg := range []tea.KeyPressMsg{{Code: '7', Text: "7"}, goToTopKeyMsg} {
+		vp, _ = vp.Update(msg)
+	}
+	if selectedItemIdx := vp.GetSelectedItemIdx(); selectedItemIdx != 6 {
+		t.Errorf("expected selected item index to be 6, got %v", selectedItemIdx)
+	}
+
+	// count prefix disabled ignores digits
+	vp.SetCountPrefixEnabled(false)
+	for _, msg := range []tea.KeyPressMsg{{Code: '7', Text: "7"}, goToBottomKeyMsg} {
+		vp, _ = vp.Update(msg)
+	}
+	if selectedItemIdx := vp.GetSelectedItemIdx(); selectedItemIdx != 99 {
+		t.Errorf("expected selected item index to be 99, got %v", selectedItemIdx)
+	}
+}
+
+func TestViewport_CountPrefix_Navigation(t *testing.T) {
+	w, h := 15, 4
+	vp := newViewport(w, h)
+	vp.SetCountPrefixEnabled(true)
+	vp.SetSelectionEnabled(true)
+	content := make([]string, 100)
+	for i := range content {
+		content[i] = "line " + strconv.Itoa(i+1)
+	}
+	setContent(&vp, content)
+
+	vp, _ = vp.Update(tea.KeyPressMsg{Code: '1', Text: "1"})
+	vp, _ = vp.Update(tea.KeyPressMsg{Code: '5', Text: "5"})
+	if count := vp.GetPendingCount(); count != 15 {
+		t.Errorf("expected pending count 15, got %d", count)
+	}
+	vp, _ = vp.Update(downKeyMsg)
+	if count := vp.GetPendingCount(); count != 0 {
+		t.Errorf("expected no pending count, got %d", count)
+	}
+	expectedView := testutil.Pad(vp.GetWidth(), vp.GetHeight(), []string{
+		"line 14",
+		"line 15",
+		"\x1b[38;2;0;0;255mline 16\x1b[m",
+		"16% (16/100)",
+	})
+	testutil.CmpStr(t, expectedView, vp.View())
+
+	// 2 pages down moves selection by 2 pages of items
+	vp, _ = vp.Update(tea.KeyPressMsg{Code: '2', Text: "2"})
+	vp, _ = vp.Update(fullPgDownKeyMsg)
+	if selectedItemIdx := vp.GetSelectedItemIdx(); selectedItemIdx != 21 {
+		t.Errorf("expected selected item index to be 21, got %v", selectedItemIdx)
+	}
+
+	// count is cleared on escape
+	vp, _ = vp.Update(tea.KeyPressMsg{Code: '9', Text: "9"})
+	vp, _ = vp.Update(tea.KeyPressMsg{Code: tea.KeyEscape})
+	vp, _ = vp.Update(upKeyMsg)
+	if selectedItemIdx := vp.GetSelectedItemIdx(); selectedItemIdx != 20 {
+		t.Errorf("expected selected item index to be 20, got %v", selectedItemIdx)
+	}
+}
+
+func TestViewport_CountPrefix_Timeout(t *testing.T) {
+	vp := newViewport(15, 4)
+	vp.SetCountPrefixEnabled(true)
+	vp.navigation.PendingTimeout = time.Millisecond
+	setContent(&vp, []string{"line 1", "line 2"})
+
+	vp, cmd := vp.Update(tea.KeyPressMsg{Code: '1', Text: "1"})
+	if cmd == nil {
+		t.Fatal("expected a count timeout command")
+	}
+	staleTimeout := cmd()
+	vp, cmd = vp.Update(tea.KeyPressMsg{Code: '5', Text: "5"})
+
+	// the timeout of the first digit doesn't clear the count extended by the second, and getting the count past its
+	// timeout has no side effects
+	vp, _ = vp.Update(staleTimeout)
+	time.Sleep(2 * vp.navigation.PendingTimeout)
+	for range 3 {
+		if count := vp.GetPendingCount(); count != 15 {
+			t.Errorf("expected pending count 15, got %d", count)
+		}
+	}
+	vp, _ = vp.Update(cmd())
+	if count := vp.GetPendingCount(); count != 0 {
+		t.Errorf("expected the count to be cleared on timeout, got %d", count)
+	}
+}
+
+func TestViewport_SelectionOn_WrapOn_AlignSelectionKeySequences(t *testing.T) {
+	w, h := 10, 6
+	vp := newViewport(w, h)
+	vp.SetSelectionEnabled(true)
+	vp.SetWrapText(true)
+	setContent(&vp, []string{
+		"first",
+		"second",
+		"the third line",
+		"fourth",
+		"fifth",
+		"sixth",
+		"seventh",
+	})
+	vp.SetSelectedItemIdx(2)
+
+	// z t
+	vp, _ = vp.Update(zKeyMsg)
+	if pending := vp.GetPendingKeys(); len(pending) != 1 || pending[0] != "z" {
+		t.Errorf("expected pending keys [z], got %v", pending)
+	}
+	vp, _ = vp.Update(tea.KeyPressMsg{Code: 't', Text: "t"})
+	expectedView := testutil.Pad(vp.GetWidth(), vp.GetHeight(), []string{
+		"\x1b[38;2;0;0;255mthe third \x1b[m",
+		"\x1b[38;2;0;0;255mline\x1b[m",
+		"fourth",
+		"fifth",
+		"sixth",
+		"42% (3/7)",
+	})
+	testutil.CmpStr(t, expectedView, vp.View())
+
+	// z z
+	vp, _ = vp.Update(zKeyMsg)
+	vp, _ = vp.Update(zKeyMsg)
+	expectedView = testutil.Pad(vp.GetWidth(), vp.GetHeight(), []string{
+		"second",
+		"\x1b[38;2;0;0;255mthe third \x1b[m",
+		"\x1b[38;2;0;0;255mline\x1b[m",
+		"fourth",
+		"fifth",
+		"42% (3/7)",
+	})
+	testutil.CmpStr(t, expectedView, vp.View())
+
+	// z b
+	vp, _ = vp.Update(zKeyMsg)
+	vp, _ = vp.Update(tea.KeyPressMsg{Code: 'b', Text: "b"})
+	expectedView = testutil.Pad(vp.GetWidth(), vp.GetHeight(), []string{
+		"first",
+		"second",
+		"\x1b[38;2;0;0;255mthe third \x1b[m",
+		"\x1b[38;2;0;0;255mline\x1b[m",
+		"fourth",
+		"42% (3/7)",
+	})
+	testutil.CmpStr(t, expectedView, vp.View())
+}
+
+func TestViewport_AmbiguousKeySequence(t *testing.T) {
+	w, h := 15, 4
+	vp := newViewport(w, h)
+	vp.SetSelectionEnabled(true)
+	km := vp.navigation.KeyMap
+	km.Top = key.NewBinding(key.WithKeys("g g"))
+	km.Bottom = key.NewBinding(key.WithKeys("g"))
+	vp.SetKeyMap(km)
+	setContent(&vp, []string{
+		"first",
+		"second",
+		"third",
+		"fourth",
+		"fifth",
+	})
+
+	// g g goes to the top
+	vp.SetSelectedItemIdx(2)
+	vp, _ = vp.Update(goToTopKeyMsg)
+	vp, _ = vp.Update(goToTopKeyMsg)
+	if selectedItemIdx := vp.GetSelectedItemIdx(); selectedItemIdx != 0 {
+		t.Errorf("expected selected item index to be 0, got %v", selectedItemIdx)
+	}
+
+	// g waits for the timeout, then goes to the bottom
+	var cmd tea.Cmd
+	vp, cmd = vp.Update(goToTopKeyMsg)
+	if selectedItemIdx := vp.GetSelectedItemIdx(); selectedItemIdx != 0 {
+		t.Errorf("expected selected item index to be 0, got %v", selectedItemIdx)
+	}
+	if cmd == nil {
+		t.Fatalf("expected sequence timeout command")
+	}
+	vp, _ = vp.Update(sequenceTimeoutMsg{navigationManagerID: vp.navigation.id, tag: vp.navigation.pendingKeysTag})
+	if selectedItemIdx := vp.GetSelectedItemIdx(); selectedItemIdx != 4 {
+		t.Errorf("expected selected item index to be 4, got %v", selectedItemIdx)
+	}
+
+	// g then k goes to the bottom, then up
+	vp.SetSelectedItemIdx(0)
+	vp, _ = vp.Update(goToTopKeyMsg)
+	vp, _ = vp.Update(upKeyMsg)
+	if selectedItemIdx := vp.GetSelectedItemIdx(); selectedItemIdx != 3 {
+		t.Errorf("expected selected item index to be 3, got %v", selectedItemIdx)
+	}
+}
+
+func TestViewport_SelectionOn_WrapOff_ScrollOff(t *testing.T) {
+	w, h := 15, 5
+	vp := newViewport(w, h)
+	vp.SetSelectionEnabled(true)
+	vp.SetScrollOff(1)
+	setContent(&vp, []string{
+		"first",
+		"second",
+		"third",
+		"fourth",
+		"fifth",
+		"sixth",
+	})
+
+	// selection scrolls with one line of context below
+	vp, _ = vp.Update(downKeyMsg)
+	vp, _ = vp.Update(downKeyMsg)
+	vp, _ = vp.Update(downKeyMsg)
+	expectedView := testutil.Pad(vp.GetWidth(), vp.GetHeight(), []string{
+		"second",
+		"third",
+		"\x1b[38;2;0;0;255mfourth\x1b[m",
+		"fifth",
+		"66% (4/6)",
+	})
+	testutil.CmpStr(t, expectedView, vp.View())
+
+	// no context past the bottom
+	vp, _ = vp.Update(downKeyMsg)
+	vp, _ = vp.Update(downKeyMsg)
+	expectedView = testutil.Pad(vp.GetWidth(), vp.GetHeight(), []string{
+		"third",
+		"fourth",
+		"fifth",
+		"\x1b[38;2;0;0;255msixth\x1b[m",
+		"100% (6/6)",
+	})
+	testutil.CmpStr(t, expectedView, vp.View())
+
+	// selection scrolls with one line of context above
+	vp, _ = vp.Update(upKeyMsg)
+	vp, _ = vp.Update(upKeyMsg)
+	vp, _ = vp.Update(upKeyMsg)
+	expectedView = testutil.Pad(vp.GetWidth(), vp.GetHeight(), []string{
+		"second",
+		"\x1b[38;2;0;0;255mthird\x1b[m",
+		"fourth",
+		"fifth",
+		"50% (3/6)",
+	})
+	testutil.CmpStr(t, expectedView, vp.View())
+}
+
+func TestViewport_SelectionOn_WrapOn_ScrollOff(t *testing.T) {
+	w, h := 10, 7
+	vp := newViewport(w, h)
+	vp.SetSelectionEnabled(true)
+	vp.SetWrapText(true)
+	vp.SetScrollOff(2)
+	setContent(&vp, []string{
+		"first",
+		"second",
+		"the third line",
+		"the fourth line",
+		"fifth",
+		"sixth",
+		"seventh",
+	})
+
+	// two wrapped lines of context below
+	vp, _ = vp.Update(downKeyMsg)
+	vp, _ = vp.Update(downKeyMsg)
+	vp, _ = vp.Update(downKeyMsg)
+	expectedView := testutil.Pad(vp.GetWidth(), vp.GetHeight(), []string{
+		"the third ",
+		"line",
+		"\x1b[38;2;0;0;255mthe fourth\x1b[m",
+		"\x1b[38;2;0;0;255m line\x1b[m",
+		"fifth",
+		"sixth",
+		"57% (4/7)",
+	})
+	testutil.CmpStr(t, expectedView, vp.View())
+
+	// margin is limited so a selection never has to leave view
+	vp.SetScrollOff(100)
+	vp, _ = vp.Update(upKeyMsg)
+	expectedView = testutil.Pad(vp.GetWidth(), vp.GetHeight(), []string{
+		"first",
+		"second",
+		"\x1b[38;2;0;0;255mthe third \x1b[m",
+		"\x1b[38;2;0;0;255mline\x1b[m",
+		"the fourth",
+		" line",
+		"42% (3/7)",
+	})
+	testutil.CmpStr(t, expectedView, vp.View())
+}
+
+func TestViewport_SelectionOn_KeepSelectionCentered(t *testing.T) {
+	w, h := 15, 6
+	vp := newViewport(w, h)
+	vp.SetSelectionEnabled(true)
+	vp.SetKeepSelectionCentered(true)
+	setContent(&vp, []string{
+		"first",
+		"second",
+		"third",
+		"fourth",
+		"fifth",
+		"sixth",
+		"seventh",
+		"eighth",
+	})
+
+	// can't center near the top
+	vp, _ = vp.Update(downKeyMsg)
+	expectedView := testutil.Pad(vp.GetWidth(), vp.GetHeight(), []string{
+		"first",
+		"\x1b[38;2;0;0;255msecond\x1b[m",
+		"third",
+		"fourth",
+		"fifth",
+		"25% (2/8)",
+	})
+	testutil.CmpStr(t, expectedView, vp.View())
+
+	vp, _ = vp.Update(downKeyMsg)
+	vp, _ = vp.Update(downKeyMsg)
+	vp, _ = vp.Update(downKeyMsg)
+	expectedView = testutil.Pad(vp.GetWidth(), vp.GetHeight(), []string{
+		"third",
+		"fourth",
+		"\x1b[38;2;0;0;255mfifth\x1b[m",
+		"sixth",
+		"seventh",
+		"62% (5/8)",
+	})
+	testutil.CmpStr(t, expectedView, vp.View())
+
+	// can't center near the bottom
+	vp, _ = vp.Update(goToBottomKeyMsg)
+	expectedView = testutil.Pad(vp.GetWidth(), vp.GetHeight(), []string{
+		"fourth",
+		"fifth",
+		"sixth",
+		"seventh",
+		"\x1b[38;2;0;0;255meighth\x1b[m",
+		"100% (8/8)",
+	})
+	testutil.CmpStr(t, expectedView, vp.View())
+}
+
+func TestViewport_SelectionOff_WrapOff_SmoothScroll(t *testing.T) {
+	w, h := 15, 5
+	vp := newViewport(w, h)
+	start := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	vp.navigation.now = func() time.Time { return start }
+	vp.SetSmoothScroll(true)
+	vp.SetSmoothScrollDuration(100 * time.Millisecond)
+	vp.SetSmoothScrollEasing(EaseLinear)
+	setContent(&vp, []string{
+		"1",
+		"2",
+		"3",
+		"4",
+		"5",
+		"6",
+		"7",
+		"8",
+		"9",
+		"10",
+	})
+	frameMsg := func(elapsed time.Duration) scrollFrameMsg {
+		return scrollFrameMsg{navigationManagerID: vp.navigation.id, tag: vp.display.animation.tag, time: start.Add(elapsed)}
+	}
+
+	// page down starts animating from the original position
+	vp, cmd := vp.Update(fullPgDownKeyMsg)
+	if cmd == nil || !vp.IsScrollAnimating() {
+		t.Fatalf("expected smooth scroll to start")
+	}
+	expectedView := testutil.Pad(vp.GetWidth(), vp.GetHeight(), []string{
+		"1",
+		"2",
+		"3",
+		"4",
+		"40% (4/10)",
+	})
+	testutil.CmpStr(t, expectedView, vp.View())
+
+	// halfway through, half the lines are scrolled
+	vp, cmd = vp.Update(frameMsg(50 * time.Millisecond))
+	if cmd == nil {
+		t.Errorf("expected command for next frame")
+	}
+	expectedView = testutil.Pad(vp.GetWidth(), vp.GetHeight(), []string{
+		"3",
+		"4",
+		"5",
+		"6",
+		"60% (6/10)",
+	})
+	testutil.CmpStr(t, expectedView, vp.View())
+
+	// at the end of the duration, the animation finishes at the target
+	vp, cmd = vp.Update(frameMsg(100 * time.Millisecond))
+	if cmd != nil || vp.IsScrollAnimating() {
+		t.Errorf("expected smooth scroll to finish")
+	}
+	expectedView = testutil.Pad(vp.GetWidth(), vp.GetHeight(), []string{
+		"5",
+		"6",
+		"7",
+		"8",
+		"80% (8/10)",
+	})
+	testutil.CmpStr(t, expectedView, vp.View())
+
+	// stale frames are ignored
+	vp, cmd = vp.Update(frameMsg(50 * time.Millisecond))
+	if cmd != nil {
+		t.Errorf("expected stale frame to be ignored")
+	}
+	testutil.CmpStr(t, expectedView, vp.View())
+}
+
+func TestViewport_SelectionOn_WrapOn_SmoothScrollInterrupted(t *testing.T) {
+	w, h := 15, 5
+	vp := newViewport(w, h)
+	start := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	vp.navigation.now = func() time.Time { return start }
+	vp.SetSelectionEnabled(true)
+	vp.SetWrapText(true)
+	vp.SetSmoothScroll(true)
+	setContent(&vp, []string{
+		"1",
+		"2",
+		"3",
+		"4",
+		"5",
+		"6",
+		"7",
+		"8",
+		"9",
+		"10",
+	})
+
+	// selection moves immediately while the scroll is animated
+	vp, _ = vp.Update(fullPgDownKeyMsg)
+	if !vp.IsScrollAnimating() {
+		t.Fatalf("expected smooth scroll to start")
+	}
+	if idx := vp.GetSelectedItemIdx(); idx != 4 {
+		t.Errorf("expected selected item idx 4, got %d", idx)
+	}
+
+	// a new key finishes the animation before being handled
+	vp, _ = vp.Update(downKeyMsg)
+	if vp.IsScrollAnimating() {
+		t.Errorf("expected smooth scroll to finish on new key")
+	}
+	expectedView := testutil.Pad(vp.GetWidth(), vp.GetHeight(), []string{
+		"5",
+		"\x1b[38;2;0;0;255m6\x1b[m",
+		"7",
+		"8",
+		"60% (6/10)",
+	})
+	testutil.CmpStr(t, expectedView, vp.View())
+}
+
+func TestViewport_SelectionOff_WrapOff_SmoothScrollInterruptedByJump(t *testing.T) {
+	tests := []struct {
+		name    string
+		jump    func(vp *Model[RenderableString])
+		expTop  int
+		enabled bool
+	}{
+		{
+			name:   "scroll to item",
+			jump:   func(vp *Model[RenderableString]) { vp.ScrollToItem(80, AlignTop) },
+			expTop: 80,
+		},
+		{
+			name:   "scroll to percent",
+			jump:   func(vp *Model[RenderableString]) { vp.ScrollToPercent(50) },
+			expTop: 49,
+		},
+		{
+			name: "next match",
+			jump: func(vp *Model[RenderableString]) {
+				vp.SetStringToHighlight("item 60")
+				vp.NextMatch()
+			},
+			expTop: 60,
+		},
+		{
+			name: "selected item",
+			jump: func(vp *Model[RenderableString]) {
+				vp.SetSelectedItemIdx(80)
+			},
+			expTop:  77,
+			enabled: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			vp := newViewport(15, 5)
+			start := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+			vp.navigation.now = func() time.Time { return start }
+			vp.SetSelectionEnabled(tt.enabled)
+			vp.SetSmoothScroll(true)
+			vp.SetSmoothScrollDuration(100 * time.Millisecond)
+			var content []string
+			for i := range 100 {
+				content = append(content, "item "+strconv.Itoa(i))
+			}
+			setContent(&vp, content)
+			frameMsg := func(elapsed time.Duration) scrollFrameMsg {
+				return scrollFrameMsg{navigationManagerID: vp.navigation.id, tag: vp.display.animation.tag, time: start.Add(elapsed)}
+			}
+
+			vp, _ = vp.Update(fullPgDownKeyMsg)
+			if !vp.IsScrollAnimating() {
+				t.Fatalf("expected smooth scroll to start")
+			}
+			tt.jump(&vp)
+			if vp.IsScrollAnimating() {
+				t.Errorf("expected jump to finish the smooth scroll")
+			}
+			for _, elapsed := range []time.Duration{50 * time.Millisecond, 100 * time.Millisecond} {
+				vp, _ = vp.Update(frameMsg(elapsed))
+				if top, _ := vp.GetTopItemIdx(); top != tt.expTop {
+					t.Errorf("after frame at %v, expected top item idx %d, got %d", elapsed, tt.expTop, top)
+				}
+			}
+		})
+	}
+}
+
+func TestViewport_SelectionOff_WrapOff_PanHeader(t *testing.T) {
+	w, h := 10, 4
+	vp := newViewport(w, h)
+	vp.SetHeaderPanEnabled(true)
+	vp.SetHeader([]string{"col1      col2"})
+	setContent(&vp, []string{
+		"aaaaaaaaa bbbbbbbbb",
+		"ccc       ddd",
+	})
+	expectedView := testutil.Pad(vp.GetWidth(), vp.GetHeight(), []string{
+		"col1   ...",
+		"aaaaaaa...",
+		"ccc    ...",
+		"100% (2/2)",
+	})
+	testutil.CmpStr(t, expectedView, vp.View())
+
+	// header pans with content
+	vp.safelySetXOffset(10)
+	expectedView = testutil.Pad(vp.GetWidth(), vp.GetHeight(), []string{
+		"...l2",
+		"...bbbbbbb",
+		"...d",
+		"100% (2/2)",
+	})
+	testutil.CmpStr(t, expectedView, vp.View())
+
+	// header doesn't pan when wrapped
+	vp.SetWrapText(true)
+	expectedView = testutil.Pad(vp.GetWidth(), vp.GetHeight(), []string{
+		"col1      ",
+		"col2",
+		"aaaaaaaaa ",
+		"50% (1/2)",
+	})
+	testutil.CmpStr(t, expectedView, vp.View())
+}
+
+func TestViewport_SelectionOn_WrapOff_FrozenWidth(t *testing.T) {
+	w, h := 15, 6
+	vp := newViewport(w, h)
+	vp.SetSelectionEnabled(true)
+	vp.SetHeaderPanEnabled(true)
+	vp.SetFrozenWidth(5)
+	vp.SetStringToHighlight("ab")
+	vp.display.Styles.HighlightStyleIfSelected = lipgloss.NewStyle().Foreground(red)
+	vp.SetHeader([]string{"key  value"})
+	setContent(&vp, []string{
+		"k1   the first value is long",
+		"k2   ab the second value ab",
+		"k3",
+		"k4   short",
+	})
+	vp.SetSelectedItemIdx(1)
+	expectedView := testutil.Pad(vp.GetWidth(), vp.GetHeight(), []string{
+		"key  value",
+		"k1   the fir...",
+		"\x1b[38;2;0;0;255mk2   \x1b[m\x1b[38;2;255;0;0mab\x1b[m\x1b[38;2;0;0;255m the ...\x1b[m",
+		"k3",
+		"k4   short",
+		"50% (2/4)",
+	})
+	testutil.CmpStr(t, expectedView, vp.View())
+
+	// frozen cells stay in place while the rest pans, with continuation at the freeze boundary
+	vp.safelySetXOffset(3)
+	expectedView = testutil.Pad(vp.GetWidth(), vp.GetHeight(), []string{
+		"key  ..",
+		"k1   ...rst ...",
+		"\x1b[38;2;0;0;255mk2   ... sec...\x1b[m",
+		"k3",
+		"k4   ..",
+		"50% (2/4)",
+	})
+	testutil.CmpStr(t, expectedView, vp.View())
+
+	// highlight in panned region
+	vp.safelySetXOffset(13)
+	expectedView = testutil.Pad(vp.GetWidth(), vp.GetHeight(), []string{
+		"key  ...",
+		"k1   ...is long",
+		"\x1b[38;2;0;0;255mk2   ...lue \x1b[m\x1b[38;2;255;0;0mab\x1b[m",
+		"k3",
+		"k4   ...",
+		"50% (2/4)",
+	})
+	testutil.CmpStr(t, expectedView, vp.View())
+}
+
+func TestViewport_SelectionOn_WrapOn_Folding(t *testing.T) {
+	w, h := 15, 6
+	vp := newViewport(w, h)
+	vp.SetSelectionEnabled(true)
+	vp.SetWrapText(true)
+	vp.SetSelectionComparator(RenderableStringCompareFn)
+	content := []string{
+		"first",
+		"second line that wraps a lot over many lines",
+		"third",
+	}
+	setContent(&vp, content)
+	vp, _ = vp.Update(downKeyMsg)
+	expectedView := testutil.Pad(vp.GetWidth(), vp.GetHeight(), []string{
+		"first",
+		"\x1b[38;2;0;0;255msecond line tha\x1b[m",
+		"\x1b[38;2;0;0;255mt wraps a lot o\x1b[m",
+		"\x1b[38;2;0;0;255mver many lines\x1b[m",
+		"third",
+		"66% (2/3)",
+	})
+	testutil.CmpStr(t, expectedView, vp.View())
+
+	// fold with key sequence
+	vp, _ = vp.Update(zKeyMsg)
+	vp, _ = vp.Update(aKeyMsg)
+	if !vp.IsItemFolded(1) {
+		t.Fatalf("expected item 1 to be folded")
+	}
+	expectedView = testutil.Pad(vp.GetWidth(), vp.GetHeight(), []string{
+		"first",
+		"\x1b[38;2;0;0;255mseco [+2 lines]\x1b[m",
+		"third",
+	})
+	testutil.CmpStr(t, expectedView, vp.View())
+
+	// fold survives content refresh, following the item
+	setContent(&vp, append([]string{"zeroth"}, content...))
+	if !vp.IsItemFolded(2) || vp.IsItemFolded(1) {
+		t.Errorf("expected fold to move to item 2")
+	}
+	expectedView = testutil.Pad(vp.GetWidth(), vp.GetHeight(), []string{
+		"zeroth",
+		"first",
+		"\x1b[38;2;0;0;255mseco [+2 lines]\x1b[m",
+		"third",
+	})
+	testutil.CmpStr(t, expectedView, vp.View())
+
+	// unfold programmatically
+	vp.UnfoldItem(2)
+	expectedView = testutil.Pad(vp.GetWidth(), vp.GetHeight(), []string{
+		"zeroth",
+		"first",
+		"\x1b[38;2;0;0;255msecond line tha\x1b[m",
+		"\x1b[38;2;0;0;255mt wraps a lot o\x1b[m",
+		"\x1b[38;2;0;0;255mver many lines\x1b[m",
+		"75% (3/4)",
+	})
+	testutil.CmpStr(t, expectedView, vp.View())
+}
+
+func TestViewport_SelectionOn_WrapOn_FoldAllContentRefresh(t *testing.T) {
+	vp := newViewport(15, 6)
+	vp.SetSelectionEnabled(true)
+	vp.SetWrapText(true)
+	numCompares := 0
+	vp.SetSelectionComparator(func(a, b RenderableString) bool {
+		numCompares++
+		return RenderableStringCompareFn(a, b)
+	})
+	content := make([]string, 1000)
+	for i := range content {
+		content[i] = "item " + strconv.Itoa(i) + " wraps over a few lines"
+	}
+	setContent(&vp, content)
+	vp.FoldAll()
+	vp.UnfoldItem(5)
+
+	// refreshing content compares each item a bounded number of times rather than once per folded item
+	numCompares = 0
+	setContent(&vp, append([]string{"new item that wraps over a few lines"}, content...))
+	if maxCompares := 2 * (len(content) + 1); numCompares > maxCompares {
+		t.Errorf("expected at most %d comparisons, got %d", maxCompares, numCompares)
+	}
+
+	// the new item is folded like the rest, and the unfolded item stays unfolded
+	for _, tt := range []struct {
+		itemIdx        int
+		expectedFolded bool
+	}{
+		{itemIdx: 0, expectedFolded: true},
+		{itemIdx: 5, expectedFolded: true},
+		{itemIdx: 6, expectedFolded: false},
+		{itemIdx: 7, expectedFolded: true},
+	} {
+		if folded := vp.IsItemFolded(tt.itemIdx); folded != tt.expectedFolded {
+			t.Errorf("expected item %d folded %v, got %v", tt.itemIdx, tt.expectedFolded, folded)
+		}
+	}
+
+	vp.UnfoldAll()
+	setContent(&vp, content)
+	if vp.IsItemFolded(0) {
+		t.Errorf("expected no folded items after unfolding all")
+	}
+}
+
+func TestViewport_SelectionOff_WrapOn_FoldingScrollMath(t *testing.T) {
+	w, h := 10, 4
+	vp := newViewport(w, h)
+	vp.SetWrapText(true)
+	setContent(&vp, []string{
+		"a",
+		"b",
+		"c",
+		"1234567890abcdefghij1234567890",
+	})
+	vp.FoldItem(3)
+	vp, _ = vp.Update(goToBottomKeyMsg)
+
+	// folded height is used to find the bottom
+	expectedView := testutil.Pad(vp.GetWidth(), vp.GetHeight(), []string{
+		"b",
+		"c",
+		"[+2 lines]",
+		"100% (4/4)",
+	})
+	testutil.CmpStr(t, expectedView, vp.View())
+
+	// unfolding while scrolled to the bottom keeps a valid scroll position
+	vp.UnfoldAll()
+	vp, _ = vp.Update(goToBottomKeyMsg)
+	expectedView = testutil.Pad(vp.GetWidth(), vp.GetHeight(), []string{
+		"1234567890",
+		"abcdefghij",
+		"1234567890",
+		"100% (4/4)",
+	})
+	testutil.CmpStr(t, expectedView, vp.View())
+
+	// folding the partially scrolled top item resets its line offset
+	vp.SetHeight(3)
+	vp, _ = vp.Update(goToBottomKeyMsg)
+	expectedView = testutil.Pad(vp.GetWidth(), vp.GetHeight(), []string{
+		"abcdefghij",
+		"1234567890",
+		"100% (4/4)",
+	})
+	testutil.CmpStr(t, expectedView, vp.View())
+	vp.FoldAll()
+	expectedView = testutil.Pad(vp.GetWidth(), vp.GetHeight(), []string{
+		"c",
+		"[+2 lines]",
+		"100% (4/4)",
+	})
+	testutil.CmpStr(t, expectedView, vp.View())
+}
+
+func TestViewport_SelectionOff_WrapOff_ScrollPosition(t *testing.T) {
+	w, h := 10, 3
+	vp := newViewport(w, h)
+	setContent(&vp, []string{
+		"first line",
+		"second line",
+		"third",
+		"fourth",
+	})
+
+	vp.SetTopItemIdx(1, 0)
+	vp.SetXOffset(1)
+	if topItemIdx, lineOffset := vp.GetTopItemIdx(); topItemIdx != 1 || lineOffset != 0 {
+		t.Errorf("expected top item 1 with offset 0, got %d with offset %d", topItemIdx, lineOffset)
+	}
+	if xOffset := vp.GetXOffset(); xOffset != 1 {
+		t.Errorf("expected x offset 1, got %d", xOffset)
+	}
+	expectedView := testutil.Pad(vp.GetWidth(), vp.GetHeight(), []string{
+		"...nd line",
+		"...d",
+		"75% (3/4)",
+	})
+	testutil.CmpStr(t, expectedView, vp.View())
+
+	// positions past the end are clamped
+	vp.SetXOffset(10)
+	if xOffset := vp.GetXOffset(); xOffset != 1 {
+		t.Errorf("expected x offset 1, got %d", xOffset)
+	}
+	vp.SetTopItemIdx(10, 0)
+	if topItemIdx, _ := vp.GetTopItemIdx(); topItemIdx != 2 {
+		t.Errorf("expected top item 2, got %d", topItemIdx)
+	}
+}
+
+func TestViewport_SelectionOn_WrapOff_ScrollLink(t *testing.T) {
+	w, h := 10, 4
+	content := []string{"first", "second", "third", "fourth", "fifth", "sixth"}
+	a, b, other := newViewport(w, h), newViewport(w, h), newViewport(w, h)
+	for _, vp := range []*Model[RenderableString]{&a, &b, &other} {
+		vp.SetSelectionEnabled(true)
+		setContent(vp, content)
+	}
+
+	// unlinked viewports don't send messages
+	var cmd tea.Cmd
+	a, cmd = a.Update(downKeyMsg)
+	if cmd != nil {
+		t.Fatalf("expected no command from unlinked viewport")
+	}
+
+	a.SetScrollLinkGroup("logs")
+	b.SetScrollLinkGroup("logs")
+	other.SetScrollLinkGroup("other")
+	a, cmd = a.Update(downKeyMsg)
+	a, _ = a.Update(downKeyMsg)
+	a, cmd = a.Update(downKeyMsg)
+	if cmd == nil {
+		t.Fatalf("expected command from linked viewport")
+	}
+	msg, ok := cmd().(ScrollLinkMsg)
+	if !ok {
+		t.Fatalf("expected ScrollLinkMsg")
+	}
+
+	// linked viewports follow without sending messages of their own
+	b, cmd = b.Update(msg)
+	if cmd != nil {
+		t.Errorf("expected no command when following a linked viewport")
+	}
+	expectedView := testutil.Pad(w, h, []string{
+		"third",
+		"fourth",
+		"\x1b[38;2;0;0;255mfifth\x1b[m",
+		"83% (5/6)",
+	})
+	testutil.CmpStr(t, expectedView, a.View())
+	testutil.CmpStr(t, expectedView, b.View())
+
+	// viewports in other groups ignore the message
+	other, _ = other.Update(msg)
+	if other.GetSelectedItemIdx() != 0 {
+		t.Errorf("expected viewport in other group not to follow, got selection %d", other.GetSelectedItemIdx())
+	}
+
+	// the sending viewport ignores its own message
+	a.SetSelectedItemIdx(0)
+	a, _ = a.Update(msg)
+	if a.GetSelectedItemIdx() != 0 {
+		t.Errorf("expected viewport to ignore its own message, got selection %d", a.GetSelectedItemIdx())
+	}
+
+	// nothing is sent if the position doesn't change
+	a, cmd = a.Update(upKeyMsg)
+	if cmd != nil {
+		t.Errorf("expected no command when position didn't change")
+	}
+}
+
+func TestViewport_SelectionOn_WrapOff_ScrollLinkByKey(t *testing.T) {
+	w, h := 10, 4
+	keyFn := func(item RenderableString) string {
+		return item.Render().Content()
+	}
+	a, b := newViewport(w, h), newViewport(w, h)
+	a.SetSelectionEnabled(true)
+	b.SetSelectionEnabled(true)
+	setContent(&a, []string{"a", "b", "c", "d"})
+	setContent(&b, []string{"d", "x", "c", "b", "a"})
+	a.SetScrollLinkGroup("items")
+	b.SetScrollLinkGroup("items")
+	a.SetScrollLinkKeyFunc(keyFn)
+	b.SetScrollLinkKeyFunc(keyFn)
+
+	a, _ = a.Update(downKeyMsg)
+	a, cmd := a.Update(downKeyMsg)
+	b, _ = b.Update(cmd())
+	if item := b.GetSelectedItem(); item == nil || item.Render().Content() != "c" {
+		t.Fatalf("expected c to be selected, got %v", item)
+	}
+	// the top item of A is a, which is at the bottom of B
+	expectedView := testutil.Pad(w, h, []string{
+		"\x1b[38;2;0;0;255mc\x1b[m",
+		"b",
+		"a",
+		"60% (3/5)",
+	})
+	testutil.CmpStr(t, expectedView, b.View())
+
+	// A follows B by item too
+	b, cmd = b.Update(downKeyMsg)
+	a, _ = a.Update(cmd())
+	if item := a.GetSelectedItem(); item == nil || item.Render().Content() != "b" {
+		t.Errorf("expected b to be selected, got %v", item)
+	}
+}
+
+func TestViewport_SelectionOn_WrapOff_Focus(t *testing.T) {
+	w, h := 10, 5
+	vp := newViewport(w, h)
+	vp.SetSelectionEnabled(true)
+	vp.SetCountPrefixEnabled(true)
+	setContent(&vp, []string{"first", "second", "third"})
+	if !vp.Focused() {
+		t.Fatalf("expected viewport to start focused")
+	}
+
+	// blurring without blurred styles keeps the styles
+	vp.Blur()
+	expectedView := testutil.Pad(vp.GetWidth(), vp.GetHeight(), []string{
+		"\x1b[38;2;0;0;255mfirst\x1b[m",
+		"second",
+		"third",
+	})
+	testutil.CmpStr(t, expectedView, vp.View())
+
+	// blurred viewports ignore keys and render with the blurred styles
+	vp.SetBlurredStyles(Styles{SelectedItemStyle: lipgloss.NewStyle().Foreground(red)})
+	vp, _ = vp.Update(downKeyMsg)
+	if vp.Focused() || vp.GetSelectedItemIdx() != 0 {
+		t.Errorf("expected blurred viewport to ignore keys, got selection %d", vp.GetSelectedItemIdx())
+	}
+	expectedView = testutil.Pad(vp.GetWidth(), vp.GetHeight(), []string{
+		"\x1b[38;2;255;0;0mfirst\x1b[m",
+		"second",
+		"third",
+	})
+	testutil.CmpStr(t, expectedView, vp.View())
+
+	// blurring discards a partially typed count
+	vp.Focus()
+	vp, _ = vp.Update(tea.KeyPressMsg{Code: '2', Text: "2"})
+	vp.Blur()
+	vp.Focus()
+	vp, _ = vp.Update(downKeyMsg)
+	expectedView = testutil.Pad(vp.GetWidth(), vp.GetHeight(), []string{
+		"first",
+		"\x1b[38;2;0;0;255msecond\x1b[m",
+		"third",
+	})
+	testutil.CmpStr(t, expectedView, vp.View())
+}
+
+func TestViewport_SelectionOn_CycleMatches(t *testing.T) {
+	w, h := 15, 4
+	vp := newViewport(w, h)
+	vp.SetSelectionEnabled(true)
+	setContent(&vp, []string{
+		"first",
+		"second",
+		"third",
+		"fourth",
+		"fifth",
+	})
+
+	// nothing to highlight
+	if vp.NextMatch() || vp.PrevMatch() {
+		t.Errorf("expected no match without a highlight")
+	}
+
+	vp.SetStringToHighlight("f")
+	for _, expectedIdx := range []int{3, 4, 0, 3} {
+		if !vp.NextMatch() {
+			t.Errorf("expected a match")
+		}
+		if selectedItemIdx := vp.GetSelectedItemIdx(); selectedItemIdx != expectedIdx {
+			t.Errorf("expected selected item index to be %d, got %v", expectedIdx, selectedItemIdx)
+		}
+	}
+
+	vp.SetRegexToHighlight(regexp.MustCompile("^(s|t)"))
+	for _, expectedIdx := range []int{2, 1, 2} {
+		if !vp.PrevMatch() {
+			t.Errorf("expected a match")
+		}
+		if selectedItemIdx := vp.GetSelectedItemIdx(); selectedItemIdx != expectedIdx {
+			t.Errorf("expected selected item index to be %d, got %v", expectedIdx, selectedItemIdx)
+		}
+	}
+
+	vp.SetStringToHighlight("sixth")
+	if vp.NextMatch() {
+		t.Errorf("expected no match")
+	}
+	if selectedItemIdx := vp.GetSelectedItemIdx(); selectedItemIdx != 2 {
+		t.Errorf("expected selection to stay at 2, got %v", selectedItemIdx)
+	}
+}
+
+func TestViewport_BackgroundSearch(t *testing.T) {
+	vp := newViewport(15, 4)
+	vp.SetSelectionEnabled(true)
+	vp.SetSearchBatchSize(10)
+	var content []string
+	for i := range 25 {
+		content = append(content, fmt.Sprintf("item %d", i))
+	}
+	setContent(&vp, content)
+	vp.SetStringToHighlight("2")
+
+	var progress [][]int
+	cmd := vp.StartSearch()
+	if !vp.IsSearching() {
+		t.Fatalf("expected search in progress")
+	}
+	for cmd != nil {
+		msg, ok := cmd().(SearchProgressMsg)
+		if !ok {
+			t.Fatalf("expected a SearchProgressMsg")
+		}
+		progress = append(progress, []int{msg.NumScanned, msg.NumItems, msg.NumMatches})
+		vp, cmd = vp.Update(msg)
+	}
+	testutil.CmpStr(t, "[[10 25 1] [20 25 2] [25 25 7]]", fmt.Sprint(progress))
+	testutil.CmpStr(t, "[2 12 20 21 22 23 24]", fmt.Sprint(vp.GetSearchMatches()))
+	if numScanned, numItems, done := vp.GetSearchProgress(); numScanned != 25 || numItems != 25 || !done || vp.IsSearching() {
+		t.Errorf("expected finished search of 25 items, got %d of %d, done %v", numScanned, numItems, done)
+	}
+
+	// jumps use the search results
+	for _, expectedIdx := range []int{2, 12, 20} {
+		vp.NextMatch()
+		if selectedItemIdx := vp.GetSelectedItemIdx(); selectedItemIdx != expectedIdx {
+			t.Errorf("expected selected item index to be %d, got %v", expectedIdx, selectedItemIdx)
+		}
+	}
+	for _, expectedIdx := range []int{12, 2, 24} {
+		vp.PrevMatch()
+		if selectedItemIdx := vp.GetSelectedItemIdx(); selectedItemIdx != expectedIdx {
+			t.Errorf("expected selected item index to be %d, got %v", expectedIdx, selectedItemIdx)
+		}
+	}
+
+	// changing the highlight discards the results
+	vp.SetStringToHighlight("1")
+	if matches := vp.GetSearchMatches(); len(matches) != 0 {
+		t.Errorf("expected no matches, got %v", matches)
+	}
+	if _, _, done := vp.GetSearchProgress(); done {
+		t.Errorf("expected no finished search")
+	}
+}
+
+func TestViewport_BackgroundSearch_Cancel(t *testing.T) {
+	tests := []struct {
+		name   string
+		change func(vp *Model[RenderableString])
+	}{
+		{
+			name:   "cancel",
+			change: func(vp *Model[RenderableString]) { vp.CancelSearch() },
+		},
+		{
+			name:   "highlight",
+			change: func(vp *Model[RenderableString]) { vp.SetStringToHighlight("3") },
+		},
+		{
+			name:   "case mode",
+			change: func(vp *Model[RenderableString]) { vp.SetHighlightCaseMode(linebuffer.CaseInsensitive) },
+		},
+		{
+			name:   "content",
+			change: func(vp *Model[RenderableString]) { setContent(vp, []string{"item 2"}) },
+		},
+		{
+			name:   "restart",
+			change: func(vp *Model[RenderableString]) { _ = vp.StartSearch() },
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			vp := newViewport(15, 4)
+			vp.SetSearchBatchSize(10)
+			var content []string
+			for i := range 25 {
+				content = append(content, fmt.Sprintf("item %d", i))
+			}
+			setContent(&vp, content)
+			vp.SetStringToHighlight("2")
+
+			cmd := vp.StartSearch()
+			msg := cmd()
+			tt.change(&vp)
+			vp, cmd = vp.Update(msg)
+			if cmd != nil {
+				t.Errorf("expected no command after a stale message")
+			}
+			if matches := vp.GetSearchMatches(); len(matches) != 0 {
+				t.Errorf("expected no matches, got %v", matches)
+			}
+		})
+	}
+}
+
+func TestViewport_BackgroundSearch_SameHighlight(t *testing.T) {
+	tests := []struct {
+		name   string
+		regex  bool
+		change func(vp *Model[RenderableString])
+	}{
+		{
+			name:   "highlight",
+			change: func(vp *Model[RenderableString]) { vp.SetStringToHighlight("2") },
+		},
+		{
+			name:   "regex",
+			regex:  true,
+			change: func(vp *Model[RenderableString]) { vp.SetRegexToHighlight(regexp.MustCompile("2")) },
+		},
+		{
+			name:   "case mode",
+			change: func(vp *Model[RenderableString]) { vp.SetHighlightCaseMode(linebuffer.CaseSensitive) },
+		},
+		{
+			name:   "match mode",
+			change: func(vp *Model[RenderableString]) { vp.SetHighlightMatchMode(linebuffer.MatchSubstring) },
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			vp := newViewport(15, 4)
+			vp.SetSearchBatchSize(10)
+			var content []string
+			for i := range 25 {
+				content = append(content, fmt.Sprintf("item %d", i))
+			}
+			setContent(&vp, content)
+			if tt.regex {
+				vp.SetRegexToHighlight(regexp.MustCompile("2"))
+			} else {
+				vp.SetStringToHighlight("2")
+			}
+
+			cmd := vp.StartSearch()
+			for cmd != nil {
+				vp, cmd = vp.Update(cmd())
+			}
+			tt.change(&vp)
+			testutil.CmpStr(t, "[2 12 20 21 22 23 24]", fmt.Sprint(vp.GetSearchMatches()))
+			if numScanned, numItems, done := vp.GetSearchProgress(); numScanned != 25 || numItems != 25 || !done {
+				t.Errorf("expected finished search of 25 items, got %d of %d, done %v", numScanned, numItems, done)
+			}
+		})
+	}
+}
+
+func TestViewport_SelectionOn_ItemStyleFunc(t *testing.T) {
+	stripe := "\x1b[48;2;0;255;0m"
+	tests := []struct {
+		name     string
+		wrapText bool
+		xOffset  int
+		expected []string
+	}{
+		{
+			name:     "wrap off",
+			wrapText: false,
+			expected: []string{
+				"\x1b[38;2;0;0;255mfirst\x1b[m      ",
+				stripe + "sec\x1b[38;2;255;0;0mo\x1b[m" + stripe + "nd l...\x1b[m",
+				"third      ",
+				"33% (1/3)  ",
+			},
+		},
+		{
+			name:     "wrap off panned",
+			wrapText: false,
+			xOffset:  5,
+			expected: []string{
+				"\x1b[38;2;0;0;255m...t\x1b[m       ",
+				stripe + "..\x1b[38;2;255;0;0m.\x1b[m" + stripe + "nd \x1b[38;2;255;0;0mline\x1b[m" + stripe + "s\x1b[m",
+				"...d       ",
+				"33% (1/3)  ",
+			},
+		},
+		{
+			name:     "wrap on",
+			wrapText: true,
+			expected: []string{
+				"\x1b[38;2;0;0;255mfirst\x1b[m      ",
+				stripe + "sec\x1b[38;2;255;0;0mo\x1b[m" + stripe + "nd \x1b[38;2;255;0;0mline\x1b[m",
+				stripe + "s          \x1b[m",
+				"33% (1/3)  ",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			vp := newViewport(11, 4)
+			vp.SetSelectionEnabled(true)
+			vp.SetWrapText(tt.wrapText)
+			vp.SetStyles(Styles{
+				FooterStyle:              lipgloss.NewStyle(),
+				HighlightStyle:           lipgloss.NewStyle().Foreground(red),
+				HighlightStyleIfSelected: lipgloss.NewStyle().Foreground(red),
+				SelectedItemStyle:        selectionStyle,
+			})
+			vp.SetItemStyleFunc(func(item RenderableString, idx int, selected bool) lipgloss.Style {
+				if idx%2 == 1 && !selected {
+					return lipgloss.NewStyle().Background(green)
+				}
+				return lipgloss.NewStyle()
+			})
+			setContent(&vp, []string{
+				"first",
+				"sec" + lipgloss.NewStyle().Foreground(red).Render("o") + "nd lines",
+				"third",
+			})
+			vp.SetStringToHighlight("line")
+			vp.SetXOffset(tt.xOffset)
+			testutil.CmpStr(t, strings.Join(tt.expected, "\n"), vp.View())
+		})
+	}
+}
+
+func TestViewport_SelectionOn_FullWidthSelection(t *testing.T) {
+	selected := func(s string) string { return selectionStyle.Render(s) }
+	tests := []struct {
+		name     string
+		wrapText bool
+		xOffset  int
+		selected int
+		expected []string
+	}{
+		{
+			name:     "wrap off",
+			selected: 0,
+			expected: []string{
+				selected("first") + selected("     "),
+				"second ...",
+				"",
+				"33% (1/3)",
+			},
+		},
+		{
+			name:     "wrap off empty",
+			selected: 2,
+			expected: []string{
+				"first",
+				"second ...",
+				selected(" ") + selected("         "),
+				"100% (3/3)",
+			},
+		},
+		{
+			name:     "wrap off panned",
+			xOffset:  1,
+			selected: 0,
+			expected: []string{
+				selected("...t") + selected("      "),
+				"...nd line",
+				"",
+				"33% (1/3)",
+			},
+		},
+		{
+			name:     "wrap on",
+			wrapText: true,
+			selected: 1,
+			expected: []string{
+				"first",
+				selected("second lin"),
+				selected("e") + selected("         "),
+				"66% (2/3)",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			vp := newViewport(10, 4)
+			vp.SetSelectionEnabled(true)
+			vp.SetFullWidthSelection(true)
+			vp.SetWrapText(tt.wrapText)
+			vp.SetStyles(Styles{
+				FooterStyle:              lipgloss.NewStyle(),
+				HighlightStyle:           lipgloss.NewStyle(),
+				HighlightStyleIfSelected: lipgloss.NewStyle(),
+				SelectedItemStyle:        selectionStyle,
+			})
+			setContent(&vp, []string{
+				"first",
+				"second line",
+				"",
+			})
+			vp.SetSelectedItemIdx(tt.selected)
+			vp.SetXOffset(tt.xOffset)
+			view := vp.View()
+			testutil.CmpStr(t, testutil.Pad(vp.GetWidth(), vp.GetHeight(), tt.expected), view)
+			for i, line := range strings.Split(view, "\n") {
+				if w := lipgloss.Width(line); w != vp.GetWidth() {
+					t.Errorf("line %d: expected width %d, got %d", i, vp.GetWidth(), w)
+				}
+			}
+		})
+	}
 }